@@ -3,6 +3,7 @@ package api
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
 	db "simplebank/db/sqlc"
 	"simplebank/token"
@@ -13,6 +14,8 @@ import (
 
 type createAccountRequest struct {
 	Currency string `json:"currency" binding:"required,currency"`
+	// Product defaults to the current account without interest
+	Product string `json:"product"`
 }
 
 // @Summary      CreateAccount
@@ -22,11 +25,13 @@ type createAccountRequest struct {
 // @Description  Create new account
 // @Accept       json
 // @Produce      json
-// @Param        input  body      createAccountRequest  true  "currency"
-// @Success      200    {object}  db.Account
-// @Failure      400    {object}  errorResponse
-// @Failure      403    {object}  errorResponse
-// @Failure      500    {object}  errorResponse
+// @Param        input            body      createAccountRequest  true   "currency and product"
+// @Param        Idempotency-Key  header    string                false  "Key to safely retry the request"
+// @Success      200              {object}  db.Account
+// @Failure      400              {object}  errorResponse
+// @Failure      403              {object}  errorResponse
+// @Failure      409              {object}  errorResponse
+// @Failure      500              {object}  errorResponse
 // @Router       /accounts [post]
 func (server *Server) createAccount(ctx *gin.Context) {
 	var req createAccountRequest
@@ -37,14 +42,38 @@ func (server *Server) createAccount(ctx *gin.Context) {
 
 	authPayload := ctx.MustGet(authPayloadKey).(*token.Payload)
 
-	arg := db.CreateAccountParams{
-		Owner:    authPayload.Username,
-		Balance:  0,
-		Currency: req.Currency,
+	idem, err := newIdempotencyParams(ctx, authPayload.Username, req)
+	if err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if idem != nil && server.replayIdempotentResponse(ctx, idem) {
+		return
+	}
+
+	product := req.Product
+	if product == "" {
+		product = db.ProductCurrent
+	} else if !server.validProduct(ctx, product) {
+		return
 	}
 
-	account, err := server.store.CreateAccount(ctx, arg)
+	arg := db.CreateAccountTxParams{
+		CreateAccountParams: db.CreateAccountParams{
+			Owner:    authPayload.Username,
+			Balance:  0,
+			Currency: req.Currency,
+			Product:  product,
+		},
+		Idempotency: idem,
+		Audit:       storeAudit(ctx),
+	}
+
+	account, err := server.store.CreateAccountTx(ctx, arg)
 	if err != nil {
+		if errors.Is(err, db.ErrDuplicateIdempotencyKey) && server.replayIdempotentResponse(ctx, idem) {
+			return
+		}
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code.Name() {
 			case "unique_violation", "foreign_key_violation":
@@ -55,10 +84,29 @@ func (server *Server) createAccount(ctx *gin.Context) {
 		NewError(ctx, http.StatusInternalServerError, err)
 		return
 	}
+	auditStored(ctx)
 
 	ctx.JSON(http.StatusOK, account)
 }
 
+// validProduct checks that customers can open accounts of the product
+func (server *Server) validProduct(ctx *gin.Context, code string) bool {
+	product, err := server.store.GetAccountProduct(ctx, code)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			NewError(ctx, http.StatusBadRequest, fmt.Errorf("unknown product %q", code))
+			return false
+		}
+		NewError(ctx, http.StatusInternalServerError, err)
+		return false
+	}
+	if product.Internal {
+		NewError(ctx, http.StatusBadRequest, fmt.Errorf("product %q is internal", code))
+		return false
+	}
+	return true
+}
+
 type getAccountRequest struct {
 	ID int64 `uri:"id" binding:"required,min=1"`
 }
@@ -84,22 +132,31 @@ func (server *Server) getAccount(ctx *gin.Context) {
 		return
 	}
 
-	account, err := server.store.GetAccount(ctx, req.ID)
+	account, valid := server.ownAccount(ctx, req.ID)
+	if !valid {
+		return
+	}
+	ctx.JSON(http.StatusOK, account)
+}
+
+// ownAccount loads an account and checks that it belongs to the authenticated user
+func (server *Server) ownAccount(ctx *gin.Context, id int64) (db.Account, bool) {
+	account, err := server.store.GetAccount(ctx, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			NewError(ctx, http.StatusNotFound, err)
-			return
+			return account, false
 		}
 		NewError(ctx, http.StatusInternalServerError, err)
-		return
+		return account, false
 	}
 	authPayload := ctx.MustGet(authPayloadKey).(*token.Payload)
 	if authPayload.Username != account.Owner {
 		err := errors.New("account doesn't belong to the authenticated user")
 		NewError(ctx, http.StatusUnauthorized, err)
-		return
+		return account, false
 	}
-	ctx.JSON(http.StatusOK, account)
+	return account, true
 }
 
 type listAccountRequest struct {
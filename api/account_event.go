@@ -0,0 +1,257 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	db "simplebank/db/sqlc"
+	"simplebank/util"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultHeartbeatInterval is used if ACCOUNT_EVENTS_HEARTBEAT isn't set
+const defaultHeartbeatInterval = 15 * time.Second
+
+// replayPageSize is the number of entries read at once when a stream resumes
+const replayPageSize = 100
+
+// maxSentEntries is the number of entry IDs a stream remembers to skip an entry sent already
+const maxSentEntries = 1000
+
+// names of the server-sent events
+const (
+	sseEntry     = "entry"
+	sseBalance   = "balance"
+	sseHeartbeat = "heartbeat"
+)
+
+type accountBalanceEvent struct {
+	AccountID        int64  `json:"account_id"`
+	Currency         string `json:"currency"`
+	Balance          int64  `json:"balance"`
+	AvailableBalance int64  `json:"available_balance"`
+	// BalanceDecimal and AvailableBalanceDecimal are the balances as decimal strings like "12.34"
+	BalanceDecimal          string `json:"balance_decimal,omitempty"`
+	AvailableBalanceDecimal string `json:"available_balance_decimal,omitempty"`
+}
+
+func newAccountBalanceEvent(accountID int64, currency string, balance, availableBalance int64) accountBalanceEvent {
+	return accountBalanceEvent{
+		AccountID:               accountID,
+		Currency:                currency,
+		Balance:                 balance,
+		AvailableBalance:        availableBalance,
+		BalanceDecimal:          util.FormatMoney(balance, currency),
+		AvailableBalanceDecimal: util.FormatMoney(availableBalance, currency),
+	}
+}
+
+// @Summary      StreamAccountEvents
+// @Security     ApiKeyAuth
+// @Tags         Account
+// @ID           stream-account-events
+// @Description  Stream the changes of an account as server-sent events. An entry event, with the entry ID as the event ID,
+// @Description  is sent once for every new entry and is followed by a balance event. Entries are sent as they arrive,
+// @Description  not necessarily in ID order. A hold changes the available balance only
+// @Description  and sends a balance event alone. A stream without Last-Event-ID starts with
+// @Description  a balance event, a stream with it first sends the entries after that ID. Heartbeat events keep the connection open
+// @Produce      text/event-stream
+// @Param        id             path      int     true   "Account ID"
+// @Param        Last-Event-ID  header    string  false  "ID of the last entry event received"
+// @Success      200            {string}  string  "event stream"
+// @Failure      400            {object}  errorResponse
+// @Failure      401            {object}  errorResponse
+// @Failure      404            {object}  errorResponse
+// @Failure      500            {object}  errorResponse
+// @Router       /accounts/{id}/events [get]
+func (server *Server) streamAccountEvents(ctx *gin.Context) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	var lastEventID int64
+	if header := ctx.GetHeader("Last-Event-ID"); header != "" {
+		id, err := strconv.ParseInt(header, 10, 64)
+		if err != nil || id < 0 {
+			err := errors.New("Last-Event-ID must be an entry ID")
+			NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		lastEventID = id
+	}
+
+	account, valid := server.ownAccount(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	// subscribe before reading the entries, so that none is committed in between unnoticed
+	sub := server.hub.Subscribe(account.ID)
+	defer sub.Close()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	stream := accountEventStream{
+		ctx:     ctx,
+		server:  server,
+		account: account,
+		lastID:  lastEventID,
+		floor:   lastEventID,
+	}
+
+	var err error
+	if lastEventID > 0 {
+		err = stream.replay()
+	} else {
+		err = stream.send("", sseBalance, newAccountBalanceEvent(account.ID, account.Currency, account.Balance, account.AvailableBalance))
+	}
+	if err != nil {
+		log.Printf("account [%d] events: %v", account.ID, err)
+		return
+	}
+
+	interval := server.config.AccountEventsHeartbeat
+	if interval <= 0 {
+		interval = defaultHeartbeatInterval
+	}
+	heartbeat := time.NewTicker(interval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case event := <-sub.Events():
+			// a pending signal means an entry before this one may be lost, so it can't move the cursor
+			err = stream.sendEvent(event, len(sub.Missed()) > 0)
+		case <-sub.Missed():
+			err = stream.replay()
+		case now := <-heartbeat.C:
+			err = stream.send("", sseHeartbeat, gin.H{"time": now.UTC()})
+		}
+		if err != nil {
+			log.Printf("account [%d] events: %v", account.ID, err)
+			return
+		}
+	}
+}
+
+// accountEventStream writes the events of an account to a server-sent events response
+type accountEventStream struct {
+	ctx     *gin.Context
+	server  *Server
+	account db.Account
+	// lastID is the cursor a replay reads after, every entry of the account up to it was sent.
+	// The entries of an account are committed in ID order, but an entry from the hub
+	// only moves it while no event was missed
+	lastID int64
+	// sent holds the IDs of the latest entries sent in ascending order, and every entry up to floor
+	// counts as sent. An entry may arrive both from the hub and a replay, and it is sent once
+	sent  []int64
+	floor int64
+}
+
+// sendEvent sends an entry received from the hub, if it wasn't sent yet, followed by the balance after it.
+// An event without an entry, of a hold, sends the balance only. Entries are sent in the order they arrive,
+// which may not be the order of their IDs
+func (stream *accountEventStream) sendEvent(event db.AccountEvent, missed bool) error {
+	if event.Entry != nil {
+		if stream.wasSent(event.Entry.ID) {
+			return nil
+		}
+		if err := stream.sendEntry(*event.Entry); err != nil {
+			return err
+		}
+		if !missed && event.Entry.ID > stream.lastID {
+			stream.lastID = event.Entry.ID
+		}
+	}
+	return stream.send("", sseBalance, newAccountBalanceEvent(stream.account.ID, stream.account.Currency, event.Balance, event.AvailableBalance))
+}
+
+// replay sends the entries after lastID from the database, followed by the current balance
+func (stream *accountEventStream) replay() error {
+	for {
+		entries, err := stream.server.store.ListEntriesAfter(stream.ctx, db.ListEntriesAfterParams{
+			AccountID: stream.account.ID,
+			AfterID:   stream.lastID,
+			Limit:     replayPageSize,
+		})
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if !stream.wasSent(entry.ID) {
+				if err := stream.sendEntry(entry); err != nil {
+					return err
+				}
+			}
+			stream.lastID = entry.ID
+		}
+		if len(entries) < replayPageSize {
+			break
+		}
+	}
+
+	account, err := stream.server.store.GetAccount(stream.ctx, stream.account.ID)
+	if err != nil {
+		return err
+	}
+	return stream.send("", sseBalance, newAccountBalanceEvent(account.ID, account.Currency, account.Balance, account.AvailableBalance))
+}
+
+func (stream *accountEventStream) sendEntry(entry db.Entry) error {
+	stream.markSent(entry.ID)
+	return stream.send(strconv.FormatInt(entry.ID, 10), sseEntry, entry)
+}
+
+// wasSent returns true if the entry was sent by this stream or before it resumed
+func (stream *accountEventStream) wasSent(id int64) bool {
+	if id <= stream.floor {
+		return true
+	}
+	i := sort.Search(len(stream.sent), func(i int) bool { return stream.sent[i] >= id })
+	return i < len(stream.sent) && stream.sent[i] == id
+}
+
+// markSent remembers the ID of an entry sent, forgetting the lowest one beyond maxSentEntries
+func (stream *accountEventStream) markSent(id int64) {
+	i := sort.Search(len(stream.sent), func(i int) bool { return stream.sent[i] >= id })
+	stream.sent = append(stream.sent, 0)
+	copy(stream.sent[i+1:], stream.sent[i:])
+	stream.sent[i] = id
+	if len(stream.sent) > maxSentEntries {
+		stream.floor = stream.sent[0]
+		stream.sent = stream.sent[1:]
+	}
+}
+
+// send writes one server-sent event and flushes it to the client
+func (stream *accountEventStream) send(id string, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	w := stream.ctx.Writer
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return err
+	}
+	w.Flush()
+	return nil
+}
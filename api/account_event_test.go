@@ -0,0 +1,303 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	mockdb "simplebank/db/mock"
+	db "simplebank/db/sqlc"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// sseEvent is a server-sent event read by readEvent
+type sseEvent struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// readEvent reads the next server-sent event of a stream
+func readEvent(t *testing.T, reader *bufio.Reader) sseEvent {
+	var event sseEvent
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return event
+		}
+		field, value := line, ""
+		if i := strings.Index(line, ": "); i >= 0 {
+			field, value = line[:i], line[i+2:]
+		}
+		switch field {
+		case "id":
+			event.ID = value
+		case "event":
+			event.Event = value
+		case "data":
+			event.Data = value
+		}
+	}
+}
+
+// openAccountEvents starts a stream of the account events, it is closed when the test ends
+func openAccountEvents(t *testing.T, server *Server, username string, accountID int64, lastEventID string) (*http.Response, *bufio.Reader) {
+	ts := httptest.NewServer(server.router)
+	t.Cleanup(ts.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	url := fmt.Sprintf("%s/accounts/%d/events", ts.URL, accountID)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		request.Header.Set("Last-Event-ID", lastEventID)
+	}
+	addAuthHeader(t, request, server.tokenMaker, authTypeBearer, username, time.Minute)
+
+	response, err := ts.Client().Do(request)
+	require.NoError(t, err)
+	t.Cleanup(func() { response.Body.Close() })
+	return response, bufio.NewReader(response.Body)
+}
+
+func requireBalanceEvent(t *testing.T, event sseEvent, balance int64) {
+	require.Equal(t, sseBalance, event.Event)
+	require.Empty(t, event.ID)
+
+	var data accountBalanceEvent
+	require.NoError(t, json.Unmarshal([]byte(event.Data), &data))
+	require.Equal(t, balance, data.Balance)
+}
+
+func requireEntryEvent(t *testing.T, event sseEvent, entry db.Entry) {
+	require.Equal(t, sseEntry, event.Event)
+	require.Equal(t, fmt.Sprint(entry.ID), event.ID)
+
+	var data db.Entry
+	require.NoError(t, json.Unmarshal([]byte(event.Data), &data))
+	require.Equal(t, entry.ID, data.ID)
+	require.Equal(t, entry.Amount, data.Amount)
+}
+
+func TestStreamAccountEventsAPI(t *testing.T) {
+	user, _ := generateRandomUser(t)
+	account := generateRandomAccount(user.Username)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+	server := newTestServer(t, store)
+	response, reader := openAccountEvents(t, server, user.Username, account.ID, "")
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+
+	requireBalanceEvent(t, readEvent(t, reader), account.Balance)
+
+	// the stream is subscribed once the first event is sent
+	entry := db.Entry{ID: 42, AccountID: account.ID, Amount: 10}
+	server.hub.Publish(db.AccountEvent{AccountID: account.ID + 1, Entry: &db.Entry{ID: 41}})
+	server.hub.Publish(db.AccountEvent{
+		AccountID:        account.ID,
+		Entry:            &entry,
+		Balance:          account.Balance + 10,
+		AvailableBalance: account.AvailableBalance + 10,
+	})
+
+	requireEntryEvent(t, readEvent(t, reader), entry)
+	requireBalanceEvent(t, readEvent(t, reader), account.Balance+10)
+
+	// a hold changes the available balance only
+	server.hub.Publish(db.AccountEvent{
+		AccountID:        account.ID,
+		Balance:          account.Balance + 10,
+		AvailableBalance: account.AvailableBalance,
+	})
+	requireBalanceEvent(t, readEvent(t, reader), account.Balance+10)
+}
+
+func TestStreamAccountEventsResumeAPI(t *testing.T) {
+	user, _ := generateRandomUser(t)
+	account := generateRandomAccount(user.Username)
+	entries := []db.Entry{
+		{ID: 6, AccountID: account.ID, Amount: 10},
+		{ID: 7, AccountID: account.ID, Amount: -3},
+	}
+	current := account
+	current.Balance += 7
+	missed := db.Entry{ID: 9, AccountID: account.ID, Amount: 1}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	gomock.InOrder(
+		store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil),
+		store.EXPECT().ListEntriesAfter(gomock.Any(), gomock.Eq(db.ListEntriesAfterParams{
+			AccountID: account.ID,
+			AfterID:   5,
+			Limit:     replayPageSize,
+		})).Times(1).Return(entries, nil),
+		store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(current, nil),
+		// the hub lost the connection, the entries after the last one sent are read again
+		store.EXPECT().ListEntriesAfter(gomock.Any(), gomock.Eq(db.ListEntriesAfterParams{
+			AccountID: account.ID,
+			AfterID:   8,
+			Limit:     replayPageSize,
+		})).Times(1).Return([]db.Entry{missed}, nil),
+		store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(current, nil),
+	)
+
+	server := newTestServer(t, store)
+	_, reader := openAccountEvents(t, server, user.Username, account.ID, "5")
+
+	requireEntryEvent(t, readEvent(t, reader), entries[0])
+	requireEntryEvent(t, readEvent(t, reader), entries[1])
+	requireBalanceEvent(t, readEvent(t, reader), current.Balance)
+
+	// an entry that was already replayed isn't sent again
+	server.hub.Publish(db.AccountEvent{AccountID: account.ID, Entry: &entries[1], Balance: current.Balance})
+	live := db.Entry{ID: 8, AccountID: account.ID, Amount: 2}
+	server.hub.Publish(db.AccountEvent{AccountID: account.ID, Entry: &live, Balance: current.Balance + 2})
+
+	requireEntryEvent(t, readEvent(t, reader), live)
+	requireBalanceEvent(t, readEvent(t, reader), current.Balance+2)
+
+	server.hub.Reset()
+	requireEntryEvent(t, readEvent(t, reader), missed)
+	requireBalanceEvent(t, readEvent(t, reader), current.Balance)
+}
+
+func TestStreamAccountEventsOutOfOrderAPI(t *testing.T) {
+	user, _ := generateRandomUser(t)
+	account := generateRandomAccount(user.Username)
+	earlier := db.Entry{ID: 11, AccountID: account.ID, Amount: 5}
+	later := db.Entry{ID: 12, AccountID: account.ID, Amount: 3}
+	missed := db.Entry{ID: 13, AccountID: account.ID, Amount: 1}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	gomock.InOrder(
+		store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil),
+		// both entries were sent, so the replay reads after the highest one and skips it
+		store.EXPECT().ListEntriesAfter(gomock.Any(), gomock.Eq(db.ListEntriesAfterParams{
+			AccountID: account.ID,
+			AfterID:   12,
+			Limit:     replayPageSize,
+		})).Times(1).Return([]db.Entry{later, missed}, nil),
+		store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil),
+	)
+
+	server := newTestServer(t, store)
+	_, reader := openAccountEvents(t, server, user.Username, account.ID, "")
+	requireBalanceEvent(t, readEvent(t, reader), account.Balance)
+
+	// the entry with the higher ID arrives first, the other one is still sent
+	server.hub.Publish(db.AccountEvent{AccountID: account.ID, Entry: &later, Balance: account.Balance + 3})
+	server.hub.Publish(db.AccountEvent{AccountID: account.ID, Entry: &earlier, Balance: account.Balance + 8})
+
+	requireEntryEvent(t, readEvent(t, reader), later)
+	requireBalanceEvent(t, readEvent(t, reader), account.Balance+3)
+	requireEntryEvent(t, readEvent(t, reader), earlier)
+	requireBalanceEvent(t, readEvent(t, reader), account.Balance+8)
+
+	// an entry that arrives again isn't sent twice, the hold after it is
+	server.hub.Publish(db.AccountEvent{AccountID: account.ID, Entry: &later, Balance: account.Balance + 8})
+	server.hub.Publish(db.AccountEvent{AccountID: account.ID, Balance: account.Balance + 8})
+	requireBalanceEvent(t, readEvent(t, reader), account.Balance+8)
+
+	server.hub.Reset()
+	requireEntryEvent(t, readEvent(t, reader), missed)
+	requireBalanceEvent(t, readEvent(t, reader), account.Balance)
+}
+
+func TestStreamAccountEventsHeartbeatAPI(t *testing.T) {
+	user, _ := generateRandomUser(t)
+	account := generateRandomAccount(user.Username)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+	server := newTestServer(t, store)
+	server.config.AccountEventsHeartbeat = 10 * time.Millisecond
+	_, reader := openAccountEvents(t, server, user.Username, account.ID, "")
+
+	requireBalanceEvent(t, readEvent(t, reader), account.Balance)
+	require.Equal(t, sseHeartbeat, readEvent(t, reader).Event)
+	require.Equal(t, sseHeartbeat, readEvent(t, reader).Event)
+}
+
+func TestStreamAccountEventsErrorsAPI(t *testing.T) {
+	user, _ := generateRandomUser(t)
+	account := generateRandomAccount(user.Username)
+
+	testCases := []struct {
+		name        string
+		username    string
+		lastEventID string
+		buildStabs  func(store *mockdb.MockStore)
+		status      int
+	}{
+		{
+			name:     "UnauthorizedUser",
+			username: "unauthorized_user",
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ListEntriesAfter(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:        "InvalidLastEventID",
+			username:    user.Username,
+			lastEventID: "abc",
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStabs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/events", account.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+			if tc.lastEventID != "" {
+				request.Header.Set("Last-Event-ID", tc.lastEventID)
+			}
+
+			addAuthHeader(t, request, server.tokenMaker, authTypeBearer, tc.username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, tc.status, recorder.Code)
+		})
+	}
+}
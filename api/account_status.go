@@ -0,0 +1,82 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	db "simplebank/db/sqlc"
+	"simplebank/token"
+
+	"github.com/gin-gonic/gin"
+)
+
+type changeAccountStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=active frozen closed"`
+	// SweepAccountID receives the balance when closing an account that isn't empty
+	SweepAccountID int64 `json:"sweep_account_id" binding:"min=0"`
+}
+
+// @Summary      ChangeAccountStatus
+// @Security     ApiKeyAuth
+// @Tags         Admin
+// @ID           change-account-status
+// @Description  Freeze, unfreeze or close an account. A frozen account can't pay, a closed one can't move money.
+// @Description  Closing an account with a balance sweeps it to sweep_account_id, another account of the owner in the same currency. Admins only
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                         true  "Account ID"
+// @Param        input  body      changeAccountStatusRequest  true  "status and sweep account"
+// @Success      200    {object}  db.ChangeAccountStatusTxResult
+// @Failure      400    {object}  errorResponse
+// @Failure      401    {object}  errorResponse
+// @Failure      404    {object}  errorResponse
+// @Failure      422    {object}  errorResponse
+// @Failure      500    {object}  errorResponse
+// @Router       /accounts/{id}/status [post]
+func (server *Server) changeAccountStatus(ctx *gin.Context) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	var req changeAccountStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*token.Payload)
+	admin, err := server.isAdmin(ctx, authPayload.Username)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if !admin {
+		err := errors.New("only an admin can change the status of an account")
+		NewError(ctx, http.StatusUnauthorized, err)
+		return
+	}
+
+	result, err := server.store.ChangeAccountStatusTx(ctx, db.ChangeAccountStatusTxParams{
+		AccountID:      uri.ID,
+		Status:         req.Status,
+		SweepAccountID: req.SweepAccountID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			NewError(ctx, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, db.ErrInvalidStatusChange) {
+			NewErrorWithCode(ctx, http.StatusUnprocessableEntity, errCodeInvalidStatusChange, err)
+			return
+		}
+		if accountStatusError(ctx, err) {
+			return
+		}
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
@@ -160,18 +160,84 @@ func TestCreateAccountAPI(t *testing.T) {
 				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
 			},
 			buildStabs: func(store *mockdb.MockStore) {
-				arg := db.CreateAccountParams{
-					Owner:    account.Owner,
-					Balance:  0,
-					Currency: account.Currency,
+				arg := db.CreateAccountTxParams{
+					CreateAccountParams: db.CreateAccountParams{
+						Owner:    account.Owner,
+						Balance:  0,
+						Currency: account.Currency,
+						Product:  db.ProductCurrent,
+					},
 				}
-				store.EXPECT().CreateAccount(gomock.Any(), gomock.Eq(arg)).Times(1).Return(account, nil)
+				store.EXPECT().CreateAccountTx(gomock.Any(), EqAudited(arg, "createAccount")).Times(1).Return(account, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 				requireBodyMatchAccount(t, recorder.Body, account)
 			},
 		},
+		{
+			name: "SavingsProduct",
+			body: gin.H{
+				"currency": account.Currency,
+				"product":  "savings",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				product := db.AccountProduct{Code: "savings", AnnualRate: "0.05", DayCount: "ACT/365"}
+				store.EXPECT().GetAccountProduct(gomock.Any(), gomock.Eq("savings")).Times(1).Return(product, nil)
+
+				arg := db.CreateAccountTxParams{
+					CreateAccountParams: db.CreateAccountParams{
+						Owner:    account.Owner,
+						Balance:  0,
+						Currency: account.Currency,
+						Product:  "savings",
+					},
+				}
+				store.EXPECT().CreateAccountTx(gomock.Any(), EqAudited(arg, "createAccount")).Times(1).Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAccount(t, recorder.Body, account)
+			},
+		},
+		{
+			name: "UnknownProduct",
+			body: gin.H{
+				"currency": account.Currency,
+				"product":  "unknown",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountProduct(gomock.Any(), gomock.Eq("unknown")).Times(1).Return(db.AccountProduct{}, sql.ErrNoRows)
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalProduct",
+			body: gin.H{
+				"currency": account.Currency,
+				"product":  db.ProductInterestExpense,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				product := db.AccountProduct{Code: db.ProductInterestExpense, DayCount: "ACT/365", Internal: true}
+				store.EXPECT().GetAccountProduct(gomock.Any(), gomock.Eq(db.ProductInterestExpense)).Times(1).Return(product, nil)
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name: "BadBody",
 			body: gin.H{
@@ -181,7 +247,7 @@ func TestCreateAccountAPI(t *testing.T) {
 				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
 			},
 			buildStabs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -196,7 +262,7 @@ func TestCreateAccountAPI(t *testing.T) {
 				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
 			},
 			buildStabs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, sql.ErrConnDone)
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, sql.ErrConnDone)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -210,7 +276,7 @@ func TestCreateAccountAPI(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 			},
 			buildStabs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
@@ -369,6 +435,8 @@ func generateRandomAccount(owner string) db.Account {
 		Owner:    owner,
 		Balance:  util.RandomMoney(),
 		Currency: util.RandomCurrency(),
+		Product:  db.ProductCurrent,
+		Status:   util.AccountActive,
 	}
 }
 
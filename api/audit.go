@@ -0,0 +1,218 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	db "simplebank/db/sqlc"
+	"simplebank/token"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	auditRecordKey  = "audit_record"
+	requestIDHeader = "X-Request-ID"
+)
+
+// maxRequestIDLength caps the length of a request ID sent by the client
+const maxRequestIDLength = 64
+
+// auditRecord collects what the audit log records about a request
+type auditRecord struct {
+	db.AuditParams
+	Target string
+	// stored is set when the store has written the record together with the change
+	stored bool
+}
+
+// auditMiddleware writes an audit log record for every state-changing request once it is handled,
+// unless the store has already written one in the transaction of the change. It also gives every
+// request an ID, the one sent in the X-Request-ID header or a new one, and returns it in the same header
+func auditMiddleware(store db.Store) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(requestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+		ctx.Header(requestIDHeader, requestID)
+
+		record := &auditRecord{
+			AuditParams: db.AuditParams{
+				IP:        ctx.ClientIP(),
+				UserAgent: ctx.Request.UserAgent(),
+				RequestID: requestID,
+				Action:    auditAction(ctx.HandlerName()),
+			},
+			Target: ctx.Request.URL.Path,
+		}
+		ctx.Set(auditRecordKey, record)
+
+		ctx.Next()
+
+		if !isStateChanging(ctx.Request.Method) || ctx.FullPath() == "" {
+			return
+		}
+		status := ctx.Writer.Status()
+		if record.stored && status < http.StatusBadRequest {
+			return
+		}
+
+		arg := db.CreateAuditLogParams{
+			Actor:     auditActor(ctx, record),
+			Ip:        record.IP,
+			UserAgent: record.UserAgent,
+			RequestID: record.RequestID,
+			Action:    record.Action,
+			Target:    record.Target,
+			Outcome:   db.AuditSuccess,
+		}
+		if status >= http.StatusBadRequest {
+			arg.Outcome = db.AuditFailure
+			arg.Detail = http.StatusText(status)
+			if err := ctx.Errors.Last(); err != nil {
+				arg.Detail += ": " + err.Error()
+			}
+		}
+		if _, err := store.CreateAuditLog(ctx, arg); err != nil {
+			log.Printf("audit log: cannot record %s of %s by %q: %v", arg.Action, arg.Target, arg.Actor, err)
+		}
+	}
+}
+
+// storeAudit returns the audit params for the store to record the change made by the request.
+// The handler must call auditStored once the store has made the change
+func storeAudit(ctx *gin.Context) *db.AuditParams {
+	record := ctx.MustGet(auditRecordKey).(*auditRecord)
+	audit := record.AuditParams
+	audit.Actor = auditActor(ctx, record)
+	return &audit
+}
+
+// auditStored tells the audit middleware that the store has recorded the request
+func auditStored(ctx *gin.Context) {
+	ctx.MustGet(auditRecordKey).(*auditRecord).stored = true
+}
+
+// setAuditActor records who made an unauthenticated request, such as the user logging in
+func setAuditActor(ctx *gin.Context, actor string) {
+	ctx.MustGet(auditRecordKey).(*auditRecord).Actor = actor
+}
+
+// setAuditTarget records the resource a request changed, if it isn't the path of the request
+func setAuditTarget(ctx *gin.Context, target string) {
+	ctx.MustGet(auditRecordKey).(*auditRecord).Target = target
+}
+
+// auditActor returns the authenticated user, or the actor set by the handler
+func auditActor(ctx *gin.Context, record *auditRecord) string {
+	if payload, ok := ctx.Get(authPayloadKey); ok {
+		return payload.(*token.Payload).Username
+	}
+	return record.Actor
+}
+
+// auditAction turns the name of a handler like "simplebank/api.(*Server).createTransfer-fm" into "createTransfer"
+func auditAction(handlerName string) string {
+	action := handlerName[strings.LastIndexByte(handlerName, '.')+1:]
+	return strings.TrimSuffix(action, "-fm")
+}
+
+func isStateChanging(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// validRequestID accepts short IDs of letters, digits, dashes, underscores and dots
+func validRequestID(id string) bool {
+	if len(id) == 0 || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+type listAuditLogRequest struct {
+	Actor     string `form:"actor"`
+	Action    string `form:"action"`
+	Target    string `form:"target"`
+	Outcome   string `form:"outcome" binding:"omitempty,oneof=success failure"`
+	RequestID string `form:"request_id"`
+	// From is included, To is not
+	From     time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To       time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	PageID   int32     `form:"page_id" binding:"required,min=1"`
+	PageSize int32     `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+// @Summary      ListAuditLog
+// @Security     ApiKeyAuth
+// @Tags         Admin
+// @ID           list-audit-log
+// @Description  List the audit log records that match all the filters, newest first. Only for admins
+// @Produce      json
+// @Param        actor       query     string  false  "Username of the caller"
+// @Param        action      query     string  false  "Action, e.g. createTransfer"
+// @Param        target      query     string  false  "Path of the resource, e.g. /accounts/1"
+// @Param        outcome     query     string  false  "success or failure"
+// @Param        request_id  query     string  false  "Request ID"
+// @Param        from        query     string  false  "RFC 3339 timestamp, included"
+// @Param        to          query     string  false  "RFC 3339 timestamp, excluded"
+// @Param        page_id     query     int     true   "Page ID"
+// @Param        page_size   query     int     true   "Page Size"
+// @Success      200         {array}   db.AuditLog
+// @Failure      400         {object}  errorResponse
+// @Failure      401         {object}  errorResponse
+// @Failure      500         {object}  errorResponse
+// @Router       /audit-log [get]
+func (server *Server) listAuditLog(ctx *gin.Context) {
+	var req listAuditLogRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*token.Payload)
+	admin, err := server.isAdmin(ctx, authPayload.Username)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if !admin {
+		err := errors.New("only an admin can read the audit log")
+		NewError(ctx, http.StatusUnauthorized, err)
+		return
+	}
+
+	arg := db.ListAuditLogsParams{
+		Actor:     req.Actor,
+		Action:    req.Action,
+		Target:    req.Target,
+		Outcome:   req.Outcome,
+		RequestID: req.RequestID,
+		FromTime:  req.From,
+		ToTime:    req.To,
+		Limit:     req.PageSize,
+		Offset:    (req.PageID - 1) * req.PageSize,
+	}
+	if arg.ToTime.IsZero() {
+		arg.ToTime = maxCursorTime
+	}
+	records, err := server.store.ListAuditLogs(ctx, arg)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, records)
+}
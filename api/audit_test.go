@@ -0,0 +1,321 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	mockdb "simplebank/db/mock"
+	db "simplebank/db/sqlc"
+	"simplebank/token"
+	"simplebank/util"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type eqAuditedMatcher struct {
+	arg    interface{}
+	action string
+}
+
+// Matches compares the arguments of a store transaction without its Audit field,
+// which must be set for the action with the request ID
+func (e eqAuditedMatcher) Matches(x interface{}) bool {
+	v := reflect.ValueOf(x)
+	if v.Type() != reflect.TypeOf(e.arg) {
+		return false
+	}
+
+	copied := reflect.New(v.Type()).Elem()
+	copied.Set(v)
+	field := copied.FieldByName("Audit")
+	audit, ok := field.Interface().(*db.AuditParams)
+	if !ok || audit == nil || audit.Action != e.action || len(audit.RequestID) == 0 {
+		return false
+	}
+	field.Set(reflect.Zero(field.Type()))
+
+	return reflect.DeepEqual(e.arg, copied.Interface())
+}
+
+func (e eqAuditedMatcher) String() string {
+	return fmt.Sprintf("matches arg %v audited as %s", e.arg, e.action)
+}
+
+func EqAudited(arg interface{}, action string) gomock.Matcher {
+	return eqAuditedMatcher{arg, action}
+}
+
+func TestAuditMiddleware(t *testing.T) {
+	user, password := generateRandomUser(t)
+	account := generateRandomAccount(user.Username)
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          gin.H
+		requestID     string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStabs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder, records []db.CreateAuditLogParams)
+	}{
+		{
+			name:      "StoredWithChange",
+			method:    http.MethodPost,
+			url:       "/accounts",
+			body:      gin.H{"currency": account.Currency},
+			requestID: "req-42",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateAccountTxParams) (db.Account, error) {
+						require.Equal(t, &db.AuditParams{
+							Actor:     user.Username,
+							IP:        "192.0.2.1",
+							UserAgent: "audit-test",
+							RequestID: "req-42",
+							Action:    "createAccount",
+						}, arg.Audit)
+						return account, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, records []db.CreateAuditLogParams) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "req-42", recorder.Header().Get(requestIDHeader))
+				require.Empty(t, records)
+			},
+		},
+		{
+			name:   "Failure",
+			method: http.MethodPost,
+			url:    "/accounts",
+			body:   gin.H{"currency": "XYZ"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, records []db.CreateAuditLogParams) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Len(t, records, 1)
+
+				record := records[0]
+				require.Equal(t, user.Username, record.Actor)
+				require.Equal(t, "createAccount", record.Action)
+				require.Equal(t, "/accounts", record.Target)
+				require.Equal(t, db.AuditFailure, record.Outcome)
+				require.Contains(t, record.Detail, http.StatusText(http.StatusBadRequest))
+				require.Equal(t, recorder.Header().Get(requestIDHeader), record.RequestID)
+			},
+		},
+		{
+			name:      "LoginOK",
+			method:    http.MethodPost,
+			url:       "/users/login",
+			body:      gin.H{"username": user.Username, "password": password},
+			requestID: "not a valid id",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, records []db.CreateAuditLogParams) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requestID := recorder.Header().Get(requestIDHeader)
+				_, err := uuid.Parse(requestID)
+				require.NoError(t, err)
+
+				require.Equal(t, []db.CreateAuditLogParams{{
+					Actor:     user.Username,
+					Ip:        "192.0.2.1",
+					UserAgent: "audit-test",
+					RequestID: requestID,
+					Action:    "loginUser",
+					Target:    "/users/" + user.Username,
+					Outcome:   db.AuditSuccess,
+				}}, records)
+			},
+		},
+		{
+			name:   "LoginWrongPassword",
+			method: http.MethodPost,
+			url:    "/users/login",
+			body:   gin.H{"username": user.Username, "password": "wrong-password"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, records []db.CreateAuditLogParams) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Len(t, records, 1)
+				require.Equal(t, user.Username, records[0].Actor)
+				require.Equal(t, "loginUser", records[0].Action)
+				require.Equal(t, db.AuditFailure, records[0].Outcome)
+			},
+		},
+		{
+			name:   "ReadNotRecorded",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/accounts/%d", account.ID),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, records []db.CreateAuditLogParams) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.NotEmpty(t, recorder.Header().Get(requestIDHeader))
+				require.Empty(t, records)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var records []db.CreateAuditLogParams
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).AnyTimes().
+				DoAndReturn(func(_ interface{}, arg db.CreateAuditLogParams) (db.AuditLog, error) {
+					records = append(records, arg)
+					return db.AuditLog{}, nil
+				})
+			tc.buildStabs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(tc.method, tc.url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.RemoteAddr = "192.0.2.1:4242"
+			request.Header.Set("User-Agent", "audit-test")
+			if len(tc.requestID) > 0 {
+				request.Header.Set(requestIDHeader, tc.requestID)
+			}
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder, records)
+		})
+	}
+}
+
+func TestListAuditLogAPI(t *testing.T) {
+	user, _ := generateRandomUser(t)
+	admin, _ := generateRandomUser(t)
+	admin.Role = util.AdminRole
+
+	records := []db.AuditLog{
+		{ID: 2, Actor: user.Username, Action: "createTransfer", Target: "/transfers/7", Outcome: db.AuditSuccess},
+		{ID: 1, Actor: user.Username, Action: "createTransfer", Target: "/transfers", Outcome: db.AuditFailure},
+	}
+
+	testCases := []struct {
+		name          string
+		query         url.Values
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStabs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			query: url.Values{
+				"actor":     {user.Username},
+				"action":    {"createTransfer"},
+				"page_id":   {"2"},
+				"page_size": {"5"},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthHeader(t, request, tokenMaker, authTypeBearer, admin.Username, time.Minute)
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+
+				arg := db.ListAuditLogsParams{
+					Actor:  user.Username,
+					Action: "createTransfer",
+					ToTime: maxCursorTime,
+					Limit:  5,
+					Offset: 5,
+				}
+				store.EXPECT().ListAuditLogs(gomock.Any(), gomock.Eq(arg)).Times(1).Return(records, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var resp []db.AuditLog
+				err := json.Unmarshal(recorder.Body.Bytes(), &resp)
+				require.NoError(t, err)
+				require.Equal(t, records, resp)
+			},
+		},
+		{
+			name:  "NotAdmin",
+			query: url.Values{"page_id": {"1"}, "page_size": {"5"}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().ListAuditLogs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidOutcome",
+			query: url.Values{"outcome": {"maybe"}, "page_id": {"1"}, "page_size": {"5"}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthHeader(t, request, tokenMaker, authTypeBearer, admin.Username, time.Minute)
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAuditLogs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStabs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/audit-log?"+tc.query.Encode(), nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
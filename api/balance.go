@@ -0,0 +1,213 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	db "simplebank/db/sqlc"
+	"simplebank/token"
+	"simplebank/util"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxBalanceHistoryDays caps the number of points returned by getBalanceHistory
+const maxBalanceHistoryDays = 366
+
+const dateFormat = "2006-01-02"
+
+type accountURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type getBalanceRequest struct {
+	// At defaults to now
+	At time.Time `form:"at" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+type balanceResponse struct {
+	AccountID int64  `json:"account_id"`
+	Currency  string `json:"currency"`
+	// MinorUnits is the number of decimals of the balance
+	MinorUnits *int32    `json:"minor_units,omitempty"`
+	At         time.Time `json:"at"`
+	Balance    int64     `json:"balance"`
+	// BalanceDecimal is the balance as a decimal string like "12.34"
+	BalanceDecimal string `json:"balance_decimal,omitempty"`
+}
+
+// @Summary      GetBalance
+// @Security     ApiKeyAuth
+// @Tags         Account
+// @ID           get-balance
+// @Description  Get the balance of an account at a point in time, computed from its entries. Available to the owner and to admins
+// @Produce      json
+// @Param        id   path      int     true   "Account ID"
+// @Param        at   query     string  false  "RFC 3339 timestamp, now by default"
+// @Success      200  {object}  balanceResponse
+// @Failure      400  {object}  errorResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      404  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Router       /accounts/{id}/balance [get]
+func (server *Server) getBalance(ctx *gin.Context) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	var req getBalanceRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if req.At.IsZero() {
+		req.At = time.Now()
+	}
+
+	account, valid := server.auditableAccount(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	// the balance at a moment includes the entries created at that moment,
+	// postgres keeps timestamps in microseconds
+	balance, err := server.store.GetBalanceBefore(ctx, db.GetBalanceBeforeParams{
+		AccountID: account.ID,
+		Before:    req.At.Add(time.Microsecond),
+	})
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, balanceResponse{
+		AccountID:      account.ID,
+		Currency:       account.Currency,
+		MinorUnits:     minorUnits(account.Currency),
+		At:             req.At,
+		Balance:        balance,
+		BalanceDecimal: util.FormatMoney(balance, account.Currency),
+	})
+}
+
+type getBalanceHistoryRequest struct {
+	From     time.Time `form:"from" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	To       time.Time `form:"to" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	Interval string    `form:"interval" binding:"omitempty,oneof=day"`
+}
+
+type balanceHistoryItem struct {
+	Date string `json:"date"`
+	// Balance at the end of the day
+	Balance int64 `json:"balance"`
+}
+
+// @Summary      GetBalanceHistory
+// @Security     ApiKeyAuth
+// @Tags         Account
+// @ID           get-balance-history
+// @Description  Get the closing balance of every UTC day between from and to, both included. Available to the owner and to admins
+// @Produce      json
+// @Param        id        path      int     true   "Account ID"
+// @Param        from      query     string  true   "First day, YYYY-MM-DD"
+// @Param        to        query     string  true   "Last day, YYYY-MM-DD"
+// @Param        interval  query     string  false  "Only day is supported"
+// @Success      200       {array}   balanceHistoryItem
+// @Failure      400       {object}  errorResponse
+// @Failure      401       {object}  errorResponse
+// @Failure      404       {object}  errorResponse
+// @Failure      500       {object}  errorResponse
+// @Router       /accounts/{id}/balance-history [get]
+func (server *Server) getBalanceHistory(ctx *gin.Context) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	var req getBalanceHistoryRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if req.To.Before(req.From) {
+		NewError(ctx, http.StatusBadRequest, errors.New("to is before from"))
+		return
+	}
+	days := int(req.To.Sub(req.From)/(24*time.Hour)) + 1
+	if days > maxBalanceHistoryDays {
+		NewError(ctx, http.StatusBadRequest, fmt.Errorf("the history is limited to %d days", maxBalanceHistoryDays))
+		return
+	}
+
+	account, valid := server.auditableAccount(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	balance, err := server.store.GetBalanceBefore(ctx, db.GetBalanceBeforeParams{
+		AccountID: account.ID,
+		Before:    req.From,
+	})
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	totals, err := server.store.ListDailyEntryTotals(ctx, db.ListDailyEntryTotalsParams{
+		AccountID: account.ID,
+		FromTime:  req.From,
+		ToTime:    req.To.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	dayTotals := make(map[string]int64, len(totals))
+	for _, total := range totals {
+		dayTotals[total.Day.UTC().Format(dateFormat)] = total.Total
+	}
+
+	history := make([]balanceHistoryItem, days)
+	for i := range history {
+		date := req.From.AddDate(0, 0, i).Format(dateFormat)
+		balance += dayTotals[date]
+		history[i] = balanceHistoryItem{
+			Date:    date,
+			Balance: balance,
+		}
+	}
+
+	ctx.JSON(http.StatusOK, history)
+}
+
+// auditableAccount returns the account if the authenticated user owns it or is an admin
+func (server *Server) auditableAccount(ctx *gin.Context, id int64) (db.Account, bool) {
+	account, err := server.store.GetAccount(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			NewError(ctx, http.StatusNotFound, err)
+			return account, false
+		}
+		NewError(ctx, http.StatusInternalServerError, err)
+		return account, false
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*token.Payload)
+	if authPayload.Username == account.Owner {
+		return account, true
+	}
+	admin, err := server.isAdmin(ctx, authPayload.Username)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return account, false
+	}
+	if !admin {
+		err := errors.New("account doesn't belong to the authenticated user")
+		NewError(ctx, http.StatusUnauthorized, err)
+		return account, false
+	}
+	return account, true
+}
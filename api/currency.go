@@ -0,0 +1,61 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"simplebank/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+// minorUnits returns the number of decimals of the amounts in a currency, nil for a currency missing from the registry
+func minorUnits(currency string) *int32 {
+	c, ok := util.LookupCurrency(currency)
+	if !ok {
+		return nil
+	}
+	return &c.MinorUnits
+}
+
+// requestAmount returns the amount of a request in minor units. Clients send either amount in minor units
+// or amount_decimal, a decimal string like "12.34" with at most the minor units of the currency as decimals
+func requestAmount(amount int64, amountDecimal string, currency string) (int64, error) {
+	if len(amountDecimal) == 0 {
+		if amount <= 0 {
+			return 0, errors.New("amount or amount_decimal is required")
+		}
+		return amount, nil
+	}
+	if amount != 0 {
+		return 0, errors.New("only one of amount and amount_decimal can be set")
+	}
+
+	money, err := util.ParseMoney(amountDecimal, currency)
+	if err != nil {
+		return 0, err
+	}
+	if money.Amount <= 0 {
+		return 0, errors.New("amount_decimal must be positive")
+	}
+	return money.Amount, nil
+}
+
+// @Summary      ListCurrencies
+// @Security     ApiKeyAuth
+// @Tags         Currency
+// @ID           list-currencies
+// @Description  List the currencies with the number of decimals of their amounts. Only enabled currencies take new accounts, transfers and rates
+// @Produce      json
+// @Success      200  {array}   db.Currency
+// @Failure      401  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Router       /currencies [get]
+func (server *Server) listCurrencies(ctx *gin.Context) {
+	currencies, err := server.store.ListCurrencies(ctx)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, currencies)
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxCursorTime is the upper bound of a listing when to isn't given
+var maxCursorTime = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// encodeCursor returns an opaque cursor pointing after a row in the (created_at, id) descending order
+func encodeCursor(createdAt time.Time, id int64) string {
+	cursor := createdAt.UTC().Format(time.RFC3339Nano) + "," + strconv.FormatInt(id, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(cursor))
+}
+
+func decodeCursor(cursor string) (time.Time, int64, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, 0, errInvalidCursor
+	}
+	parts := strings.SplitN(string(data), ",", 2)
+	if len(parts) != 2 {
+		return time.Time{}, 0, errInvalidCursor
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, 0, errInvalidCursor
+	}
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return time.Time{}, 0, errInvalidCursor
+	}
+	return createdAt, id, nil
+}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"simplebank/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	createdAt := time.Now().Truncate(time.Microsecond)
+	id := util.RandomInt(1, 1000)
+
+	decodedCreatedAt, decodedID, err := decodeCursor(encodeCursor(createdAt, id))
+	require.NoError(t, err)
+	require.True(t, createdAt.Equal(decodedCreatedAt))
+	require.Equal(t, id, decodedID)
+
+	for _, cursor := range []string{"", "!", "bm90LWEtY3Vyc29y", "MjAyMi0wMy0wMVQwMDowMDowMFoseA"} {
+		_, _, err := decodeCursor(cursor)
+		require.ErrorIs(t, err, errInvalidCursor, cursor)
+	}
+}
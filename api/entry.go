@@ -0,0 +1,106 @@
+package api
+
+import (
+	"math"
+	"net/http"
+	db "simplebank/db/sqlc"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type listEntriesRequest struct {
+	// From is included, To is not
+	From      time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To        time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Direction string    `form:"direction" binding:"omitempty,oneof=debit credit"`
+	// MinAmount and MaxAmount bound the absolute amount
+	MinAmount int64  `form:"min_amount" binding:"min=0"`
+	MaxAmount int64  `form:"max_amount" binding:"omitempty,gtefield=MinAmount"`
+	Cursor    string `form:"cursor"`
+	PageSize  int32  `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+type listEntriesResponse struct {
+	Entries []db.Entry `json:"entries"`
+	// NextCursor fetches the next page, it is empty on the last one
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// @Summary      ListEntries
+// @Security     ApiKeyAuth
+// @Tags         Account
+// @ID           list-entries
+// @Description  List the entries of an account, newest first. Pass next_cursor of a page as cursor with the same filters to get the next one
+// @Produce      json
+// @Param        id          path      int     true   "Account ID"
+// @Param        from        query     string  false  "RFC 3339 timestamp, included"
+// @Param        to          query     string  false  "RFC 3339 timestamp, excluded"
+// @Param        direction   query     string  false  "debit or credit"
+// @Param        min_amount  query     int     false  "Minimum absolute amount"
+// @Param        max_amount  query     int     false  "Maximum absolute amount"
+// @Param        cursor      query     string  false  "next_cursor of the previous page"
+// @Param        page_size   query     int     true   "Page Size"
+// @Success      200         {object}  listEntriesResponse
+// @Failure      400         {object}  errorResponse
+// @Failure      401         {object}  errorResponse
+// @Failure      404         {object}  errorResponse
+// @Failure      500         {object}  errorResponse
+// @Router       /accounts/{id}/entries [get]
+func (server *Server) listEntries(ctx *gin.Context) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	var req listEntriesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	arg := db.ListAccountEntriesParams{
+		AccountID: uri.ID,
+		FromTime:  req.From,
+		ToTime:    req.To,
+		Direction: req.Direction,
+		MinAmount: req.MinAmount,
+		MaxAmount: req.MaxAmount,
+		// one more row tells whether there is a next page
+		RowLimit: req.PageSize + 1,
+	}
+	if arg.ToTime.IsZero() {
+		arg.ToTime = maxCursorTime
+	}
+	if arg.MaxAmount == 0 {
+		arg.MaxAmount = math.MaxInt64
+	}
+	// the first page starts at to
+	arg.CursorCreatedAt = arg.ToTime
+	if req.Cursor != "" {
+		var err error
+		arg.CursorCreatedAt, arg.CursorID, err = decodeCursor(req.Cursor)
+		if err != nil {
+			NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	if _, valid := server.ownAccount(ctx, uri.ID); !valid {
+		return
+	}
+
+	entries, err := server.store.ListAccountEntries(ctx, arg)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := listEntriesResponse{Entries: entries}
+	if len(entries) > int(req.PageSize) {
+		resp.Entries = entries[:req.PageSize]
+		last := resp.Entries[len(resp.Entries)-1]
+		resp.NextCursor = encodeCursor(last.CreatedAt, last.ID)
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
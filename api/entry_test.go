@@ -0,0 +1,206 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	mockdb "simplebank/db/mock"
+	db "simplebank/db/sqlc"
+	"simplebank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListEntriesAPI(t *testing.T) {
+	user, _ := generateRandomUser(t)
+	account := generateRandomAccount(user.Username)
+
+	n := 6
+	entries := make([]db.Entry, n)
+	for i := range entries {
+		entries[i] = generateRandomEntry(account.ID)
+	}
+	last := entries[n-2]
+	cursor := encodeCursor(last.CreatedAt, last.ID)
+	from := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		query         string
+		username      string
+		buildStabs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "FirstPage",
+			query:    fmt.Sprintf("?page_size=%d", n-1),
+			username: user.Username,
+			buildStabs: func(store *mockdb.MockStore) {
+				arg := db.ListAccountEntriesParams{
+					AccountID:       account.ID,
+					ToTime:          maxCursorTime,
+					MaxAmount:       math.MaxInt64,
+					CursorCreatedAt: maxCursorTime,
+					RowLimit:        int32(n),
+				}
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ListAccountEntries(gomock.Any(), gomock.Eq(arg)).Times(1).Return(entries, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var resp listEntriesResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &resp)
+				require.NoError(t, err)
+				require.Len(t, resp.Entries, n-1)
+				require.Equal(t, cursor, resp.NextCursor)
+			},
+		},
+		{
+			name:     "NextPageWithFilters",
+			query:    "?page_size=5&from=2022-03-01T00:00:00Z&direction=debit&min_amount=10&max_amount=20&cursor=" + cursor,
+			username: user.Username,
+			buildStabs: func(store *mockdb.MockStore) {
+				arg := db.ListAccountEntriesParams{
+					AccountID:       account.ID,
+					FromTime:        from,
+					ToTime:          maxCursorTime,
+					Direction:       "debit",
+					MinAmount:       10,
+					MaxAmount:       20,
+					CursorCreatedAt: last.CreatedAt.UTC(),
+					CursorID:        last.ID,
+					RowLimit:        6,
+				}
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ListAccountEntries(gomock.Any(), gomock.Eq(arg)).Times(1).Return(entries[:2], nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var resp listEntriesResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &resp)
+				require.NoError(t, err)
+				require.Len(t, resp.Entries, 2)
+				require.Empty(t, resp.NextCursor)
+			},
+		},
+		{
+			name:     "UnauthorizedUser",
+			query:    "?page_size=5",
+			username: "unauthorized_user",
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ListAccountEntries(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "NotFound",
+			query:    "?page_size=5",
+			username: user.Username,
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().ListAccountEntries(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidCursor",
+			query:    "?page_size=5&cursor=bm90LWEtY3Vyc29y",
+			username: user.Username,
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidDirection",
+			query:    "?page_size=5&direction=both",
+			username: user.Username,
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "MaxAmountBelowMinAmount",
+			query:    "?page_size=5&min_amount=20&max_amount=10",
+			username: user.Username,
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidPageSize",
+			query:    "?page_size=1000",
+			username: user.Username,
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "InternalError",
+			query:    "?page_size=5",
+			username: user.Username,
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ListAccountEntries(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStabs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/entries%s", account.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthHeader(t, request, server.tokenMaker, authTypeBearer, tc.username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func generateRandomEntry(accountID int64) db.Entry {
+	return db.Entry{
+		ID:        util.RandomInt(1, 1000),
+		AccountID: accountID,
+		Amount:    util.RandomMoney(),
+		CreatedAt: time.Now().Add(-time.Duration(util.RandomInt(1, 1000)) * time.Second).Truncate(time.Microsecond),
+	}
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	db "simplebank/db/sqlc"
+	"simplebank/fraud"
+	"simplebank/token"
+
+	"github.com/gin-gonic/gin"
+)
+
+// checkFraud runs the fraud rules of the server on a transfer. A denied transfer is recorded for the analysts
+// and answered with an error, then checkFraud returns false. A transfer to review is made or stored for later,
+// with the returned flag for the store to record along with it
+func (server *Server) checkFraud(ctx *gin.Context, transfer fraud.Transfer) (*db.FraudFlagParams, bool) {
+	result, err := server.fraud.Evaluate(ctx, fraud.NewTransferFacts(server.store, transfer))
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	switch result.Decision {
+	case fraud.Review:
+		return &db.FraudFlagParams{
+			Username: transfer.Username,
+			Decision: result.Decision,
+			Rule:     result.Rule,
+		}, true
+	case fraud.Deny:
+		_, err := server.store.CreateFraudFlag(ctx, db.CreateFraudFlagParams{
+			Username:      transfer.Username,
+			FromAccountID: transfer.FromAccount.ID,
+			ToAccountID:   transfer.ToAccount.ID,
+			Amount:        transfer.Amount,
+			Currency:      transfer.FromAccount.Currency,
+			Decision:      result.Decision,
+			Rule:          result.Rule,
+		})
+		if err != nil {
+			NewError(ctx, http.StatusInternalServerError, err)
+			return nil, false
+		}
+		// the rule isn't named, so it can't be worked around
+		err = errors.New("the transfer was declined by the fraud checks")
+		NewErrorWithCode(ctx, http.StatusUnprocessableEntity, errCodeTransferDenied, err)
+		return nil, false
+	}
+	return nil, true
+}
+
+type listFraudFlagsRequest struct {
+	Username string `form:"username"`
+	Decision string `form:"decision" binding:"omitempty,oneof=review deny"`
+	PageID   int32  `form:"page_id" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+// @Summary      ListFraudFlags
+// @Security     ApiKeyAuth
+// @Tags         Admin
+// @ID           list-fraud-flags
+// @Description  List the transfers the fraud rules flagged for review or denied, newest first. Only for admins
+// @Produce      json
+// @Param        username   query     string  false  "Username of the sender"
+// @Param        decision   query     string  false  "review or deny"
+// @Param        page_id    query     int     true   "Page ID"
+// @Param        page_size  query     int     true   "Page Size"
+// @Success      200        {array}   db.FraudFlag
+// @Failure      400        {object}  errorResponse
+// @Failure      401        {object}  errorResponse
+// @Failure      500        {object}  errorResponse
+// @Router       /fraud-flags [get]
+func (server *Server) listFraudFlags(ctx *gin.Context) {
+	var req listFraudFlagsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*token.Payload)
+	admin, err := server.isAdmin(ctx, authPayload.Username)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if !admin {
+		err := errors.New("only an admin can read the fraud flags")
+		NewError(ctx, http.StatusUnauthorized, err)
+		return
+	}
+
+	flags, err := server.store.ListFraudFlags(ctx, db.ListFraudFlagsParams{
+		Username: req.Username,
+		Decision: req.Decision,
+		Limit:    req.PageSize,
+		Offset:   (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, flags)
+}
@@ -0,0 +1,478 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	mockdb "simplebank/db/mock"
+	db "simplebank/db/sqlc"
+	"simplebank/fraud"
+	"simplebank/token"
+	"simplebank/util"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTransferFraudAPI(t *testing.T) {
+	user1, _ := generateRandomUser(t)
+	user2, _ := generateRandomUser(t)
+
+	account1 := generateRandomAccount(user1.Username)
+	account2 := generateRandomAccount(user2.Username)
+	account1.Currency = util.USD
+	account2.Currency = util.USD
+	account1.AvailableBalance = 1000
+	transfer := generateRandomTransfer(account1.ID, account2.ID, 950)
+
+	rules := testFraudRules(t)
+	executeAt := time.Now().Add(time.Hour)
+
+	payeeArg := db.CountPayeeTransfersParams{
+		Owner:       user1.Username,
+		ToAccountID: account2.ID,
+	}
+
+	testCases := []struct {
+		name          string
+		amount        int64
+		executeAt     *time.Time
+		buildStabs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Deny",
+			amount: 950,
+			buildStabs: func(store *mockdb.MockStore) {
+				arg := db.CreateFraudFlagParams{
+					Username:      user1.Username,
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        950,
+					Currency:      util.USD,
+					Decision:      fraud.Deny,
+					Rule:          "empties_balance",
+				}
+				store.EXPECT().CreateFraudFlag(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.FraudFlag{ID: 1}, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+
+				var resp errorResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &resp)
+				require.NoError(t, err)
+				require.Equal(t, errCodeTransferDenied, resp.ErrorCode)
+				require.NotContains(t, resp.Message, "empties_balance")
+			},
+		},
+		{
+			name:   "Review",
+			amount: 10,
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountPayeeTransfers(gomock.Any(), gomock.Eq(payeeArg)).Times(1).Return(int64(0), nil)
+				store.EXPECT().CreateFraudFlag(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.TransferTxParams) (db.TransferTxResult, error) {
+						require.Equal(t, &db.FraudFlagParams{
+							Username: user1.Username,
+							Decision: fraud.Review,
+							Rule:     "new_payee",
+						}, arg.Fraud)
+						return db.TransferTxResult{Transfer: transfer}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Allow",
+			amount: 10,
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountPayeeTransfers(gomock.Any(), gomock.Eq(payeeArg)).Times(1).Return(int64(3), nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.TransferTxParams) (db.TransferTxResult, error) {
+						require.Nil(t, arg.Fraud)
+						return db.TransferTxResult{Transfer: transfer}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "ScheduledDeny",
+			amount:    950,
+			executeAt: &executeAt,
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateFraudFlag(gomock.Any(), gomock.Any()).Times(1).Return(db.FraudFlag{ID: 1}, nil)
+				store.EXPECT().CreateScheduledTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:      "ScheduledReview",
+			amount:    10,
+			executeAt: &executeAt,
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountPayeeTransfers(gomock.Any(), gomock.Eq(payeeArg)).Times(1).Return(int64(0), nil)
+				store.EXPECT().CreateScheduledTransferTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateScheduledTransferTxParams) (db.ScheduledTransfer, error) {
+						require.Equal(t, &db.FraudFlagParams{
+							Username: user1.Username,
+							Decision: fraud.Review,
+							Rule:     "new_payee",
+						}, arg.Fraud)
+						return db.ScheduledTransfer{ID: 1, Status: db.ScheduledTransferPending}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "FactError",
+			amount: 10,
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountPayeeTransfers(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), sql.ErrConnDone)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+			tc.buildStabs(store)
+
+			server := newTestServer(t, store)
+			server.fraud.SetRules(rules)
+			recorder := httptest.NewRecorder()
+			req := gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"amount":          tc.amount,
+				"currency":        util.USD,
+			}
+			if tc.executeAt != nil {
+				req["execute_at"] = tc.executeAt
+			}
+			body, err := json.Marshal(req)
+			require.NoError(t, err)
+			request, err := http.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(body))
+			require.NoError(t, err)
+
+			addAuthHeader(t, request, server.tokenMaker, authTypeBearer, user1.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestFraudChecksAPI(t *testing.T) {
+	user1, _ := generateRandomUser(t)
+	user2, _ := generateRandomUser(t)
+
+	account1 := generateRandomAccount(user1.Username)
+	account2 := generateRandomAccount(user2.Username)
+	account1.Currency = util.USD
+	account2.Currency = util.USD
+	account1.AvailableBalance = 1000
+	rules := testFraudRules(t)
+
+	// the held money counts towards the balance of a capture
+	heldAccount := account1
+	heldAccount.AvailableBalance = 0
+	hold := generateRandomHold(account1.ID, account2.ID)
+	hold.Amount = 1000
+
+	newPayee := func(store *mockdb.MockStore) {
+		store.EXPECT().CountPayeeTransfers(gomock.Any(), gomock.Eq(db.CountPayeeTransfersParams{
+			Owner:       user1.Username,
+			ToAccountID: account2.ID,
+		})).Times(1).Return(int64(0), nil)
+	}
+	reviewFlag := &db.FraudFlagParams{
+		Username: user1.Username,
+		Decision: fraud.Review,
+		Rule:     "new_payee",
+	}
+	standingOrder := func(amount int64) gin.H {
+		return gin.H{
+			"from_account_id": account1.ID,
+			"to_account_id":   account2.ID,
+			"amount":          amount,
+			"currency":        util.USD,
+			"frequency":       util.Monthly,
+			"start_at":        time.Now().Add(time.Hour),
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		url           string
+		body          gin.H
+		buildStabs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "BatchDeny",
+			url:  "/transfers/batch",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"currency":        util.USD,
+				"legs":            []gin.H{{"to_account_id": account2.ID, "amount": 950}},
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().CreateFraudFlag(gomock.Any(), gomock.Any()).Times(1).Return(db.FraudFlag{ID: 1}, nil)
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name: "BatchReview",
+			url:  "/transfers/batch",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"currency":        util.USD,
+				"legs":            []gin.H{{"to_account_id": account2.ID, "amount": 10}},
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				newPayee(store)
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.BatchTransferTxParams) (db.BatchTransferTxResult, error) {
+						require.Equal(t, reviewFlag, arg.Legs[0].Fraud)
+						return db.BatchTransferTxResult{}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "StandingOrderDeny",
+			url:  "/standing-orders",
+			body: standingOrder(950),
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().CreateFraudFlag(gomock.Any(), gomock.Any()).Times(1).Return(db.FraudFlag{ID: 1}, nil)
+				store.EXPECT().CreateStandingOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name: "StandingOrderReview",
+			url:  "/standing-orders",
+			body: standingOrder(10),
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				newPayee(store)
+				store.EXPECT().CreateStandingOrderTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateStandingOrderTxParams) (db.StandingOrder, error) {
+						require.Equal(t, reviewFlag, arg.Fraud)
+						return db.StandingOrder{ID: 1}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "CaptureDeny",
+			url:  fmt.Sprintf("/holds/%d/capture", hold.ID),
+			body: gin.H{"amount": 950},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(hold, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(heldAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				arg := db.CreateFraudFlagParams{
+					Username:      user1.Username,
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        950,
+					Currency:      util.USD,
+					Decision:      fraud.Deny,
+					Rule:          "empties_balance",
+				}
+				store.EXPECT().CreateFraudFlag(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.FraudFlag{ID: 1}, nil)
+				store.EXPECT().CaptureHoldTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name: "CaptureReview",
+			url:  fmt.Sprintf("/holds/%d/capture", hold.ID),
+			body: gin.H{"amount": 10},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(hold, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(heldAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				newPayee(store)
+				store.EXPECT().CaptureHoldTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CaptureHoldTxParams) (db.CaptureHoldTxResult, error) {
+						require.Equal(t, reviewFlag, arg.Fraud)
+						return db.CaptureHoldTxResult{Hold: hold}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStabs(store)
+
+			server := newTestServer(t, store)
+			server.fraud.SetRules(rules)
+			recorder := httptest.NewRecorder()
+			body, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+			request, err := http.NewRequest(http.MethodPost, tc.url, bytes.NewReader(body))
+			require.NoError(t, err)
+
+			addAuthHeader(t, request, server.tokenMaker, authTypeBearer, user1.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListFraudFlagsAPI(t *testing.T) {
+	user, _ := generateRandomUser(t)
+	admin, _ := generateRandomUser(t)
+	admin.Role = util.AdminRole
+
+	flags := []db.FraudFlag{
+		{ID: 2, Username: user.Username, Decision: fraud.Deny, Rule: "empties_balance"},
+		{ID: 1, Username: user.Username, Decision: fraud.Review, Rule: "new_payee", TransferID: sql.NullInt64{Int64: 7, Valid: true}},
+	}
+
+	testCases := []struct {
+		name          string
+		query         url.Values
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStabs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: url.Values{"username": {user.Username}, "page_id": {"1"}, "page_size": {"10"}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthHeader(t, request, tokenMaker, authTypeBearer, admin.Username, time.Minute)
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+
+				arg := db.ListFraudFlagsParams{
+					Username: user.Username,
+					Limit:    10,
+					Offset:   0,
+				}
+				store.EXPECT().ListFraudFlags(gomock.Any(), gomock.Eq(arg)).Times(1).Return(flags, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var resp []db.FraudFlag
+				err := json.Unmarshal(recorder.Body.Bytes(), &resp)
+				require.NoError(t, err)
+				require.Equal(t, flags, resp)
+			},
+		},
+		{
+			name:  "NotAdmin",
+			query: url.Values{"page_id": {"1"}, "page_size": {"10"}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().ListFraudFlags(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidDecision",
+			query: url.Values{"decision": {"allow"}, "page_id": {"1"}, "page_size": {"10"}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthHeader(t, request, tokenMaker, authTypeBearer, admin.Username, time.Minute)
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListFraudFlags(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStabs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/fraud-flags?"+tc.query.Encode(), nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+// testFraudRules denies a transfer of most of the balance and flags one to a new payee for review
+func testFraudRules(t *testing.T) []fraud.Rule {
+	rules, err := fraud.ParseRules([]byte(`[
+		{"name": "empties_balance", "decision": "deny", "conditions": [{"fact": "transfer.balance_percent", "op": ">=", "value": 90}]},
+		{"name": "new_payee", "decision": "review", "conditions": [{"fact": "payee.transfers", "op": "==", "value": 0}]}
+	]`))
+	require.NoError(t, err)
+	return rules
+}
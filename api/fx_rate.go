@@ -0,0 +1,53 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	db "simplebank/db/sqlc"
+
+	"github.com/gin-gonic/gin"
+)
+
+type getFxRateRequest struct {
+	FromCurrency string `form:"from_currency" binding:"required,currency"`
+	ToCurrency   string `form:"to_currency" binding:"required,currency,nefield=FromCurrency"`
+}
+
+// @Summary      GetFxRate
+// @Security     ApiKeyAuth
+// @Tags         FxRate
+// @ID           get-fx-rate
+// @Description  Get the current fx rate quote to use for a cross-currency transfer
+// @Accept       json
+// @Produce      json
+// @Param        from_currency  query     string  true  "Currency of the source account"
+// @Param        to_currency    query     string  true  "Currency of the destination account"
+// @Success      200            {object}  db.FxRate
+// @Failure      400            {object}  errorResponse
+// @Failure      401            {object}  errorResponse
+// @Failure      404            {object}  errorResponse
+// @Failure      500            {object}  errorResponse
+// @Router       /fx-rates [get]
+func (server *Server) getFxRate(ctx *gin.Context) {
+	var req getFxRateRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	rate, err := server.store.GetLatestFxRate(ctx, db.GetLatestFxRateParams{
+		FromCurrency: req.FromCurrency,
+		ToCurrency:   req.ToCurrency,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			NewError(ctx, http.StatusNotFound, errors.New("no fx rate for this currency pair"))
+			return
+		}
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rate)
+}
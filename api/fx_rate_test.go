@@ -0,0 +1,127 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	mockdb "simplebank/db/mock"
+	db "simplebank/db/sqlc"
+	"simplebank/token"
+	"simplebank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFxRateAPI(t *testing.T) {
+	user, _ := generateRandomUser(t)
+	rate := db.FxRate{
+		ID:           util.RandomInt(1, 1000),
+		FromCurrency: util.USD,
+		ToCurrency:   util.EUR,
+		Rate:         "0.9231",
+	}
+
+	testCases := []struct {
+		name          string
+		fromCurrency  string
+		toCurrency    string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStabs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:         "OK",
+			fromCurrency: util.USD,
+			toCurrency:   util.EUR,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				arg := db.GetLatestFxRateParams{
+					FromCurrency: util.USD,
+					ToCurrency:   util.EUR,
+				}
+				store.EXPECT().GetLatestFxRate(gomock.Any(), gomock.Eq(arg)).Times(1).Return(rate, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotRate db.FxRate
+				err := json.Unmarshal(recorder.Body.Bytes(), &gotRate)
+				require.NoError(t, err)
+				require.Equal(t, rate, gotRate)
+			},
+		},
+		{
+			name:         "NotFound",
+			fromCurrency: util.USD,
+			toCurrency:   util.EUR,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLatestFxRate(gomock.Any(), gomock.Any()).Times(1).Return(db.FxRate{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:         "SameCurrency",
+			fromCurrency: util.USD,
+			toCurrency:   util.USD,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLatestFxRate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:         "InternalError",
+			fromCurrency: util.EUR,
+			toCurrency:   util.USD,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLatestFxRate(gomock.Any(), gomock.Any()).Times(1).Return(db.FxRate{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStabs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/fx-rates", nil)
+			require.NoError(t, err)
+			q := request.URL.Query()
+			q.Add("from_currency", tc.fromCurrency)
+			q.Add("to_currency", tc.toCurrency)
+			request.URL.RawQuery = q.Encode()
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	db "simplebank/db/sqlc"
+	"simplebank/fraud"
+	"simplebank/token"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type holdResponse struct {
+	ID             int64     `json:"id"`
+	AccountID      int64     `json:"account_id"`
+	ToAccountID    int64     `json:"to_account_id"`
+	Amount         int64     `json:"amount"`
+	Status         string    `json:"status"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	CapturedAmount int64     `json:"captured_amount"`
+	TransferID     *int64    `json:"transfer_id,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func newHoldResponse(hold db.Hold) holdResponse {
+	resp := holdResponse{
+		ID:             hold.ID,
+		AccountID:      hold.AccountID,
+		ToAccountID:    hold.ToAccountID,
+		Amount:         hold.Amount,
+		Status:         hold.Status,
+		ExpiresAt:      hold.ExpiresAt,
+		CapturedAmount: hold.CapturedAmount,
+		CreatedAt:      hold.CreatedAt,
+	}
+	if hold.TransferID.Valid {
+		resp.TransferID = &hold.TransferID.Int64
+	}
+	return resp
+}
+
+type createHoldRequest struct {
+	AccountID   int64 `json:"account_id" binding:"required,min=1"`
+	ToAccountID int64 `json:"to_account_id" binding:"required,min=1"`
+	// Amount in minor units, or AmountDecimal as a decimal string like "12.34"
+	Amount        int64  `json:"amount" binding:"omitempty,gt=0"`
+	AmountDecimal string `json:"amount_decimal"`
+	Currency      string `json:"currency" binding:"required,currency"`
+}
+
+// @Summary      CreateHold
+// @Security     ApiKeyAuth
+// @Tags         Hold
+// @ID           create-hold
+// @Description  Reserve an amount on an account to capture later. The hold expires if it is neither captured nor voided in time
+// @Accept       json
+// @Produce      json
+// @Param        input  body      createHoldRequest  true  "Hold info"
+// @Success      200    {object}  holdResponse
+// @Failure      400    {object}  errorResponse
+// @Failure      401    {object}  errorResponse
+// @Failure      404    {object}  errorResponse
+// @Failure      422    {object}  errorResponse
+// @Failure      500    {object}  errorResponse
+// @Router       /holds [post]
+func (server *Server) createHold(ctx *gin.Context) {
+	var req createHoldRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	amount, err := requestAmount(req.Amount, req.AmountDecimal, req.Currency)
+	if err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.Amount, req.AmountDecimal = amount, ""
+
+	account, valid := server.validDebitAccount(ctx, req.AccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*token.Payload)
+	if authPayload.Username != account.Owner {
+		err := errors.New("account doesn't belong to the authenticated user")
+		NewError(ctx, http.StatusUnauthorized, err)
+		return
+	}
+
+	_, valid = server.validAccount(ctx, req.ToAccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	arg := db.CreateHoldParams{
+		AccountID:   req.AccountID,
+		ToAccountID: req.ToAccountID,
+		Amount:      req.Amount,
+		ExpiresAt:   time.Now().Add(server.config.HoldDuration),
+	}
+	hold, err := server.store.CreateHoldTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) {
+			NewErrorWithCode(ctx, http.StatusUnprocessableEntity, errCodeInsufficientFunds, err)
+			return
+		}
+		if accountStatusError(ctx, err) {
+			return
+		}
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newHoldResponse(hold))
+}
+
+type holdURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// @Summary      GetHold
+// @Security     ApiKeyAuth
+// @Tags         Hold
+// @ID           get-hold
+// @Description  Get hold
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Hold ID"
+// @Success      200  {object}  holdResponse
+// @Failure      400  {object}  errorResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      404  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Router       /holds/{id} [get]
+func (server *Server) getHold(ctx *gin.Context) {
+	var uri holdURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	hold, _, valid := server.ownHold(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newHoldResponse(hold))
+}
+
+type captureHoldRequest struct {
+	// Amount to transfer, the whole held amount by default
+	Amount int64 `json:"amount" binding:"omitempty,gt=0"`
+}
+
+type captureHoldResponse struct {
+	Hold     holdResponse        `json:"hold"`
+	Transfer db.TransferTxResult `json:"transfer"`
+}
+
+// @Summary      CaptureHold
+// @Security     ApiKeyAuth
+// @Tags         Hold
+// @ID           capture-hold
+// @Description  Transfer up to the held amount to the destination account and release the rest. The transfer goes through the fraud rules
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                 true   "Hold ID"
+// @Param        input  body      captureHoldRequest  false  "Amount to capture"
+// @Success      200    {object}  captureHoldResponse
+// @Failure      400    {object}  errorResponse
+// @Failure      401    {object}  errorResponse
+// @Failure      404    {object}  errorResponse
+// @Failure      409    {object}  errorResponse
+// @Failure      422    {object}  errorResponse
+// @Failure      500    {object}  errorResponse
+// @Router       /holds/{id}/capture [post]
+func (server *Server) captureHold(ctx *gin.Context) {
+	var uri holdURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	var req captureHoldRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	hold, account, valid := server.ownHold(ctx, uri.ID)
+	if !valid {
+		return
+	}
+	toAccount, err := server.store.GetAccount(ctx, hold.ToAccountID)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	amount := req.Amount
+	if amount == 0 {
+		amount = hold.Amount
+	}
+	// the held money is still there for the capture
+	account.AvailableBalance += hold.Amount
+	flag, valid := server.checkFraud(ctx, fraud.Transfer{
+		Username:    account.Owner,
+		Amount:      amount,
+		FromAccount: account,
+		ToAccount:   toAccount,
+	})
+	if !valid {
+		return
+	}
+
+	arg := db.CaptureHoldTxParams{
+		HoldID: uri.ID,
+		Amount: req.Amount,
+		Audit:  storeAudit(ctx),
+		Fraud:  flag,
+	}
+	result, err := server.store.CaptureHoldTx(ctx, arg)
+	if err != nil {
+		server.holdError(ctx, err)
+		return
+	}
+	auditStored(ctx)
+
+	ctx.JSON(http.StatusOK, captureHoldResponse{
+		Hold:     newHoldResponse(result.Hold),
+		Transfer: result.TransferTxResult,
+	})
+}
+
+// @Summary      VoidHold
+// @Security     ApiKeyAuth
+// @Tags         Hold
+// @ID           void-hold
+// @Description  Release a hold without transferring anything
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Hold ID"
+// @Success      200  {object}  holdResponse
+// @Failure      400  {object}  errorResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      404  {object}  errorResponse
+// @Failure      409  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Router       /holds/{id}/void [post]
+func (server *Server) voidHold(ctx *gin.Context) {
+	var uri holdURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, _, valid := server.ownHold(ctx, uri.ID); !valid {
+		return
+	}
+
+	hold, err := server.store.VoidHoldTx(ctx, uri.ID)
+	if err != nil {
+		server.holdError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newHoldResponse(hold))
+}
+
+// ownHold loads a hold and its account and checks that the account belongs to the authenticated user
+func (server *Server) ownHold(ctx *gin.Context, id int64) (db.Hold, db.Account, bool) {
+	hold, err := server.store.GetHold(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			NewError(ctx, http.StatusNotFound, err)
+			return hold, db.Account{}, false
+		}
+		NewError(ctx, http.StatusInternalServerError, err)
+		return hold, db.Account{}, false
+	}
+
+	account, err := server.store.GetAccount(ctx, hold.AccountID)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return hold, account, false
+	}
+	authPayload := ctx.MustGet(authPayloadKey).(*token.Payload)
+	if authPayload.Username != account.Owner {
+		err := errors.New("hold doesn't belong to the authenticated user")
+		NewError(ctx, http.StatusUnauthorized, err)
+		return hold, account, false
+	}
+
+	return hold, account, true
+}
+
+// holdError responds with the status matching an error of capturing or voiding a hold
+func (server *Server) holdError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, db.ErrHoldNotActive):
+		NewErrorWithCode(ctx, http.StatusConflict, errCodeHoldNotActive, err)
+	case errors.Is(err, db.ErrCaptureExceedsHold):
+		NewErrorWithCode(ctx, http.StatusUnprocessableEntity, errCodeCaptureExceedsHold, err)
+	case errors.Is(err, db.ErrInsufficientFunds):
+		NewErrorWithCode(ctx, http.StatusUnprocessableEntity, errCodeInsufficientFunds, err)
+	case errors.Is(err, db.ErrTransferLimitExceeded):
+		NewErrorWithCode(ctx, http.StatusUnprocessableEntity, errCodeTransferLimitExceeded, err)
+	default:
+		if !accountStatusError(ctx, err) {
+			NewError(ctx, http.StatusInternalServerError, err)
+		}
+	}
+}
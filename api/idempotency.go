@@ -0,0 +1,81 @@
+package api
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	db "simplebank/db/sqlc"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	idempotencyKeyHeader    = "Idempotency-Key"
+	idempotencyKeyMaxLength = 255
+)
+
+// newIdempotencyParams reads the Idempotency-Key header of the request.
+// It returns nil if the client didn't send one
+func newIdempotencyParams(ctx *gin.Context, username string, req interface{}) (*db.IdempotencyParams, error) {
+	key := ctx.GetHeader(idempotencyKeyHeader)
+	if len(key) == 0 {
+		return nil, nil
+	}
+	if len(key) > idempotencyKeyMaxLength {
+		return nil, fmt.Errorf("%s header must be at most %d characters", idempotencyKeyHeader, idempotencyKeyMaxLength)
+	}
+
+	hash, err := requestHash(ctx.Request.Method, ctx.FullPath(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &db.IdempotencyParams{
+		Username:    username,
+		Key:         key,
+		RequestHash: hash,
+	}, nil
+}
+
+// requestHash hashes the bound request rather than the raw body,
+// so formatting differences between retries don't matter
+func requestHash(method, path string, req interface{}) (string, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", method, path)
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// replayIdempotentResponse writes the stored response if the key was already used.
+// It returns false if the request has to be processed
+func (server *Server) replayIdempotentResponse(ctx *gin.Context, idem *db.IdempotencyParams) bool {
+	stored, err := server.store.GetIdempotencyKey(ctx, db.GetIdempotencyKeyParams{
+		Username: idem.Username,
+		Key:      idem.Key,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false
+		}
+		NewError(ctx, http.StatusInternalServerError, err)
+		return true
+	}
+
+	if stored.RequestHash != idem.RequestHash {
+		err := errors.New("idempotency key was already used for a different request")
+		NewErrorWithCode(ctx, http.StatusConflict, errCodeIdempotencyKeyReused, err)
+		return true
+	}
+
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", stored.ResponseBody)
+	return true
+}
@@ -0,0 +1,209 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	mockdb "simplebank/db/mock"
+	db "simplebank/db/sqlc"
+	"simplebank/util"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAccountIdempotencyAPI(t *testing.T) {
+	user, _ := generateRandomUser(t)
+	account := generateRandomAccount(user.Username)
+	key := util.RandomString(16)
+
+	hash, err := requestHash(http.MethodPost, "/accounts", createAccountRequest{Currency: account.Currency})
+	require.NoError(t, err)
+	storedBody, err := json.Marshal(account)
+	require.NoError(t, err)
+
+	stored := db.IdempotencyKey{
+		Username:     user.Username,
+		Key:          key,
+		RequestHash:  hash,
+		ResponseBody: storedBody,
+	}
+	getArg := db.GetIdempotencyKeyParams{
+		Username: user.Username,
+		Key:      key,
+	}
+
+	testCases := []struct {
+		name          string
+		key           string
+		body          gin.H
+		buildStabs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "NewKey",
+			key:  key,
+			body: gin.H{
+				"currency": account.Currency,
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetIdempotencyKey(gomock.Any(), gomock.Eq(getArg)).Times(1).Return(db.IdempotencyKey{}, sql.ErrNoRows)
+
+				arg := db.CreateAccountTxParams{
+					CreateAccountParams: db.CreateAccountParams{
+						Owner:    user.Username,
+						Balance:  0,
+						Currency: account.Currency,
+						Product:  db.ProductCurrent,
+					},
+					Idempotency: &db.IdempotencyParams{
+						Username:    user.Username,
+						Key:         key,
+						RequestHash: hash,
+					},
+				}
+				store.EXPECT().CreateAccountTx(gomock.Any(), EqAudited(arg, "createAccount")).Times(1).Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAccount(t, recorder.Body, account)
+			},
+		},
+		{
+			name: "Replay",
+			key:  key,
+			body: gin.H{
+				"currency": account.Currency,
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetIdempotencyKey(gomock.Any(), gomock.Eq(getArg)).Times(1).Return(stored, nil)
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAccount(t, recorder.Body, account)
+			},
+		},
+		{
+			name: "KeyReusedWithDifferentBody",
+			key:  key,
+			body: gin.H{
+				"currency": otherCurrency(account.Currency),
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetIdempotencyKey(gomock.Any(), gomock.Eq(getArg)).Times(1).Return(stored, nil)
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+
+				var resp errorResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &resp)
+				require.NoError(t, err)
+				require.Equal(t, errCodeIdempotencyKeyReused, resp.ErrorCode)
+			},
+		},
+		{
+			name: "ConcurrentDuplicate",
+			key:  key,
+			body: gin.H{
+				"currency": account.Currency,
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				gomock.InOrder(
+					store.EXPECT().GetIdempotencyKey(gomock.Any(), gomock.Eq(getArg)).Times(1).Return(db.IdempotencyKey{}, sql.ErrNoRows),
+					store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, db.ErrDuplicateIdempotencyKey),
+					store.EXPECT().GetIdempotencyKey(gomock.Any(), gomock.Eq(getArg)).Times(1).Return(stored, nil),
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAccount(t, recorder.Body, account)
+			},
+		},
+		{
+			name: "KeyTooLong",
+			key:  strings.Repeat("k", idempotencyKeyMaxLength+1),
+			body: gin.H{
+				"currency": account.Currency,
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetIdempotencyKey(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			key:  key,
+			body: gin.H{
+				"currency": account.Currency,
+			},
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetIdempotencyKey(gomock.Any(), gomock.Any()).Times(1).Return(db.IdempotencyKey{}, sql.ErrConnDone)
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStabs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+			request, err := http.NewRequest(http.MethodPost, "/accounts", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			request.Header.Set(idempotencyKeyHeader, tc.key)
+			addAuthHeader(t, request, server.tokenMaker, authTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestRequestHash(t *testing.T) {
+	req := TransferRequest{FromAccountID: 1, ToAccountID: 2, Amount: 10, Currency: util.USD}
+
+	hash1, err := requestHash(http.MethodPost, "/transfers", req)
+	require.NoError(t, err)
+	hash2, err := requestHash(http.MethodPost, "/transfers", req)
+	require.NoError(t, err)
+	require.Equal(t, hash1, hash2)
+
+	hash3, err := requestHash(http.MethodPost, "/accounts", req)
+	require.NoError(t, err)
+	require.NotEqual(t, hash1, hash3)
+
+	req.Amount = 11
+	hash4, err := requestHash(http.MethodPost, "/transfers", req)
+	require.NoError(t, err)
+	require.NotEqual(t, hash1, hash4)
+}
+
+func otherCurrency(currency string) string {
+	if currency == util.USD {
+		return util.EUR
+	}
+	return util.USD
+}
@@ -2,22 +2,32 @@ package api
 
 import (
 	"os"
+	mockdb "simplebank/db/mock"
 	db "simplebank/db/sqlc"
+	"simplebank/fraud"
+	"simplebank/notify"
 	"simplebank/util"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
 
 func newTestServer(t *testing.T, store db.Store) *Server {
+	if mock, ok := store.(*mockdb.MockStore); ok {
+		// the audit middleware records every state-changing request
+		mock.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).AnyTimes()
+	}
+
 	config := util.Config{
 		TokenSymmetricKey:   util.RandomString(32),
 		AccessTokenDuration: time.Minute,
+		HoldDuration:        time.Hour,
 	}
 
-	server, err := NewServer(config, store)
+	server, err := NewServer(config, store, notify.NewHub(), fraud.NewEngine())
 	require.NoError(t, err)
 	return server
 }
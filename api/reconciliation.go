@@ -0,0 +1,41 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"simplebank/token"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary      Reconcile
+// @Security     ApiKeyAuth
+// @Tags         Admin
+// @ID           reconcile
+// @Description  Check that every balance is the sum of its entries, every transfer has its two entries and every currency nets to zero. Admins only
+// @Produce      json
+// @Success      200  {object}  db.ReconciliationReport
+// @Failure      401  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Router       /reconciliation [get]
+func (server *Server) reconcile(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authPayloadKey).(*token.Payload)
+	admin, err := server.isAdmin(ctx, authPayload.Username)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if !admin {
+		err := errors.New("only an admin can reconcile the ledger")
+		NewError(ctx, http.StatusUnauthorized, err)
+		return
+	}
+
+	report, err := server.store.Reconcile(ctx)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,175 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	db "simplebank/db/sqlc"
+	"simplebank/token"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type scheduledTransferResponse struct {
+	ID            int64     `json:"id"`
+	FromAccountID int64     `json:"from_account_id"`
+	ToAccountID   int64     `json:"to_account_id"`
+	Amount        int64     `json:"amount"`
+	ExecuteAt     time.Time `json:"execute_at"`
+	Status        string    `json:"status"`
+	TransferID    *int64    `json:"transfer_id,omitempty"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func newScheduledTransferResponse(scheduled db.ScheduledTransfer) scheduledTransferResponse {
+	resp := scheduledTransferResponse{
+		ID:            scheduled.ID,
+		FromAccountID: scheduled.FromAccountID,
+		ToAccountID:   scheduled.ToAccountID,
+		Amount:        scheduled.Amount,
+		ExecuteAt:     scheduled.ExecuteAt,
+		Status:        scheduled.Status,
+		FailureReason: scheduled.FailureReason,
+		CreatedAt:     scheduled.CreatedAt,
+	}
+	if scheduled.TransferID.Valid {
+		resp.TransferID = &scheduled.TransferID.Int64
+	}
+	return resp
+}
+
+// scheduleTransfer stores an already validated and checked transfer request, with its fraud flag if any,
+// to be executed by the worker at req.ExecuteAt
+func (server *Server) scheduleTransfer(ctx *gin.Context, req TransferRequest, owner string, idem *db.IdempotencyParams, flag *db.FraudFlagParams) {
+	arg := db.CreateScheduledTransferTxParams{
+		CreateScheduledTransferParams: db.CreateScheduledTransferParams{
+			Owner:         owner,
+			FromAccountID: req.FromAccountID,
+			ToAccountID:   req.ToAccountID,
+			Amount:        req.Amount,
+			ExecuteAt:     *req.ExecuteAt,
+		},
+		Idempotency: idem,
+		Fraud:       flag,
+	}
+
+	scheduled, err := server.store.CreateScheduledTransferTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrDuplicateIdempotencyKey) && server.replayIdempotentResponse(ctx, idem) {
+			return
+		}
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newScheduledTransferResponse(scheduled))
+}
+
+type listScheduledTransfersRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=pending completed failed canceled"`
+	PageID   int32  `form:"page_id" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// @Summary      ListScheduledTransfers
+// @Security     ApiKeyAuth
+// @Tags         Transfer
+// @ID           list-scheduled-transfers
+// @Description  List scheduled transfers of the user, pending ones by default
+// @Accept       json
+// @Produce      json
+// @Param        status     query     string  false  "pending, completed, failed or canceled"
+// @Param        page_id    query     int     true   "Page ID"
+// @Param        page_size  query     int     true   "Page Size"
+// @Success      200        {array}   scheduledTransferResponse
+// @Failure      400        {object}  errorResponse
+// @Failure      401        {object}  errorResponse
+// @Failure      500        {object}  errorResponse
+// @Router       /scheduled-transfers [get]
+func (server *Server) listScheduledTransfers(ctx *gin.Context) {
+	var req listScheduledTransfersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if len(req.Status) == 0 {
+		req.Status = db.ScheduledTransferPending
+	}
+	authPayload := ctx.MustGet(authPayloadKey).(*token.Payload)
+
+	arg := db.ListScheduledTransfersParams{
+		Owner:  authPayload.Username,
+		Status: req.Status,
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+	scheduled, err := server.store.ListScheduledTransfers(ctx, arg)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := make([]scheduledTransferResponse, len(scheduled))
+	for i := range scheduled {
+		resp[i] = newScheduledTransferResponse(scheduled[i])
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type cancelScheduledTransferRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// @Summary      CancelScheduledTransfer
+// @Security     ApiKeyAuth
+// @Tags         Transfer
+// @ID           cancel-scheduled-transfer
+// @Description  Cancel a pending scheduled transfer
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Scheduled transfer ID"
+// @Success      200  {object}  scheduledTransferResponse
+// @Failure      400  {object}  errorResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      404  {object}  errorResponse
+// @Failure      409  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Router       /scheduled-transfers/{id}/cancel [post]
+func (server *Server) cancelScheduledTransfer(ctx *gin.Context) {
+	var req cancelScheduledTransferRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	scheduled, err := server.store.GetScheduledTransfer(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			NewError(ctx, http.StatusNotFound, err)
+			return
+		}
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	authPayload := ctx.MustGet(authPayloadKey).(*token.Payload)
+	if authPayload.Username != scheduled.Owner {
+		err := errors.New("scheduled transfer doesn't belong to the authenticated user")
+		NewError(ctx, http.StatusUnauthorized, err)
+		return
+	}
+
+	scheduled, err = server.store.CancelScheduledTransfer(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err := errors.New("only a pending scheduled transfer that is not being executed can be canceled")
+			NewError(ctx, http.StatusConflict, err)
+			return
+		}
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newScheduledTransferResponse(scheduled))
+}
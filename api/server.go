@@ -1,8 +1,12 @@
 package api
 
 import (
+	"context"
 	"fmt"
+	"net"
 	db "simplebank/db/sqlc"
+	"simplebank/fraud"
+	"simplebank/notify"
 	"simplebank/token"
 	"simplebank/util"
 
@@ -18,10 +22,15 @@ type Server struct {
 	config     util.Config
 	tokenMaker token.Maker
 	router     *gin.Engine
+	hub        *notify.Hub
+	fraud      *fraud.Engine
+	// lookupIP resolves the hosts of webhook URLs
+	lookupIP func(ctx context.Context, host string) ([]net.IPAddr, error)
 }
 
-// NewServer creates HTTP servre and setup routes
-func NewServer(config util.Config, store db.Store) (*Server, error) {
+// NewServer creates HTTP servre and setup routes.
+// The hub feeds the account event streams, the fraud engine decides on the transfers
+func NewServer(config util.Config, store db.Store, hub *notify.Hub, engine *fraud.Engine) (*Server, error) {
 	maker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create maker: %w", err)
@@ -30,10 +39,16 @@ func NewServer(config util.Config, store db.Store) (*Server, error) {
 		store:      store,
 		config:     config,
 		tokenMaker: maker,
+		hub:        hub,
+		fraud:      engine,
+		lookupIP:   net.DefaultResolver.LookupIPAddr,
 	}
 
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterValidation("currency", validCurrency)
+		v.RegisterValidation("frequency", validFrequency)
+		v.RegisterValidation("webhookevent", validWebhookEvent)
+		v.RegisterValidation("webhookurl", validWebhookURL)
 	}
 
 	server.createRoutes()
@@ -43,6 +58,7 @@ func NewServer(config util.Config, store db.Store) (*Server, error) {
 
 func (server *Server) createRoutes() {
 	router := gin.Default()
+	router.Use(auditMiddleware(server.store))
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
@@ -54,7 +70,42 @@ func (server *Server) createRoutes() {
 	authRoutes.POST("/accounts", server.createAccount)
 	authRoutes.GET("/accounts/:id", server.getAccount)
 	authRoutes.GET("/accounts", server.listAccount)
+	authRoutes.GET("/accounts/:id/balance", server.getBalance)
+	authRoutes.GET("/accounts/:id/balance-history", server.getBalanceHistory)
+	authRoutes.GET("/accounts/:id/entries", server.listEntries)
+	authRoutes.GET("/accounts/:id/statement", server.getStatement)
+	authRoutes.GET("/accounts/:id/events", server.streamAccountEvents)
+	authRoutes.POST("/accounts/:id/status", server.changeAccountStatus)
 	authRoutes.POST("/transfers", server.createTransfer)
+	authRoutes.GET("/transfers", server.listTransfers)
+	authRoutes.GET("/transfers/:id", server.getTransfer)
+	authRoutes.POST("/transfers/batch", server.createBatchTransfer)
+	authRoutes.POST("/transfers/:id/reverse", server.reverseTransfer)
+	authRoutes.GET("/fx-rates", server.getFxRate)
+	authRoutes.GET("/currencies", server.listCurrencies)
+	authRoutes.GET("/transfer-fees", server.listTransferFees)
+	authRoutes.POST("/holds", server.createHold)
+	authRoutes.GET("/holds/:id", server.getHold)
+	authRoutes.POST("/holds/:id/capture", server.captureHold)
+	authRoutes.POST("/holds/:id/void", server.voidHold)
+	authRoutes.GET("/scheduled-transfers", server.listScheduledTransfers)
+	authRoutes.POST("/scheduled-transfers/:id/cancel", server.cancelScheduledTransfer)
+	authRoutes.POST("/standing-orders", server.createStandingOrder)
+	authRoutes.GET("/standing-orders", server.listStandingOrders)
+	authRoutes.GET("/standing-orders/:id", server.getStandingOrder)
+	authRoutes.PUT("/standing-orders/:id", server.updateStandingOrder)
+	authRoutes.DELETE("/standing-orders/:id", server.deleteStandingOrder)
+	authRoutes.GET("/standing-orders/:id/runs", server.listStandingOrderRuns)
+	authRoutes.GET("/reconciliation", server.reconcile)
+	authRoutes.GET("/tx-stats", server.getTxRetryStats)
+	authRoutes.GET("/audit-log", server.listAuditLog)
+	authRoutes.GET("/fraud-flags", server.listFraudFlags)
+	authRoutes.POST("/webhooks", server.createWebhookSubscription)
+	authRoutes.GET("/webhooks", server.listWebhookSubscriptions)
+	authRoutes.DELETE("/webhooks/:id", server.deleteWebhookSubscription)
+	authRoutes.GET("/webhooks/:id/deliveries", server.listWebhookDeliveries)
+	authRoutes.GET("/webhook-deliveries/:id", server.getWebhookDelivery)
+	authRoutes.POST("/webhook-deliveries/:id/replay", server.replayWebhookDelivery)
 
 	server.router = router
 }
@@ -63,15 +114,44 @@ func (server *Server) Start(addres string) error {
 	return server.router.Run(addres)
 }
 
-type errorResponse struct{
-	Code int `json:"code"`
-	Message string `json:"error"`
+type errorResponse struct {
+	Code      int    `json:"code"`
+	Message   string `json:"error"`
+	ErrorCode string `json:"error_code,omitempty"`
 }
 
+// machine-readable error codes returned in errorResponse.ErrorCode
+const (
+	errCodeInsufficientFunds     = "insufficient_funds"
+	errCodeIdempotencyKeyReused  = "idempotency_key_reused"
+	errCodeInvalidFxRate         = "invalid_fx_rate"
+	errCodeInvalidReversal       = "invalid_reversal"
+	errCodeHoldNotActive         = "hold_not_active"
+	errCodeCaptureExceedsHold    = "capture_exceeds_hold"
+	errCodeTransferLimitExceeded = "transfer_limit_exceeded"
+	errCodeAccountFrozen         = "account_frozen"
+	errCodeAccountClosed         = "account_closed"
+	errCodeInvalidStatusChange   = "invalid_status_change"
+	errCodeTransferDenied        = "transfer_denied"
+)
+
 func NewError(ctx *gin.Context, status int, err error) {
 	er := errorResponse{
-		Code: status,
+		Code:    status,
 		Message: err.Error(),
 	}
+	// the audit log records the error of a failed request
+	ctx.Error(err)
+	ctx.JSON(status, er)
+}
+
+// NewErrorWithCode is like NewError but also sets a machine-readable error code
+func NewErrorWithCode(ctx *gin.Context, status int, code string, err error) {
+	er := errorResponse{
+		Code:      status,
+		Message:   err.Error(),
+		ErrorCode: code,
+	}
+	ctx.Error(err)
 	ctx.JSON(status, er)
 }
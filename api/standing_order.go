@@ -0,0 +1,438 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	db "simplebank/db/sqlc"
+	"simplebank/fraud"
+	"simplebank/token"
+	"simplebank/util"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type standingOrderResponse struct {
+	ID            int64      `json:"id"`
+	FromAccountID int64      `json:"from_account_id"`
+	ToAccountID   int64      `json:"to_account_id"`
+	Amount        int64      `json:"amount"`
+	Frequency     string     `json:"frequency"`
+	DayOfMonth    int32      `json:"day_of_month"`
+	NextRunAt     time.Time  `json:"next_run_at"`
+	EndAt         *time.Time `json:"end_at,omitempty"`
+	Status        string     `json:"status"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+func newStandingOrderResponse(order db.StandingOrder) standingOrderResponse {
+	resp := standingOrderResponse{
+		ID:            order.ID,
+		FromAccountID: order.FromAccountID,
+		ToAccountID:   order.ToAccountID,
+		Amount:        order.Amount,
+		Frequency:     order.Frequency,
+		DayOfMonth:    order.DayOfMonth,
+		NextRunAt:     order.NextRunAt,
+		Status:        order.Status,
+		CreatedAt:     order.CreatedAt,
+	}
+	if order.EndAt.Valid {
+		resp.EndAt = &order.EndAt.Time
+	}
+	return resp
+}
+
+type standingOrderRunResponse struct {
+	ID            int64     `json:"id"`
+	RunAt         time.Time `json:"run_at"`
+	Status        string    `json:"status"`
+	TransferID    *int64    `json:"transfer_id,omitempty"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func newStandingOrderRunResponse(run db.StandingOrderRun) standingOrderRunResponse {
+	resp := standingOrderRunResponse{
+		ID:            run.ID,
+		RunAt:         run.RunAt,
+		Status:        run.Status,
+		FailureReason: run.FailureReason,
+		CreatedAt:     run.CreatedAt,
+	}
+	if run.TransferID.Valid {
+		resp.TransferID = &run.TransferID.Int64
+	}
+	return resp
+}
+
+type createStandingOrderRequest struct {
+	FromAccountID int64 `json:"from_account_id" binding:"required,min=1"`
+	ToAccountID   int64 `json:"to_account_id" binding:"required,min=1"`
+	// Amount in minor units, or AmountDecimal as a decimal string like "12.34"
+	Amount        int64  `json:"amount" binding:"omitempty,gt=0"`
+	AmountDecimal string `json:"amount_decimal"`
+	Currency      string `json:"currency" binding:"required,currency"`
+	Frequency     string `json:"frequency" binding:"required,frequency"`
+	// DayOfMonth of monthly runs, the day of StartAt by default
+	DayOfMonth int32      `json:"day_of_month" binding:"omitempty,min=1,max=31"`
+	StartAt    time.Time  `json:"start_at" binding:"required"`
+	EndAt      *time.Time `json:"end_at"`
+}
+
+// @Summary      CreateStandingOrder
+// @Security     ApiKeyAuth
+// @Tags         StandingOrder
+// @ID           create-standing-order
+// @Description  Create a standing order that makes a transfer every day, week or month. The order goes through the fraud rules
+// @Accept       json
+// @Produce      json
+// @Param        input  body      createStandingOrderRequest  true  "Standing order info"
+// @Success      200    {object}  standingOrderResponse
+// @Failure      400    {object}  errorResponse
+// @Failure      401    {object}  errorResponse
+// @Failure      404    {object}  errorResponse
+// @Failure      422    {object}  errorResponse
+// @Failure      500    {object}  errorResponse
+// @Router       /standing-orders [post]
+func (server *Server) createStandingOrder(ctx *gin.Context) {
+	var req createStandingOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	amount, err := requestAmount(req.Amount, req.AmountDecimal, req.Currency)
+	if err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.Amount, req.AmountDecimal = amount, ""
+	if !req.StartAt.After(time.Now()) {
+		err := errors.New("start_at must be in the future")
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if req.DayOfMonth == 0 {
+		req.DayOfMonth = int32(req.StartAt.Day())
+	}
+
+	firstRun, err := util.FirstRun(req.Frequency, int(req.DayOfMonth), req.StartAt)
+	if err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if req.EndAt != nil && req.EndAt.Before(firstRun) {
+		err := errors.New("end_at is before the first run")
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	fromAccount, valid := server.validDebitAccount(ctx, req.FromAccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*token.Payload)
+	if authPayload.Username != fromAccount.Owner {
+		err := errors.New("account doesn't belong to the authenticated user")
+		NewError(ctx, http.StatusUnauthorized, err)
+		return
+	}
+
+	toAccount, valid := server.validAccount(ctx, req.ToAccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	flag, valid := server.checkFraud(ctx, fraud.Transfer{
+		Username:    authPayload.Username,
+		Amount:      req.Amount,
+		FromAccount: fromAccount,
+		ToAccount:   toAccount,
+	})
+	if !valid {
+		return
+	}
+
+	arg := db.CreateStandingOrderTxParams{
+		CreateStandingOrderParams: db.CreateStandingOrderParams{
+			Owner:         authPayload.Username,
+			FromAccountID: req.FromAccountID,
+			ToAccountID:   req.ToAccountID,
+			Amount:        req.Amount,
+			Frequency:     req.Frequency,
+			DayOfMonth:    req.DayOfMonth,
+			NextRunAt:     firstRun,
+			EndAt:         nullTime(req.EndAt),
+		},
+		Fraud: flag,
+	}
+	order, err := server.store.CreateStandingOrderTx(ctx, arg)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newStandingOrderResponse(order))
+}
+
+type standingOrderURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// @Summary      GetStandingOrder
+// @Security     ApiKeyAuth
+// @Tags         StandingOrder
+// @ID           get-standing-order
+// @Description  Get standing order
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Standing order ID"
+// @Success      200  {object}  standingOrderResponse
+// @Failure      400  {object}  errorResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      404  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Router       /standing-orders/{id} [get]
+func (server *Server) getStandingOrder(ctx *gin.Context) {
+	var uri standingOrderURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	order, valid := server.ownStandingOrder(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newStandingOrderResponse(order))
+}
+
+type listStandingOrdersRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// @Summary      ListStandingOrders
+// @Security     ApiKeyAuth
+// @Tags         StandingOrder
+// @ID           list-standing-orders
+// @Description  List standing orders of the user
+// @Accept       json
+// @Produce      json
+// @Param        page_id    query     int  true  "Page ID"
+// @Param        page_size  query     int  true  "Page Size"
+// @Success      200        {array}   standingOrderResponse
+// @Failure      400        {object}  errorResponse
+// @Failure      401        {object}  errorResponse
+// @Failure      500        {object}  errorResponse
+// @Router       /standing-orders [get]
+func (server *Server) listStandingOrders(ctx *gin.Context) {
+	var req listStandingOrdersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	authPayload := ctx.MustGet(authPayloadKey).(*token.Payload)
+
+	arg := db.ListStandingOrdersParams{
+		Owner:  authPayload.Username,
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+	orders, err := server.store.ListStandingOrders(ctx, arg)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := make([]standingOrderResponse, len(orders))
+	for i := range orders {
+		resp[i] = newStandingOrderResponse(orders[i])
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type updateStandingOrderRequest struct {
+	Amount int64 `json:"amount" binding:"required,gt=0"`
+	// EndAt replaces the end date, null removes it
+	EndAt *time.Time `json:"end_at"`
+}
+
+// @Summary      UpdateStandingOrder
+// @Security     ApiKeyAuth
+// @Tags         StandingOrder
+// @ID           update-standing-order
+// @Description  Change the amount and the end date of an active standing order
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                         true  "Standing order ID"
+// @Param        input  body      updateStandingOrderRequest  true  "New amount and end date"
+// @Success      200    {object}  standingOrderResponse
+// @Failure      400    {object}  errorResponse
+// @Failure      401    {object}  errorResponse
+// @Failure      404    {object}  errorResponse
+// @Failure      409    {object}  errorResponse
+// @Failure      500    {object}  errorResponse
+// @Router       /standing-orders/{id} [put]
+func (server *Server) updateStandingOrder(ctx *gin.Context) {
+	var uri standingOrderURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	var req updateStandingOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	order, valid := server.ownStandingOrder(ctx, uri.ID)
+	if !valid {
+		return
+	}
+	if req.EndAt != nil && req.EndAt.Before(order.NextRunAt) {
+		err := errors.New("end_at is before the next run")
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	arg := db.UpdateStandingOrderParams{
+		ID:     uri.ID,
+		Amount: req.Amount,
+		EndAt:  nullTime(req.EndAt),
+	}
+	order, err := server.store.UpdateStandingOrder(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err := errors.New("only an active standing order can be changed")
+			NewError(ctx, http.StatusConflict, err)
+			return
+		}
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newStandingOrderResponse(order))
+}
+
+// @Summary      DeleteStandingOrder
+// @Security     ApiKeyAuth
+// @Tags         StandingOrder
+// @ID           delete-standing-order
+// @Description  Cancel an active standing order. Its past runs are kept
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Standing order ID"
+// @Success      200  {object}  standingOrderResponse
+// @Failure      400  {object}  errorResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      404  {object}  errorResponse
+// @Failure      409  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Router       /standing-orders/{id} [delete]
+func (server *Server) deleteStandingOrder(ctx *gin.Context) {
+	var uri standingOrderURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, valid := server.ownStandingOrder(ctx, uri.ID); !valid {
+		return
+	}
+
+	order, err := server.store.CancelStandingOrder(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err := errors.New("only an active standing order can be canceled")
+			NewError(ctx, http.StatusConflict, err)
+			return
+		}
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newStandingOrderResponse(order))
+}
+
+// @Summary      ListStandingOrderRuns
+// @Security     ApiKeyAuth
+// @Tags         StandingOrder
+// @ID           list-standing-order-runs
+// @Description  List the runs of a standing order, the latest first
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int  true  "Standing order ID"
+// @Param        page_id    query     int  true  "Page ID"
+// @Param        page_size  query     int  true  "Page Size"
+// @Success      200        {array}   standingOrderRunResponse
+// @Failure      400        {object}  errorResponse
+// @Failure      401        {object}  errorResponse
+// @Failure      404        {object}  errorResponse
+// @Failure      500        {object}  errorResponse
+// @Router       /standing-orders/{id}/runs [get]
+func (server *Server) listStandingOrderRuns(ctx *gin.Context) {
+	var uri standingOrderURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	var req listStandingOrdersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, valid := server.ownStandingOrder(ctx, uri.ID); !valid {
+		return
+	}
+
+	arg := db.ListStandingOrderRunsParams{
+		StandingOrderID: uri.ID,
+		Limit:           req.PageSize,
+		Offset:          (req.PageID - 1) * req.PageSize,
+	}
+	runs, err := server.store.ListStandingOrderRuns(ctx, arg)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := make([]standingOrderRunResponse, len(runs))
+	for i := range runs {
+		resp[i] = newStandingOrderRunResponse(runs[i])
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ownStandingOrder loads a standing order and checks that it belongs to the authenticated user
+func (server *Server) ownStandingOrder(ctx *gin.Context, id int64) (db.StandingOrder, bool) {
+	order, err := server.store.GetStandingOrder(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			NewError(ctx, http.StatusNotFound, err)
+			return order, false
+		}
+		NewError(ctx, http.StatusInternalServerError, err)
+		return order, false
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*token.Payload)
+	if authPayload.Username != order.Owner {
+		err := errors.New("standing order doesn't belong to the authenticated user")
+		NewError(ctx, http.StatusUnauthorized, err)
+		return order, false
+	}
+
+	return order, true
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
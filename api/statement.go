@@ -0,0 +1,95 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	db "simplebank/db/sqlc"
+	"simplebank/statement"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type getStatementRequest struct {
+	From   time.Time `form:"from" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	To     time.Time `form:"to" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	Format string    `form:"format" binding:"required,oneof=csv ofx camt053"`
+}
+
+// statementResponse sets the download headers once the statement starts,
+// so an error before that can still be answered with JSON
+type statementResponse struct {
+	db.StatementWriter
+	ctx    *gin.Context
+	format string
+}
+
+func (resp *statementResponse) Begin(summary db.StatementSummary) error {
+	resp.ctx.Header("Content-Type", statement.ContentType(resp.format))
+	resp.ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, statement.FileName(resp.format, summary)))
+	resp.ctx.Status(http.StatusOK)
+	return resp.StatementWriter.Begin(summary)
+}
+
+// @Summary      GetStatement
+// @Security     ApiKeyAuth
+// @Tags         Account
+// @ID           get-statement
+// @Description  Download the statement of an account between from and to, both included, with the opening and closing balances. Entries are streamed as they are read. Available to the owner and to admins
+// @Produce      text/csv
+// @Produce      application/x-ofx
+// @Produce      application/xml
+// @Param        id      path      int     true  "Account ID"
+// @Param        from    query     string  true  "First day, YYYY-MM-DD"
+// @Param        to      query     string  true  "Last day, YYYY-MM-DD"
+// @Param        format  query     string  true  "csv, ofx or camt053"
+// @Success      200     {file}    file
+// @Failure      400     {object}  errorResponse
+// @Failure      401     {object}  errorResponse
+// @Failure      404     {object}  errorResponse
+// @Failure      500     {object}  errorResponse
+// @Router       /accounts/{id}/statement [get]
+func (server *Server) getStatement(ctx *gin.Context) {
+	var uri accountURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	var req getStatementRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if req.To.Before(req.From) {
+		NewError(ctx, http.StatusBadRequest, errors.New("to is before from"))
+		return
+	}
+
+	account, valid := server.auditableAccount(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	writer, err := statement.NewWriter(req.Format, ctx.Writer)
+	if err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	err = server.store.ExportStatement(ctx, db.ExportStatementParams{
+		Account: account,
+		From:    req.From,
+		To:      req.To.AddDate(0, 0, 1),
+	}, &statementResponse{StatementWriter: writer, ctx: ctx, format: req.Format})
+	if err != nil {
+		if ctx.Writer.Written() {
+			// the status is sent already, the client gets a truncated statement
+			ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+		ctx.Header("Content-Type", "")
+		ctx.Header("Content-Disposition", "")
+		NewError(ctx, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,191 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	mockdb "simplebank/db/mock"
+	db "simplebank/db/sqlc"
+	"simplebank/util"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStatementAPI(t *testing.T) {
+	user, _ := generateRandomUser(t)
+	admin, _ := generateRandomUser(t)
+	admin.Role = util.AdminRole
+	account := generateRandomAccount(user.Username)
+
+	from := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2022, 3, 3, 0, 0, 0, 0, time.UTC)
+	arg := db.ExportStatementParams{
+		Account: account,
+		From:    from,
+		To:      to.AddDate(0, 0, 1),
+	}
+	summary := db.StatementSummary{
+		Account:        account,
+		From:           arg.From,
+		To:             arg.To,
+		OpeningBalance: 100,
+		ClosingBalance: 70,
+	}
+	entry := db.StatementEntry{
+		ListStatementEntriesRow: db.ListStatementEntriesRow{
+			ID:                    1,
+			Amount:                -30,
+			CreatedAt:             from.Add(time.Hour),
+			TransferID:            sql.NullInt64{Int64: 2, Valid: true},
+			CounterpartyAccountID: 3,
+		},
+		Balance: 70,
+	}
+	export := func(ctx context.Context, arg db.ExportStatementParams, w db.StatementWriter) error {
+		if err := w.Begin(summary); err != nil {
+			return err
+		}
+		if err := w.Entry(entry); err != nil {
+			return err
+		}
+		return w.End()
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		username      string
+		buildStabs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "CSV",
+			query:    "?from=2022-03-01&to=2022-03-03&format=csv",
+			username: user.Username,
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ExportStatement(gomock.Any(), gomock.Eq(arg), gomock.Any()).Times(1).DoAndReturn(export)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "text/csv", recorder.Header().Get("Content-Type"))
+				filename := fmt.Sprintf(`attachment; filename="statement-%d-20220301-20220304.csv"`, account.ID)
+				require.Equal(t, filename, recorder.Header().Get("Content-Disposition"))
+
+				records, err := csv.NewReader(recorder.Body).ReadAll()
+				require.NoError(t, err)
+				require.Len(t, records, 4)
+				require.Equal(t, "-0.30", records[2][5])
+				require.Equal(t, "0.70", records[3][6])
+			},
+		},
+		{
+			name:     "OFX",
+			query:    "?from=2022-03-01&to=2022-03-03&format=ofx",
+			username: user.Username,
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ExportStatement(gomock.Any(), gomock.Eq(arg), gomock.Any()).Times(1).DoAndReturn(export)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/x-ofx", recorder.Header().Get("Content-Type"))
+				require.Contains(t, recorder.Body.String(), "<TRNAMT>-0.30</TRNAMT>")
+			},
+		},
+		{
+			name:     "CAMT053",
+			query:    "?from=2022-03-01&to=2022-03-03&format=camt053",
+			username: admin.Username,
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().ExportStatement(gomock.Any(), gomock.Eq(arg), gomock.Any()).Times(1).DoAndReturn(export)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/xml", recorder.Header().Get("Content-Type"))
+				require.True(t, strings.HasSuffix(recorder.Header().Get("Content-Disposition"), `.xml"`))
+				require.Contains(t, recorder.Body.String(), "camt.053.001.02")
+			},
+		},
+		{
+			name:     "UnauthorizedUser",
+			query:    "?from=2022-03-01&to=2022-03-03&format=csv",
+			username: "unauthorized_user",
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(1).Return(db.User{Role: util.DepositorRole}, nil)
+				store.EXPECT().ExportStatement(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "UnsupportedFormat",
+			query:    "?from=2022-03-01&to=2022-03-03&format=pdf",
+			username: user.Username,
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "ToBeforeFrom",
+			query:    "?from=2022-03-03&to=2022-03-01&format=csv",
+			username: user.Username,
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "InternalError",
+			query:    "?from=2022-03-01&to=2022-03-03&format=csv",
+			username: user.Username,
+			buildStabs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ExportStatement(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Type"), "application/json")
+				require.Empty(t, recorder.Header().Get("Content-Disposition"))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStabs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/statement%s", account.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthHeader(t, request, server.tokenMaker, authTypeBearer, tc.username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
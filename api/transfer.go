@@ -4,32 +4,47 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	db "simplebank/db/sqlc"
+	"simplebank/fraud"
 	"simplebank/token"
+	"simplebank/util"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type TransferRequest struct {
-	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
-	ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`
-	Amount        int64  `json:"amount" binding:"required,gt=0"`
+	FromAccountID int64 `json:"from_account_id" binding:"required,min=1"`
+	ToAccountID   int64 `json:"to_account_id" binding:"required,min=1"`
+	// Amount in minor units, or AmountDecimal as a decimal string like "12.34"
+	Amount        int64  `json:"amount" binding:"omitempty,gt=0"`
+	AmountDecimal string `json:"amount_decimal"`
 	Currency      string `json:"currency" binding:"required,currency"`
+	// ToCurrency is the currency of the destination account if it differs from Currency
+	ToCurrency string `json:"to_currency" binding:"omitempty,currency"`
+	// FxRateID is the quoted rate for a cross-currency transfer, see GET /fx-rates
+	FxRateID int64 `json:"fx_rate_id" binding:"omitempty,min=1"`
+	// ExecuteAt schedules the transfer for later instead of executing it now
+	ExecuteAt *time.Time `json:"execute_at"`
 }
 
 // @Summary      CreateTransfer
 // @Security     ApiKeyAuth
 // @Tags         Transfer
 // @ID           create-transfer
-// @Description  Create new transfer
+// @Description  Create new transfer, or schedule it if execute_at is set. The transfer goes through the fraud rules either way
 // @Accept       json
 // @Produce      json
-// @Param        input  body      TransferRequest  true  "Transfer info"
-// @Success      200    {object}  db.TransferTxResult
-// @Failure      400    {object}  errorResponse
-// @Failure      401    {object}  errorResponse
-// @Failure      500    {object}  errorResponse
+// @Param        input            body      TransferRequest  true   "Transfer info"
+// @Param        Idempotency-Key  header    string           false  "Key to safely retry the request"
+// @Success      200              {object}  db.TransferTxResult
+// @Failure      400              {object}  errorResponse
+// @Failure      401              {object}  errorResponse
+// @Failure      409              {object}  errorResponse
+// @Failure      422              {object}  errorResponse
+// @Failure      500              {object}  errorResponse
 // @Router       /transfers [post]
 func (server *Server) createTransfer(ctx *gin.Context) {
 	var req TransferRequest
@@ -37,8 +52,27 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		NewError(ctx, http.StatusBadRequest, err)
 		return
 	}
+	amount, err := requestAmount(req.Amount, req.AmountDecimal, req.Currency)
+	if err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.Amount, req.AmountDecimal = amount, ""
 
-	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, req.Currency)
+	if req.ExecuteAt != nil {
+		if !req.ExecuteAt.After(time.Now()) {
+			err := errors.New("execute_at must be in the future")
+			NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		if req.FxRateID != 0 || (len(req.ToCurrency) > 0 && req.ToCurrency != req.Currency) {
+			err := errors.New("a scheduled transfer can't be cross-currency")
+			NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	fromAccount, valid := server.validDebitAccount(ctx, req.FromAccountID, req.Currency)
 
 	if !valid {
 		return
@@ -51,19 +85,298 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		return
 	}
 
-	_, valid = server.validAccount(ctx, req.ToAccountID, req.Currency)
+	toCurrency := req.Currency
+	if len(req.ToCurrency) > 0 {
+		toCurrency = req.ToCurrency
+	}
+	if toCurrency != req.Currency && req.FxRateID == 0 {
+		err := errors.New("fx_rate_id is required for a cross-currency transfer")
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	toAccount, valid := server.validAccount(ctx, req.ToAccountID, toCurrency)
 	if !valid {
 		return
 	}
 
+	idem, err := newIdempotencyParams(ctx, authPayload.Username, req)
+	if err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if idem != nil && server.replayIdempotentResponse(ctx, idem) {
+		return
+	}
+
+	flag, valid := server.checkFraud(ctx, fraud.Transfer{
+		Username:    authPayload.Username,
+		Amount:      req.Amount,
+		FromAccount: fromAccount,
+		ToAccount:   toAccount,
+	})
+	if !valid {
+		return
+	}
+
+	if req.ExecuteAt != nil {
+		server.scheduleTransfer(ctx, req, authPayload.Username, idem, flag)
+		return
+	}
+
 	arg := db.TransferTxParams{
 		FromAccountID: req.FromAccountID,
 		ToAccountID:   req.ToAccountID,
 		Amount:        req.Amount,
+		FxRateID:      req.FxRateID,
+		Idempotency:   idem,
+		Audit:         storeAudit(ctx),
+		Fraud:         flag,
 	}
 
 	result, err := server.store.TransferTx(ctx, arg)
 	if err != nil {
+		if errors.Is(err, db.ErrDuplicateIdempotencyKey) && server.replayIdempotentResponse(ctx, idem) {
+			return
+		}
+		if errors.Is(err, db.ErrInsufficientFunds) {
+			NewErrorWithCode(ctx, http.StatusUnprocessableEntity, errCodeInsufficientFunds, err)
+			return
+		}
+		if errors.Is(err, db.ErrTransferLimitExceeded) {
+			NewErrorWithCode(ctx, http.StatusUnprocessableEntity, errCodeTransferLimitExceeded, err)
+			return
+		}
+		if accountStatusError(ctx, err) {
+			return
+		}
+		if errors.Is(err, db.ErrInvalidFxRate) {
+			NewErrorWithCode(ctx, http.StatusUnprocessableEntity, errCodeInvalidFxRate, err)
+			return
+		}
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	auditStored(ctx)
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+type batchTransferLeg struct {
+	ToAccountID int64 `json:"to_account_id" binding:"required,min=1"`
+	// Amount in minor units, or AmountDecimal as a decimal string like "12.34"
+	Amount        int64  `json:"amount" binding:"omitempty,gt=0"`
+	AmountDecimal string `json:"amount_decimal"`
+}
+
+type batchTransferRequest struct {
+	FromAccountID int64              `json:"from_account_id" binding:"required,min=1"`
+	Currency      string             `json:"currency" binding:"required,currency"`
+	Legs          []batchTransferLeg `json:"legs" binding:"required,min=1,max=100,dive"`
+}
+
+// @Summary      CreateBatchTransfer
+// @Security     ApiKeyAuth
+// @Tags         Transfer
+// @ID           create-batch-transfer
+// @Description  Transfer from one account to many in a single transaction, either every leg or none. Every leg goes through the fraud rules
+// @Accept       json
+// @Produce      json
+// @Param        input            body      batchTransferRequest  true   "Batch transfer info"
+// @Param        Idempotency-Key  header    string                false  "Key to safely retry the request"
+// @Success      200              {object}  db.BatchTransferTxResult
+// @Failure      400              {object}  errorResponse
+// @Failure      401              {object}  errorResponse
+// @Failure      404              {object}  errorResponse
+// @Failure      409              {object}  errorResponse
+// @Failure      422              {object}  errorResponse
+// @Failure      500              {object}  errorResponse
+// @Router       /transfers/batch [post]
+func (server *Server) createBatchTransfer(ctx *gin.Context) {
+	var req batchTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	for i, leg := range req.Legs {
+		amount, err := requestAmount(leg.Amount, leg.AmountDecimal, req.Currency)
+		if err != nil {
+			NewError(ctx, http.StatusBadRequest, fmt.Errorf("leg %d: %w", i, err))
+			return
+		}
+		req.Legs[i].Amount, req.Legs[i].AmountDecimal = amount, ""
+	}
+
+	fromAccount, valid := server.validDebitAccount(ctx, req.FromAccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*token.Payload)
+	if authPayload.Username != fromAccount.Owner {
+		err := errors.New("account doesn't belong to the authenticated user")
+		NewError(ctx, http.StatusUnauthorized, err)
+		return
+	}
+
+	legs := make([]db.BatchTransferLeg, len(req.Legs))
+	toAccounts := map[int64]db.Account{}
+	for i, leg := range req.Legs {
+		if leg.ToAccountID == req.FromAccountID {
+			err := fmt.Errorf("leg %d transfers to the source account", i)
+			NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		if _, checked := toAccounts[leg.ToAccountID]; !checked {
+			toAccount, valid := server.validAccount(ctx, leg.ToAccountID, req.Currency)
+			if !valid {
+				return
+			}
+			toAccounts[leg.ToAccountID] = toAccount
+		}
+		legs[i] = db.BatchTransferLeg{
+			ToAccountID: leg.ToAccountID,
+			Amount:      leg.Amount,
+		}
+	}
+
+	idem, err := newIdempotencyParams(ctx, authPayload.Username, req)
+	if err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if idem != nil && server.replayIdempotentResponse(ctx, idem) {
+		return
+	}
+
+	// every leg goes through the fraud rules, a denied leg stops the whole batch
+	for i := range legs {
+		flag, valid := server.checkFraud(ctx, fraud.Transfer{
+			Username:    authPayload.Username,
+			Amount:      legs[i].Amount,
+			FromAccount: fromAccount,
+			ToAccount:   toAccounts[legs[i].ToAccountID],
+		})
+		if !valid {
+			return
+		}
+		legs[i].Fraud = flag
+	}
+
+	arg := db.BatchTransferTxParams{
+		FromAccountID: req.FromAccountID,
+		Legs:          legs,
+		Idempotency:   idem,
+		Audit:         storeAudit(ctx),
+	}
+	result, err := server.store.BatchTransferTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrDuplicateIdempotencyKey) && server.replayIdempotentResponse(ctx, idem) {
+			return
+		}
+		if errors.Is(err, db.ErrInsufficientFunds) {
+			NewErrorWithCode(ctx, http.StatusUnprocessableEntity, errCodeInsufficientFunds, err)
+			return
+		}
+		if errors.Is(err, db.ErrTransferLimitExceeded) {
+			NewErrorWithCode(ctx, http.StatusUnprocessableEntity, errCodeTransferLimitExceeded, err)
+			return
+		}
+		if accountStatusError(ctx, err) {
+			return
+		}
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	auditStored(ctx)
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+type reverseTransferURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type reverseTransferRequest struct {
+	// Amount to take back from the recipient, everything that is left by default
+	Amount int64 `json:"amount" binding:"omitempty,gt=0"`
+}
+
+// @Summary      ReverseTransfer
+// @Security     ApiKeyAuth
+// @Tags         Transfer
+// @ID           reverse-transfer
+// @Description  Move money of a transfer back to the sender with a compensating transfer. Allowed to the recipient and to admins
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                     true   "Transfer ID"
+// @Param        input  body      reverseTransferRequest  false  "Amount to reverse"
+// @Success      200    {object}  db.ReverseTransferTxResult
+// @Failure      400    {object}  errorResponse
+// @Failure      401    {object}  errorResponse
+// @Failure      404    {object}  errorResponse
+// @Failure      422    {object}  errorResponse
+// @Failure      500    {object}  errorResponse
+// @Router       /transfers/{id}/reverse [post]
+func (server *Server) reverseTransfer(ctx *gin.Context) {
+	var uri reverseTransferURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	var req reverseTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	transfer, err := server.store.GetTransfer(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			NewError(ctx, http.StatusNotFound, err)
+			return
+		}
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	recipient, err := server.store.GetAccount(ctx, transfer.ToAccountID)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	authPayload := ctx.MustGet(authPayloadKey).(*token.Payload)
+	if authPayload.Username != recipient.Owner {
+		admin, err := server.isAdmin(ctx, authPayload.Username)
+		if err != nil {
+			NewError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		if !admin {
+			err := errors.New("only the recipient or an admin can reverse a transfer")
+			NewError(ctx, http.StatusUnauthorized, err)
+			return
+		}
+	}
+
+	arg := db.ReverseTransferTxParams{
+		TransferID: transfer.ID,
+		Amount:     req.Amount,
+		ReversedBy: authPayload.Username,
+	}
+	result, err := server.store.ReverseTransferTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidReversal) {
+			NewErrorWithCode(ctx, http.StatusUnprocessableEntity, errCodeInvalidReversal, err)
+			return
+		}
+		if errors.Is(err, db.ErrInsufficientFunds) {
+			NewErrorWithCode(ctx, http.StatusUnprocessableEntity, errCodeInsufficientFunds, err)
+			return
+		}
+		if accountStatusError(ctx, err) {
+			return
+		}
 		NewError(ctx, http.StatusInternalServerError, err)
 		return
 	}
@@ -71,6 +384,134 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, result)
 }
 
+type getTransferRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// @Summary      GetTransfer
+// @Security     ApiKeyAuth
+// @Tags         Transfer
+// @ID           get-transfer
+// @Description  Get a transfer with the owners of both accounts. Visible to the owners of either account
+// @Produce      json
+// @Param        id   path      int  true  "Transfer ID"
+// @Success      200  {object}  db.GetTransferWithOwnersRow
+// @Failure      400  {object}  errorResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      404  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Router       /transfers/{id} [get]
+func (server *Server) getTransfer(ctx *gin.Context) {
+	var req getTransferRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	transfer, err := server.store.GetTransferWithOwners(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			NewError(ctx, http.StatusNotFound, err)
+			return
+		}
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	authPayload := ctx.MustGet(authPayloadKey).(*token.Payload)
+	if authPayload.Username != transfer.FromOwner && authPayload.Username != transfer.ToOwner {
+		err := errors.New("transfer doesn't involve an account of the authenticated user")
+		NewError(ctx, http.StatusUnauthorized, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, transfer)
+}
+
+type listTransfersRequest struct {
+	AccountID int64  `form:"account_id" binding:"required,min=1"`
+	Direction string `form:"direction" binding:"omitempty,oneof=in out all"`
+	// From is included, To is not
+	From     time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To       time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Cursor   string    `form:"cursor"`
+	PageSize int32     `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+type listTransfersResponse struct {
+	Transfers []db.ListTransfersRow `json:"transfers"`
+	// NextCursor fetches the next page, it is empty on the last one
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// @Summary      ListTransfers
+// @Security     ApiKeyAuth
+// @Tags         Transfer
+// @ID           list-transfers
+// @Description  List the transfers of an account of the authenticated user, newest first, with the owners of both accounts. Pass next_cursor of a page as cursor with the same filters to get the next one
+// @Produce      json
+// @Param        account_id  query     int     true   "Account ID"
+// @Param        direction   query     string  false  "in, out or all (default)"
+// @Param        from        query     string  false  "RFC 3339 timestamp, included"
+// @Param        to          query     string  false  "RFC 3339 timestamp, excluded"
+// @Param        cursor      query     string  false  "next_cursor of the previous page"
+// @Param        page_size   query     int     true   "Page Size"
+// @Success      200         {object}  listTransfersResponse
+// @Failure      400         {object}  errorResponse
+// @Failure      401         {object}  errorResponse
+// @Failure      404         {object}  errorResponse
+// @Failure      500         {object}  errorResponse
+// @Router       /transfers [get]
+func (server *Server) listTransfers(ctx *gin.Context) {
+	var req listTransfersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	arg := db.ListTransfersParams{
+		AccountID: req.AccountID,
+		Direction: req.Direction,
+		FromTime:  req.From,
+		ToTime:    req.To,
+		// one more row tells whether there is a next page
+		RowLimit: req.PageSize + 1,
+	}
+	if arg.Direction == "" {
+		arg.Direction = "all"
+	}
+	if arg.ToTime.IsZero() {
+		arg.ToTime = maxCursorTime
+	}
+	// the first page starts at to
+	arg.CursorCreatedAt = arg.ToTime
+	if req.Cursor != "" {
+		var err error
+		arg.CursorCreatedAt, arg.CursorID, err = decodeCursor(req.Cursor)
+		if err != nil {
+			NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	if _, valid := server.ownAccount(ctx, req.AccountID); !valid {
+		return
+	}
+
+	transfers, err := server.store.ListTransfers(ctx, arg)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := listTransfersResponse{Transfers: transfers}
+	if len(transfers) > int(req.PageSize) {
+		resp.Transfers = transfers[:req.PageSize]
+		last := resp.Transfers[len(resp.Transfers)-1]
+		resp.NextCursor = encodeCursor(last.CreatedAt, last.ID)
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
 func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
 	account, err := server.store.GetAccount(ctx, accountID)
 	if err != nil {
@@ -86,6 +527,40 @@ func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency s
 		NewError(ctx, http.StatusBadRequest, err)
 		return account, false
 	}
+	if account.Status == util.AccountClosed {
+		err := fmt.Errorf("account [%d] is closed", accountID)
+		NewErrorWithCode(ctx, http.StatusUnprocessableEntity, errCodeAccountClosed, err)
+		return account, false
+	}
 
 	return account, true
 }
+
+// validDebitAccount is validAccount for an account money is taken from, which must not be frozen either
+func (server *Server) validDebitAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
+	account, valid := server.validAccount(ctx, accountID, currency)
+	if !valid {
+		return account, false
+	}
+	if account.Status == util.AccountFrozen {
+		err := fmt.Errorf("account [%d] is frozen", accountID)
+		NewErrorWithCode(ctx, http.StatusUnprocessableEntity, errCodeAccountFrozen, err)
+		return account, false
+	}
+
+	return account, true
+}
+
+// accountStatusError responds to an error of a frozen or closed account, which
+// the store may still return if the status changed after validDebitAccount checked it
+func accountStatusError(ctx *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, db.ErrAccountFrozen):
+		NewErrorWithCode(ctx, http.StatusUnprocessableEntity, errCodeAccountFrozen, err)
+	case errors.Is(err, db.ErrAccountClosed):
+		NewErrorWithCode(ctx, http.StatusUnprocessableEntity, errCodeAccountClosed, err)
+	default:
+		return false
+	}
+	return true
+}
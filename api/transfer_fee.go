@@ -0,0 +1,28 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary      ListTransferFees
+// @Security     ApiKeyAuth
+// @Tags         Transfer
+// @ID           list-transfer-fees
+// @Description  List the fee schedule. The most specific rule applies: currency first, then own or other accounts
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   db.TransferFee
+// @Failure      401  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Router       /transfer-fees [get]
+func (server *Server) listTransferFees(ctx *gin.Context) {
+	fees, err := server.store.ListTransferFees(ctx)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, fees)
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"simplebank/token"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary      TxRetryStats
+// @Security     ApiKeyAuth
+// @Tags         Admin
+// @ID           tx-retry-stats
+// @Description  Count the database transactions retried after a serialization failure or a deadlock since the server started. Admins only
+// @Produce      json
+// @Success      200  {object}  db.TxRetryStats
+// @Failure      401  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Router       /tx-stats [get]
+func (server *Server) getTxRetryStats(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authPayloadKey).(*token.Payload)
+	admin, err := server.isAdmin(ctx, authPayload.Username)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if !admin {
+		err := errors.New("only an admin can see transaction stats")
+		NewError(ctx, http.StatusUnauthorized, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.store.TxRetryStats())
+}
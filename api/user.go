@@ -54,20 +54,26 @@ func (server *Server) createUser(ctx *gin.Context) {
 		NewError(ctx, http.StatusBadRequest, err)
 		return
 	}
+	setAuditActor(ctx, req.Username)
+	setAuditTarget(ctx, "/users/"+req.Username)
+
 	hashedPassword, err := util.HashPassword(req.Password)
 	if err != nil {
 		NewError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
-	arg := db.CreateUserParams{
-		Username:       req.Username,
-		HashedPassword: hashedPassword,
-		FullName:       req.FullName,
-		Email:          req.Email,
+	arg := db.CreateUserTxParams{
+		CreateUserParams: db.CreateUserParams{
+			Username:       req.Username,
+			HashedPassword: hashedPassword,
+			FullName:       req.FullName,
+			Email:          req.Email,
+		},
+		Audit: storeAudit(ctx),
 	}
 
-	user, err := server.store.CreateUser(ctx, arg)
+	user, err := server.store.CreateUserTx(ctx, arg)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code.Name() {
@@ -79,6 +85,7 @@ func (server *Server) createUser(ctx *gin.Context) {
 		NewError(ctx, http.StatusInternalServerError, err)
 		return
 	}
+	auditStored(ctx)
 
 	resp := newUserResponse(user)
 
@@ -114,6 +121,8 @@ func (server *Server) loginUser(ctx *gin.Context) {
 		NewError(ctx, http.StatusBadRequest, err)
 		return
 	}
+	setAuditActor(ctx, req.Username)
+	setAuditTarget(ctx, "/users/"+req.Username)
 
 	user, err := server.store.GetUser(ctx, req.Username)
 	if err != nil {
@@ -144,3 +153,12 @@ func (server *Server) loginUser(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, res)
 }
+
+// isAdmin reports whether the user has the admin role
+func (server *Server) isAdmin(ctx *gin.Context, username string) (bool, error) {
+	user, err := server.store.GetUser(ctx, username)
+	if err != nil {
+		return false, err
+	}
+	return user.Role == util.AdminRole, nil
+}
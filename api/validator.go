@@ -12,3 +12,24 @@ var validCurrency validator.Func = func(fieldLevel validator.FieldLevel) bool {
 	}
 	return false
 }
+
+var validFrequency validator.Func = func(fieldLevel validator.FieldLevel) bool {
+	if frequency, ok := fieldLevel.Field().Interface().(string); ok {
+		return util.IsFrequencySupport(frequency)
+	}
+	return false
+}
+
+var validWebhookEvent validator.Func = func(fieldLevel validator.FieldLevel) bool {
+	if event, ok := fieldLevel.Field().Interface().(string); ok {
+		return util.IsWebhookEventSupport(event)
+	}
+	return false
+}
+
+var validWebhookURL validator.Func = func(fieldLevel validator.FieldLevel) bool {
+	if rawURL, ok := fieldLevel.Field().Interface().(string); ok {
+		return util.IsWebhookURL(rawURL)
+	}
+	return false
+}
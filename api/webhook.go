@@ -0,0 +1,386 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	db "simplebank/db/sqlc"
+	"simplebank/token"
+	"simplebank/util"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type webhookSubscriptionResponse struct {
+	ID         int64     `json:"id"`
+	Url        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func newWebhookSubscriptionResponse(subscription db.WebhookSubscription) webhookSubscriptionResponse {
+	return webhookSubscriptionResponse{
+		ID:         subscription.ID,
+		Url:        subscription.Url,
+		EventTypes: subscription.EventTypes,
+		CreatedAt:  subscription.CreatedAt,
+	}
+}
+
+// createWebhookSubscriptionResponse is the only response with the secret,
+// it can't be read again after the subscription is created
+type createWebhookSubscriptionResponse struct {
+	webhookSubscriptionResponse
+	Secret string `json:"secret"`
+}
+
+type webhookDeliveryResponse struct {
+	ID             int64           `json:"id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	EventID        int64           `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         string          `json:"status"`
+	Attempts       int32           `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastStatusCode int32           `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+}
+
+func newWebhookDeliveryResponse(delivery db.WebhookDelivery) webhookDeliveryResponse {
+	resp := webhookDeliveryResponse{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+	}
+	if delivery.DeliveredAt.Valid {
+		resp.DeliveredAt = &delivery.DeliveredAt.Time
+	}
+	return resp
+}
+
+type webhookDeliveryDetailsResponse struct {
+	webhookDeliveryResponse
+	AttemptLog []db.WebhookAttempt `json:"attempt_log"`
+}
+
+type createWebhookSubscriptionRequest struct {
+	// Url is an http or https URL on a public address
+	Url        string   `json:"url" binding:"required,webhookurl,max=2048"`
+	EventTypes []string `json:"event_types" binding:"required,min=1,dive,webhookevent"`
+}
+
+// @Summary      CreateWebhookSubscription
+// @Security     ApiKeyAuth
+// @Tags         Webhook
+// @ID           create-webhook-subscription
+// @Description  Subscribe an http or https URL to events. Loopback, private and link-local addresses are refused.
+// @Description  The response has the secret that signs every delivery, it is returned only once
+// @Accept       json
+// @Produce      json
+// @Param        input  body      createWebhookSubscriptionRequest  true  "Webhook URL and event types"
+// @Success      200    {object}  createWebhookSubscriptionResponse
+// @Failure      400    {object}  errorResponse
+// @Failure      401    {object}  errorResponse
+// @Failure      500    {object}  errorResponse
+// @Router       /webhooks [post]
+func (server *Server) createWebhookSubscription(ctx *gin.Context) {
+	var req createWebhookSubscriptionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	// the worker checks the address again on every delivery, in case the host resolves elsewhere by then
+	if err := util.CheckWebhookHost(ctx, server.lookupIP, req.Url); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	secret, err := util.NewWebhookSecret()
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*token.Payload)
+	arg := db.CreateWebhookSubscriptionParams{
+		Owner:      authPayload.Username,
+		Url:        req.Url,
+		EventTypes: uniqueStrings(req.EventTypes),
+		Secret:     secret,
+	}
+	subscription, err := server.store.CreateWebhookSubscription(ctx, arg)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, createWebhookSubscriptionResponse{
+		webhookSubscriptionResponse: newWebhookSubscriptionResponse(subscription),
+		Secret:                      subscription.Secret,
+	})
+}
+
+// @Summary      ListWebhookSubscriptions
+// @Security     ApiKeyAuth
+// @Tags         Webhook
+// @ID           list-webhook-subscriptions
+// @Description  List webhook subscriptions of the user
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   webhookSubscriptionResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Router       /webhooks [get]
+func (server *Server) listWebhookSubscriptions(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authPayloadKey).(*token.Payload)
+
+	subscriptions, err := server.store.ListWebhookSubscriptions(ctx, authPayload.Username)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := make([]webhookSubscriptionResponse, len(subscriptions))
+	for i := range subscriptions {
+		resp[i] = newWebhookSubscriptionResponse(subscriptions[i])
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type webhookURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// @Summary      DeleteWebhookSubscription
+// @Security     ApiKeyAuth
+// @Tags         Webhook
+// @ID           delete-webhook-subscription
+// @Description  Delete a webhook subscription together with its deliveries
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Webhook subscription ID"
+// @Success      200  {object}  webhookSubscriptionResponse
+// @Failure      400  {object}  errorResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      404  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Router       /webhooks/{id} [delete]
+func (server *Server) deleteWebhookSubscription(ctx *gin.Context) {
+	var uri webhookURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	subscription, valid := server.ownWebhookSubscription(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	if err := server.store.DeleteWebhookSubscription(ctx, uri.ID); err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newWebhookSubscriptionResponse(subscription))
+}
+
+type listWebhookDeliveriesRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// @Summary      ListWebhookDeliveries
+// @Security     ApiKeyAuth
+// @Tags         Webhook
+// @ID           list-webhook-deliveries
+// @Description  List the deliveries of a webhook subscription, the latest first
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int  true  "Webhook subscription ID"
+// @Param        page_id    query     int  true  "Page ID"
+// @Param        page_size  query     int  true  "Page Size"
+// @Success      200        {array}   webhookDeliveryResponse
+// @Failure      400        {object}  errorResponse
+// @Failure      401        {object}  errorResponse
+// @Failure      404        {object}  errorResponse
+// @Failure      500        {object}  errorResponse
+// @Router       /webhooks/{id}/deliveries [get]
+func (server *Server) listWebhookDeliveries(ctx *gin.Context) {
+	var uri webhookURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	var req listWebhookDeliveriesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, valid := server.ownWebhookSubscription(ctx, uri.ID); !valid {
+		return
+	}
+
+	arg := db.ListWebhookDeliveriesParams{
+		SubscriptionID: uri.ID,
+		Limit:          req.PageSize,
+		Offset:         (req.PageID - 1) * req.PageSize,
+	}
+	deliveries, err := server.store.ListWebhookDeliveries(ctx, arg)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := make([]webhookDeliveryResponse, len(deliveries))
+	for i := range deliveries {
+		resp[i] = newWebhookDeliveryResponse(deliveries[i])
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// @Summary      GetWebhookDelivery
+// @Security     ApiKeyAuth
+// @Tags         Webhook
+// @ID           get-webhook-delivery
+// @Description  Get a webhook delivery with the log of its attempts
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Webhook delivery ID"
+// @Success      200  {object}  webhookDeliveryDetailsResponse
+// @Failure      400  {object}  errorResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      404  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Router       /webhook-deliveries/{id} [get]
+func (server *Server) getWebhookDelivery(ctx *gin.Context) {
+	var uri webhookURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	delivery, valid := server.ownWebhookDelivery(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	attempts, err := server.store.ListWebhookAttempts(ctx, delivery.ID)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, webhookDeliveryDetailsResponse{
+		webhookDeliveryResponse: newWebhookDeliveryResponse(delivery),
+		AttemptLog:              attempts,
+	})
+}
+
+// @Summary      ReplayWebhookDelivery
+// @Security     ApiKeyAuth
+// @Tags         Webhook
+// @ID           replay-webhook-delivery
+// @Description  Send a delivered or failed webhook delivery again, with a fresh number of attempts
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Webhook delivery ID"
+// @Success      200  {object}  webhookDeliveryResponse
+// @Failure      400  {object}  errorResponse
+// @Failure      401  {object}  errorResponse
+// @Failure      404  {object}  errorResponse
+// @Failure      409  {object}  errorResponse
+// @Failure      500  {object}  errorResponse
+// @Router       /webhook-deliveries/{id}/replay [post]
+func (server *Server) replayWebhookDelivery(ctx *gin.Context) {
+	var uri webhookURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	delivery, valid := server.ownWebhookDelivery(ctx, uri.ID)
+	if !valid {
+		return
+	}
+	if delivery.Status == db.WebhookDeliveryPending {
+		err := errors.New("webhook delivery is still pending")
+		NewError(ctx, http.StatusConflict, err)
+		return
+	}
+
+	delivery, err := server.store.ReplayWebhookDelivery(ctx, uri.ID)
+	if err != nil {
+		NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newWebhookDeliveryResponse(delivery))
+}
+
+// ownWebhookSubscription loads a webhook subscription and checks that it belongs to the authenticated user
+func (server *Server) ownWebhookSubscription(ctx *gin.Context, id int64) (db.WebhookSubscription, bool) {
+	subscription, err := server.store.GetWebhookSubscription(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			NewError(ctx, http.StatusNotFound, err)
+			return subscription, false
+		}
+		NewError(ctx, http.StatusInternalServerError, err)
+		return subscription, false
+	}
+
+	authPayload := ctx.MustGet(authPayloadKey).(*token.Payload)
+	if authPayload.Username != subscription.Owner {
+		err := errors.New("webhook subscription doesn't belong to the authenticated user")
+		NewError(ctx, http.StatusUnauthorized, err)
+		return subscription, false
+	}
+
+	return subscription, true
+}
+
+// ownWebhookDelivery loads a webhook delivery and checks that its subscription belongs to the authenticated user
+func (server *Server) ownWebhookDelivery(ctx *gin.Context, id int64) (db.WebhookDelivery, bool) {
+	delivery, err := server.store.GetWebhookDelivery(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			NewError(ctx, http.StatusNotFound, err)
+			return delivery, false
+		}
+		NewError(ctx, http.StatusInternalServerError, err)
+		return delivery, false
+	}
+
+	if _, valid := server.ownWebhookSubscription(ctx, delivery.SubscriptionID); !valid {
+		return delivery, false
+	}
+	return delivery, true
+}
+
+// uniqueStrings removes repeated values keeping the order of the first ones
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,27 @@
+CREATE TABLE "fx_rates" (
+  "id" bigserial PRIMARY KEY,
+  "from_currency" varchar NOT NULL,
+  "to_currency" varchar NOT NULL,
+  "rate" numeric NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "fx_rates" ADD CONSTRAINT "fx_rate_positive" CHECK ("rate" > 0);
+
+ALTER TABLE "fx_rates" ADD CONSTRAINT "fx_rate_currencies_differ" CHECK ("from_currency" <> "to_currency");
+
+CREATE INDEX ON "fx_rates" ("from_currency", "to_currency", "created_at");
+
+COMMENT ON COLUMN "fx_rates"."rate" IS 'units of to_currency for one unit of from_currency';
+
+ALTER TABLE "transfers" ADD COLUMN "to_amount" bigint;
+
+UPDATE "transfers" SET "to_amount" = "amount";
+
+ALTER TABLE "transfers" ALTER COLUMN "to_amount" SET NOT NULL;
+
+ALTER TABLE "transfers" ADD COLUMN "fx_rate" numeric NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN "transfers"."to_amount" IS 'amount credited to the destination account, in its currency';
+
+COMMENT ON COLUMN "transfers"."fx_rate" IS 'rate applied to amount to get to_amount';
@@ -0,0 +1,55 @@
+CREATE TABLE "standing_orders" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "frequency" varchar NOT NULL,
+  "day_of_month" int NOT NULL,
+  "next_run_at" timestamptz NOT NULL,
+  "end_at" timestamptz,
+  "status" varchar NOT NULL DEFAULT 'active',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "standing_order_runs" (
+  "id" bigserial PRIMARY KEY,
+  "standing_order_id" bigint NOT NULL,
+  "run_at" timestamptz NOT NULL,
+  "status" varchar NOT NULL,
+  "transfer_id" bigint,
+  "failure_reason" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "standing_orders" ADD CONSTRAINT "standing_order_amount_positive" CHECK ("amount" > 0);
+
+ALTER TABLE "standing_orders" ADD CONSTRAINT "standing_order_day_of_month_valid" CHECK ("day_of_month" BETWEEN 1 AND 31);
+
+ALTER TABLE "standing_order_runs" ADD FOREIGN KEY ("standing_order_id") REFERENCES "standing_orders" ("id");
+
+ALTER TABLE "standing_order_runs" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "standing_orders" ("owner");
+
+CREATE INDEX ON "standing_orders" ("status", "next_run_at");
+
+CREATE UNIQUE INDEX ON "standing_order_runs" ("standing_order_id", "run_at");
+
+COMMENT ON COLUMN "standing_orders"."frequency" IS 'daily, weekly or monthly';
+
+COMMENT ON COLUMN "standing_orders"."day_of_month" IS 'monthly runs happen on this day, or on the last day of shorter months';
+
+COMMENT ON COLUMN "standing_orders"."end_at" IS 'no runs are made after it';
+
+COMMENT ON COLUMN "standing_orders"."status" IS 'active, canceled or finished';
+
+COMMENT ON COLUMN "standing_order_runs"."run_at" IS 'the period the run pays for';
+
+COMMENT ON COLUMN "standing_order_runs"."status" IS 'completed or failed';
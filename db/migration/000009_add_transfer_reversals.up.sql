@@ -0,0 +1,18 @@
+CREATE TABLE "transfer_reversals" (
+  "reversal_id" bigint PRIMARY KEY,
+  "transfer_id" bigint NOT NULL,
+  "reversed_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("reversal_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("reversed_by") REFERENCES "users" ("username");
+
+CREATE INDEX ON "transfer_reversals" ("transfer_id");
+
+COMMENT ON COLUMN "transfer_reversals"."reversal_id" IS 'the compensating transfer';
+
+COMMENT ON COLUMN "transfer_reversals"."transfer_id" IS 'the transfer being reversed';
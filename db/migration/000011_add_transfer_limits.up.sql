@@ -0,0 +1,20 @@
+CREATE TABLE "transfer_limits" (
+  "username" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "daily_limit" bigint,
+  "monthly_limit" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("username", "currency")
+);
+
+ALTER TABLE "transfer_limits" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_limits" ADD CONSTRAINT "daily_limit_non_negative" CHECK ("daily_limit" >= 0);
+
+ALTER TABLE "transfer_limits" ADD CONSTRAINT "monthly_limit_non_negative" CHECK ("monthly_limit" >= 0);
+
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
+
+COMMENT ON COLUMN "transfer_limits"."daily_limit" IS 'overrides the default daily limit, 0 means no limit';
+
+COMMENT ON COLUMN "transfer_limits"."monthly_limit" IS 'overrides the default monthly limit, 0 means no limit';
@@ -0,0 +1,25 @@
+ALTER TABLE "entries" ADD COLUMN "transfer_id" bigint;
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "entries" ("transfer_id");
+
+COMMENT ON COLUMN "entries"."transfer_id" IS 'the transfer the entry belongs to';
+
+-- link the existing entries to their transfers, both are created in one transaction and share created_at.
+-- Entries that match more than one transfer are left unlinked and show up in the reconciliation report
+WITH matches AS (
+  SELECT e.id AS entry_id, t.id AS transfer_id
+  FROM entries e
+  JOIN transfers t ON t.created_at = e.created_at
+  WHERE (e.account_id = t.from_account_id AND e.amount = -t.amount)
+     OR (e.account_id = t.to_account_id AND e.amount = t.to_amount)
+), unique_matches AS (
+  SELECT entry_id, MIN(transfer_id) AS transfer_id
+  FROM matches
+  GROUP BY entry_id
+  HAVING COUNT(*) = 1
+)
+UPDATE entries e SET transfer_id = m.transfer_id
+FROM unique_matches m
+WHERE e.id = m.entry_id;
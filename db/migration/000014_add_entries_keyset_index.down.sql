@@ -0,0 +1,3 @@
+CREATE INDEX ON "entries" ("account_id", "created_at");
+
+DROP INDEX IF EXISTS "entries_account_id_created_at_id_idx";
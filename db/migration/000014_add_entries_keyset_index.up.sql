@@ -0,0 +1,5 @@
+-- serves the (created_at, id) keyset pagination of the entries of an account,
+-- and everything the (account_id, created_at) index did
+CREATE INDEX ON "entries" ("account_id", "created_at", "id");
+
+DROP INDEX IF EXISTS "entries_account_id_created_at_idx";
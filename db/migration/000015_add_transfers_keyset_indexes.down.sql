@@ -0,0 +1,5 @@
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
+
+DROP INDEX IF EXISTS "transfers_to_account_id_created_at_id_idx";
+
+DROP INDEX IF EXISTS "transfers_from_account_id_created_at_id_idx";
@@ -0,0 +1,7 @@
+-- serve the (created_at, id) keyset pagination of the transfers of an account in both directions,
+-- the first one also covers what the (from_account_id, created_at) index did
+CREATE INDEX ON "transfers" ("from_account_id", "created_at", "id");
+
+CREATE INDEX ON "transfers" ("to_account_id", "created_at", "id");
+
+DROP INDEX IF EXISTS "transfers_from_account_id_created_at_idx";
@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS "interest_postings";
+
+DROP TABLE IF EXISTS "interest_accruals";
+
+-- bank accounts that were used stay with their owner, their entries can't be undone
+DELETE FROM "accounts" a WHERE a."owner" = '_bank'
+AND NOT EXISTS (SELECT 1 FROM "entries" e WHERE e."account_id" = a."id");
+
+DELETE FROM "users" u WHERE u."username" = '_bank'
+AND NOT EXISTS (SELECT 1 FROM "accounts" a WHERE a."owner" = u."username");
+
+ALTER TABLE IF EXISTS "accounts" DROP CONSTRAINT IF EXISTS "owner_currency_product_key";
+
+ALTER TABLE IF EXISTS "accounts" DROP COLUMN IF EXISTS "product";
+
+ALTER TABLE IF EXISTS "accounts" ADD CONSTRAINT "owner_currency_key" UNIQUE ("owner", "currency");
+
+DROP TABLE IF EXISTS "account_products";
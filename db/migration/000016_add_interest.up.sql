@@ -0,0 +1,73 @@
+CREATE TABLE "account_products" (
+  "code" varchar PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "annual_rate" numeric NOT NULL DEFAULT 0,
+  "day_count" varchar NOT NULL DEFAULT 'ACT/365',
+  "internal" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "account_products" ADD CONSTRAINT "annual_rate_non_negative" CHECK ("annual_rate" >= 0);
+
+ALTER TABLE "account_products" ADD CONSTRAINT "day_count_supported" CHECK ("day_count" IN ('ACT/365', '30/360'));
+
+INSERT INTO "account_products" ("code", "name", "internal") VALUES
+  ('current', 'Current account', false),
+  ('interest_expense', 'Interest expense', true);
+
+ALTER TABLE "accounts" ADD COLUMN "product" varchar NOT NULL DEFAULT 'current';
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("product") REFERENCES "account_products" ("code");
+
+-- a user may hold an account of every product in every currency
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "owner_currency_key";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "owner_currency_product_key" UNIQUE ("owner", "currency", "product");
+
+-- owns the internal accounts, the username can't be registered and the password hash matches no password
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email") VALUES
+  ('_bank', '!', 'Simple Bank', 'bank@simplebank.invalid');
+
+CREATE TABLE "interest_accruals" (
+  "account_id" bigint NOT NULL,
+  "day" date NOT NULL,
+  "balance" bigint NOT NULL,
+  "annual_rate" numeric NOT NULL,
+  "day_count" varchar NOT NULL,
+  "amount" numeric NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("account_id", "day")
+);
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+CREATE TABLE "interest_postings" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "month" date NOT NULL,
+  "amount" bigint NOT NULL,
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "interest_postings" ADD CONSTRAINT "account_month_key" UNIQUE ("account_id", "month");
+
+COMMENT ON COLUMN "account_products"."annual_rate" IS 'nominal yearly interest rate, 0.05 is 5%';
+
+COMMENT ON COLUMN "account_products"."day_count" IS 'ACT/365 or 30/360';
+
+COMMENT ON COLUMN "account_products"."internal" IS 'only for accounts of the bank';
+
+COMMENT ON COLUMN "interest_accruals"."balance" IS 'closing balance of the day';
+
+COMMENT ON COLUMN "interest_accruals"."amount" IS 'interest earned that day, not rounded';
+
+COMMENT ON COLUMN "interest_postings"."month" IS 'first day of the month the interest was accrued in';
+
+COMMENT ON COLUMN "interest_postings"."amount" IS 'sum of the accruals of the month, rounded';
+
+COMMENT ON COLUMN "interest_postings"."transfer_id" IS 'transfer from the interest expense account, null when the amount rounds to zero';
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS "transfer_fees";
+
+-- fee income accounts that were used keep their product, their entries can't be undone
+DELETE FROM "accounts" a WHERE a."product" = 'fee_income'
+AND NOT EXISTS (SELECT 1 FROM "entries" e WHERE e."account_id" = a."id");
+
+DELETE FROM "account_products" p WHERE p."code" = 'fee_income'
+AND NOT EXISTS (SELECT 1 FROM "accounts" a WHERE a."product" = p."code");
@@ -0,0 +1,25 @@
+CREATE TABLE "transfer_fees" (
+  "currency" varchar NOT NULL DEFAULT '',
+  "scope" varchar NOT NULL DEFAULT 'any',
+  "flat_fee" bigint NOT NULL DEFAULT 0,
+  "rate" numeric NOT NULL DEFAULT 0,
+  "min_fee" bigint NOT NULL DEFAULT 0,
+  "max_fee" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("currency", "scope")
+);
+
+ALTER TABLE "transfer_fees" ADD CONSTRAINT "scope_supported" CHECK ("scope" IN ('any', 'own', 'other'));
+
+ALTER TABLE "transfer_fees" ADD CONSTRAINT "fees_non_negative" CHECK ("flat_fee" >= 0 AND "rate" >= 0 AND "min_fee" >= 0 AND "max_fee" >= 0);
+
+INSERT INTO "account_products" ("code", "name", "internal") VALUES
+  ('fee_income', 'Fee income', true);
+
+COMMENT ON COLUMN "transfer_fees"."currency" IS 'currency of the source account, empty for any currency';
+
+COMMENT ON COLUMN "transfer_fees"."scope" IS 'any, own for transfers between accounts of the same user, other otherwise';
+
+COMMENT ON COLUMN "transfer_fees"."rate" IS 'share of the amount, 0.01 is 1%';
+
+COMMENT ON COLUMN "transfer_fees"."max_fee" IS '0 for no maximum';
@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS "accounts" DROP CONSTRAINT IF EXISTS "status_supported";
+
+ALTER TABLE IF EXISTS "accounts" DROP COLUMN IF EXISTS "status";
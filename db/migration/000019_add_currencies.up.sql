@@ -0,0 +1,25 @@
+CREATE TABLE "currencies" (
+  "code" varchar PRIMARY KEY,
+  "minor_units" int NOT NULL,
+  "enabled" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "currencies" ADD CONSTRAINT "code_iso_4217" CHECK ("code" ~ '^[A-Z]{3}$');
+
+ALTER TABLE "currencies" ADD CONSTRAINT "minor_units_range" CHECK ("minor_units" BETWEEN 0 AND 4);
+
+INSERT INTO "currencies" ("code", "minor_units", "enabled") VALUES
+  ('USD', 2, true),
+  ('EUR', 2, true),
+  ('GBP', 2, false),
+  ('JPY', 0, false),
+  ('KWD', 3, false);
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+COMMENT ON COLUMN "currencies"."code" IS 'ISO 4217 alphabetic code';
+
+COMMENT ON COLUMN "currencies"."minor_units" IS 'decimals of an amount, 2 for USD, 0 for JPY, 3 for KWD';
+
+COMMENT ON COLUMN "currencies"."enabled" IS 'new accounts, transfers and rates are only accepted in enabled currencies';
@@ -0,0 +1,17 @@
+CREATE TABLE "outbox_events" (
+  "id" bigserial PRIMARY KEY,
+  "aggregate_type" varchar NOT NULL,
+  "aggregate_id" varchar NOT NULL,
+  "event_type" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "published_at" timestamptz
+);
+
+CREATE INDEX ON "outbox_events" ("id") WHERE "published_at" IS NULL;
+
+COMMENT ON COLUMN "outbox_events"."aggregate_type" IS 'transfer, account or user';
+
+COMMENT ON COLUMN "outbox_events"."event_type" IS 'e.g. transfer.completed, account.created, user.registered';
+
+COMMENT ON COLUMN "outbox_events"."published_at" IS 'null until the relay has published the event';
@@ -0,0 +1,45 @@
+CREATE TABLE "audit_log" (
+  "id" bigserial PRIMARY KEY,
+  "actor" varchar NOT NULL,
+  "ip" varchar NOT NULL,
+  "user_agent" varchar NOT NULL,
+  "request_id" varchar NOT NULL,
+  "action" varchar NOT NULL,
+  "target" varchar NOT NULL,
+  "outcome" varchar NOT NULL,
+  "detail" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "audit_log" ("created_at");
+
+CREATE INDEX ON "audit_log" ("actor", "created_at");
+
+CREATE INDEX ON "audit_log" ("action", "created_at");
+
+CREATE INDEX ON "audit_log" ("request_id");
+
+COMMENT ON COLUMN "audit_log"."actor" IS 'username of the caller, empty for an anonymous request';
+
+COMMENT ON COLUMN "audit_log"."action" IS 'API handler, e.g. createTransfer';
+
+COMMENT ON COLUMN "audit_log"."target" IS 'path of the affected resource, e.g. /accounts/1';
+
+COMMENT ON COLUMN "audit_log"."outcome" IS 'success or failure';
+
+COMMENT ON COLUMN "audit_log"."detail" IS 'the error of a failed request';
+
+-- the audit log is append-only, records can't be changed or removed
+CREATE FUNCTION audit_log_immutable() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_no_update_delete
+  BEFORE UPDATE OR DELETE ON "audit_log"
+  FOR EACH ROW EXECUTE FUNCTION audit_log_immutable();
+
+CREATE TRIGGER audit_log_no_truncate
+  BEFORE TRUNCATE ON "audit_log"
+  FOR EACH STATEMENT EXECUTE FUNCTION audit_log_immutable();
@@ -0,0 +1,32 @@
+CREATE TABLE "fraud_flags" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "decision" varchar NOT NULL,
+  "rule" varchar NOT NULL,
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "fraud_flags" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "fraud_flags" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "fraud_flags" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "fraud_flags" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "fraud_flags" ("created_at");
+
+CREATE INDEX ON "fraud_flags" ("decision", "created_at");
+
+CREATE INDEX ON "fraud_flags" ("username", "created_at");
+
+COMMENT ON COLUMN "fraud_flags"."decision" IS 'review: the transfer was made or scheduled, deny: it was blocked';
+
+COMMENT ON COLUMN "fraud_flags"."rule" IS 'name of the fraud rule that decided';
+
+COMMENT ON COLUMN "fraud_flags"."transfer_id" IS 'the transfer made after a review decision, null if it was denied or is only scheduled';
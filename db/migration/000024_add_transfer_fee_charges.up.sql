@@ -0,0 +1,13 @@
+CREATE TABLE "transfer_fee_charges" (
+  "transfer_id" bigint PRIMARY KEY,
+  "fee_transfer_id" bigint UNIQUE NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "transfer_fee_charges" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_fee_charges" ADD FOREIGN KEY ("fee_transfer_id") REFERENCES "transfers" ("id");
+
+COMMENT ON COLUMN "transfer_fee_charges"."transfer_id" IS 'the transfer the fee was charged for';
+
+COMMENT ON COLUMN "transfer_fee_charges"."fee_transfer_id" IS 'the transfer of the fee to the fee income account, a reversal refunds it in part or in full';
@@ -0,0 +1,3 @@
+ALTER TABLE "scheduled_transfers" ADD COLUMN "claimed_until" timestamptz;
+
+COMMENT ON COLUMN "scheduled_transfers"."claimed_until" IS 'a worker that claimed the transfer runs it until then, after that another worker may claim it again';
@@ -2,9 +2,10 @@
 INSERT INTO accounts (
     owner,
     balance,
-    currency
+    currency,
+    product
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4
 )
 RETURNING *;
 
@@ -34,4 +35,36 @@ UPDATE accounts SET balance = balance + sqlc.arg(amount)
 WHERE id = sqlc.arg(id)
 RETURNING *;
 -- name: DeleteAccount :exec
-DELETE FROM accounts WHERE id = $1;
\ No newline at end of file
+DELETE FROM accounts WHERE id = $1;
+
+-- name: UpdateAccountOverdraftLimit :one
+UPDATE accounts SET overdraft_limit = sqlc.arg(overdraft_limit)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: AddAccountHeldAmount :one
+UPDATE accounts SET held_amount = held_amount + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: GetAccountByProduct :one
+SELECT * FROM accounts
+WHERE owner = $1 AND currency = $2 AND product = $3
+LIMIT 1;
+
+-- name: CreateBankAccount :exec
+INSERT INTO accounts (
+    owner,
+    balance,
+    currency,
+    product,
+    overdraft_limit
+) VALUES (
+  $1, 0, $2, $3, $4
+)
+ON CONFLICT (owner, currency, product) DO NOTHING;
+
+-- name: UpdateAccountStatus :one
+UPDATE accounts SET status = $1
+WHERE id = $2
+RETURNING *;
@@ -0,0 +1,19 @@
+-- name: GetAccountProduct :one
+SELECT * FROM account_products
+WHERE code = $1 LIMIT 1;
+
+-- name: SetAccountProduct :one
+INSERT INTO account_products (
+    code,
+    name,
+    annual_rate,
+    day_count
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT (code) DO UPDATE SET
+    name = EXCLUDED.name,
+    annual_rate = EXCLUDED.annual_rate,
+    day_count = EXCLUDED.day_count
+WHERE NOT account_products.internal
+RETURNING *;
@@ -0,0 +1,27 @@
+-- name: CreateAuditLog :one
+INSERT INTO audit_log (
+    actor,
+    ip,
+    user_agent,
+    request_id,
+    action,
+    target,
+    outcome,
+    detail
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: ListAuditLogs :many
+SELECT * FROM audit_log
+WHERE (sqlc.arg(actor)::varchar = '' OR actor = sqlc.arg(actor))
+AND (sqlc.arg(action)::varchar = '' OR action = sqlc.arg(action))
+AND (sqlc.arg(target)::varchar = '' OR target = sqlc.arg(target))
+AND (sqlc.arg(outcome)::varchar = '' OR outcome = sqlc.arg(outcome))
+AND (sqlc.arg(request_id)::varchar = '' OR request_id = sqlc.arg(request_id))
+AND created_at >= sqlc.arg(from_time)
+AND created_at < sqlc.arg(to_time)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
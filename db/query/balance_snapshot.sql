@@ -0,0 +1,56 @@
+-- name: CreateBalanceSnapshots :execrows
+INSERT INTO balance_snapshots (
+    account_id,
+    snapshot_at,
+    balance
+)
+SELECT
+    a.id,
+    sqlc.arg(snapshot_at)::timestamptz,
+    COALESCE(s.balance, 0) + (
+        SELECT COALESCE(SUM(e.amount), 0)
+        FROM entries e
+        WHERE e.account_id = a.id
+        AND e.created_at >= COALESCE(s.snapshot_at, '-infinity')
+        AND e.created_at < sqlc.arg(snapshot_at)
+    )
+FROM accounts a
+LEFT JOIN LATERAL (
+    SELECT snapshot_at, balance FROM balance_snapshots
+    WHERE account_id = a.id AND snapshot_at < sqlc.arg(snapshot_at)
+    ORDER BY snapshot_at DESC
+    LIMIT 1
+) s ON true
+WHERE EXISTS (
+    SELECT 1 FROM entries e
+    WHERE e.account_id = a.id
+    AND e.created_at >= COALESCE(s.snapshot_at, '-infinity')
+    AND e.created_at < sqlc.arg(snapshot_at)
+)
+ON CONFLICT (account_id, snapshot_at) DO NOTHING;
+
+-- name: GetBalanceBefore :one
+WITH snapshot AS (
+    SELECT snapshot_at, balance FROM balance_snapshots
+    WHERE account_id = sqlc.arg(account_id) AND snapshot_at <= sqlc.arg(before)
+    ORDER BY snapshot_at DESC
+    LIMIT 1
+)
+SELECT (
+    COALESCE((SELECT balance FROM snapshot), 0) + COALESCE(SUM(e.amount), 0)
+)::bigint AS balance
+FROM entries e
+WHERE e.account_id = sqlc.arg(account_id)
+AND e.created_at >= COALESCE((SELECT snapshot_at FROM snapshot), '-infinity')
+AND e.created_at < sqlc.arg(before);
+
+-- name: ListDailyEntryTotals :many
+SELECT
+    date_trunc('day', created_at, 'UTC') AS day,
+    SUM(amount)::bigint AS total
+FROM entries
+WHERE account_id = sqlc.arg(account_id)
+AND created_at >= sqlc.arg(from_time)
+AND created_at < sqlc.arg(to_time)
+GROUP BY day
+ORDER BY day;
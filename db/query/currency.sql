@@ -0,0 +1,16 @@
+-- name: ListCurrencies :many
+SELECT * FROM currencies
+ORDER BY code;
+
+-- name: SetCurrency :one
+INSERT INTO currencies (
+    code,
+    minor_units,
+    enabled
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (code) DO UPDATE SET
+    enabled = EXCLUDED.enabled
+WHERE currencies.minor_units = EXCLUDED.minor_units
+RETURNING *;
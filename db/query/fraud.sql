@@ -0,0 +1,34 @@
+-- name: CreateFraudFlag :one
+INSERT INTO fraud_flags (
+    username,
+    from_account_id,
+    to_account_id,
+    amount,
+    currency,
+    decision,
+    rule,
+    transfer_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: ListFraudFlags :many
+SELECT * FROM fraud_flags
+WHERE (sqlc.arg(username)::varchar = '' OR username = sqlc.arg(username))
+AND (sqlc.arg(decision)::varchar = '' OR decision = sqlc.arg(decision))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: CountPayeeTransfers :one
+SELECT count(*) FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = $1 AND t.to_account_id = $2;
+
+-- name: GetOutgoingTransferStats :one
+SELECT count(*) AS count, COALESCE(SUM(t.amount), 0)::bigint AS total
+FROM transfers t
+WHERE t.from_account_id = $1 AND t.created_at >= $2
+AND NOT EXISTS (SELECT 1 FROM transfer_reversals r WHERE r.reversal_id = t.id)
+AND NOT EXISTS (SELECT 1 FROM accounts f WHERE f.id = t.to_account_id AND f.product = 'fee_income');
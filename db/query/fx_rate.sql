@@ -0,0 +1,19 @@
+-- name: CreateFxRate :one
+INSERT INTO fx_rates (
+    from_currency,
+    to_currency,
+    rate
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: GetFxRate :one
+SELECT * FROM fx_rates
+WHERE id = $1 LIMIT 1;
+
+-- name: GetLatestFxRate :one
+SELECT * FROM fx_rates
+WHERE from_currency = $1 AND to_currency = $2
+ORDER BY created_at DESC, id DESC
+LIMIT 1;
@@ -0,0 +1,36 @@
+-- name: CreateHold :one
+INSERT INTO holds (
+    account_id,
+    to_account_id,
+    amount,
+    expires_at
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetHold :one
+SELECT * FROM holds
+WHERE id = $1 LIMIT 1;
+
+-- name: GetHoldForUpdate :one
+SELECT * FROM holds
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListExpiredHolds :many
+SELECT * FROM holds
+WHERE status = 'active' AND expires_at <= now()
+ORDER BY expires_at, id
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: CaptureHold :one
+UPDATE holds SET status = 'captured', captured_amount = $2, transfer_id = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateHoldStatus :one
+UPDATE holds SET status = $2
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,64 @@
+-- name: ListAccountsToAccrue :many
+SELECT a.id, p.annual_rate, p.day_count
+FROM accounts a
+JOIN account_products p ON p.code = a.product
+WHERE p.annual_rate > 0
+AND a.status <> 'closed'
+AND a.created_at < sqlc.arg(day_end)
+AND NOT EXISTS (
+    SELECT 1 FROM interest_accruals i
+    WHERE i.account_id = a.id AND i.day = sqlc.arg(day)
+)
+AND NOT EXISTS (
+    SELECT 1 FROM interest_postings ip
+    WHERE ip.account_id = a.id AND ip.month = sqlc.arg(month)
+)
+ORDER BY a.id;
+
+-- name: CreateInterestAccrual :execrows
+INSERT INTO interest_accruals (
+    account_id,
+    day,
+    balance,
+    annual_rate,
+    day_count,
+    amount
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (account_id, day) DO NOTHING;
+
+-- name: ListInterestAccruals :many
+SELECT * FROM interest_accruals
+WHERE account_id = $1 AND day >= $2 AND day < $3
+ORDER BY day;
+
+-- name: ListUnpostedInterest :many
+SELECT i.account_id, a.currency, SUM(i.amount)::numeric AS amount
+FROM interest_accruals i
+JOIN accounts a ON a.id = i.account_id
+WHERE i.day >= sqlc.arg(month) AND i.day < sqlc.arg(next_month)
+AND a.status <> 'closed'
+AND NOT EXISTS (
+    SELECT 1 FROM interest_postings p
+    WHERE p.account_id = i.account_id AND p.month = sqlc.arg(month)
+)
+GROUP BY i.account_id, a.currency
+ORDER BY i.account_id
+LIMIT sqlc.arg(row_limit);
+
+-- name: CreateInterestPosting :one
+INSERT INTO interest_postings (
+    account_id,
+    month,
+    amount
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (account_id, month) DO NOTHING
+RETURNING *;
+
+-- name: SetInterestPostingTransfer :one
+UPDATE interest_postings SET transfer_id = $2
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,50 @@
+-- name: ListBalanceMismatches :many
+SELECT
+    a.id AS account_id,
+    a.currency,
+    a.balance,
+    COALESCE(SUM(e.amount), 0)::bigint AS entries_total
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id
+GROUP BY a.id
+HAVING a.balance <> COALESCE(SUM(e.amount), 0)
+ORDER BY a.id;
+
+-- name: ListTransferEntryMismatches :many
+SELECT
+    t.id AS transfer_id,
+    COUNT(e.id) AS entries,
+    COUNT(e.id) FILTER (WHERE e.account_id = t.from_account_id AND e.amount = -t.amount) AS from_entries,
+    COUNT(e.id) FILTER (WHERE e.account_id = t.to_account_id AND e.amount = t.to_amount) AS to_entries
+FROM transfers t
+LEFT JOIN entries e ON e.transfer_id = t.id
+GROUP BY t.id
+HAVING COUNT(e.id) <> 2
+    OR COUNT(e.id) FILTER (WHERE e.account_id = t.from_account_id AND e.amount = -t.amount) <> 1
+    OR COUNT(e.id) FILTER (WHERE e.account_id = t.to_account_id AND e.amount = t.to_amount) <> 1
+ORDER BY t.id;
+
+-- name: ListCurrencyImbalances :many
+SELECT
+    flows.currency,
+    SUM(flows.amount)::bigint AS net
+FROM (
+    SELECT a.currency, e.amount
+    FROM entries e
+    JOIN accounts a ON a.id = e.account_id
+    UNION ALL
+    SELECT f.currency, t.amount
+    FROM transfers t
+    JOIN accounts f ON f.id = t.from_account_id
+    JOIN accounts r ON r.id = t.to_account_id
+    WHERE f.currency <> r.currency
+    UNION ALL
+    SELECT r.currency, -t.to_amount
+    FROM transfers t
+    JOIN accounts f ON f.id = t.from_account_id
+    JOIN accounts r ON r.id = t.to_account_id
+    WHERE f.currency <> r.currency
+) AS flows
+GROUP BY flows.currency
+HAVING SUM(flows.amount) <> 0
+ORDER BY flows.currency;
@@ -0,0 +1,50 @@
+-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfers (
+    owner,
+    from_account_id,
+    to_account_id,
+    amount,
+    execute_at
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetScheduledTransfer :one
+SELECT * FROM scheduled_transfers
+WHERE id = $1 LIMIT 1;
+
+-- name: ListScheduledTransfers :many
+SELECT * FROM scheduled_transfers
+WHERE owner = $1 AND status = $2
+ORDER BY execute_at, id
+LIMIT $3
+OFFSET $4;
+
+-- name: ClaimDueScheduledTransfers :many
+UPDATE scheduled_transfers SET claimed_until = sqlc.arg(lease_until)
+WHERE id IN (
+    SELECT id FROM scheduled_transfers
+    WHERE status = 'pending' AND execute_at <= now()
+      AND (claimed_until IS NULL OR claimed_until <= now())
+    ORDER BY execute_at, id
+    LIMIT sqlc.arg('limit')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CancelScheduledTransfer :one
+UPDATE scheduled_transfers SET status = 'canceled'
+WHERE id = $1 AND status = 'pending'
+  AND (claimed_until IS NULL OR claimed_until <= now())
+RETURNING *;
+
+-- name: CompleteScheduledTransfer :one
+UPDATE scheduled_transfers SET status = 'completed', transfer_id = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: FailScheduledTransfer :one
+UPDATE scheduled_transfers SET status = 'failed', failure_reason = $2
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,66 @@
+-- name: CreateStandingOrder :one
+INSERT INTO standing_orders (
+    owner,
+    from_account_id,
+    to_account_id,
+    amount,
+    frequency,
+    day_of_month,
+    next_run_at,
+    end_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: GetStandingOrder :one
+SELECT * FROM standing_orders
+WHERE id = $1 LIMIT 1;
+
+-- name: ListStandingOrders :many
+SELECT * FROM standing_orders
+WHERE owner = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: UpdateStandingOrder :one
+UPDATE standing_orders SET amount = $2, end_at = $3
+WHERE id = $1 AND status = 'active'
+RETURNING *;
+
+-- name: CancelStandingOrder :one
+UPDATE standing_orders SET status = 'canceled'
+WHERE id = $1 AND status = 'active'
+RETURNING *;
+
+-- name: ListDueStandingOrders :many
+SELECT * FROM standing_orders
+WHERE status = 'active' AND next_run_at <= now()
+ORDER BY next_run_at, id
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: UpdateStandingOrderSchedule :one
+UPDATE standing_orders SET next_run_at = $2, status = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateStandingOrderRun :one
+INSERT INTO standing_order_runs (
+    standing_order_id,
+    run_at,
+    status,
+    transfer_id,
+    failure_reason
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: ListStandingOrderRuns :many
+SELECT * FROM standing_order_runs
+WHERE standing_order_id = $1
+ORDER BY run_at DESC
+LIMIT $2
+OFFSET $3;
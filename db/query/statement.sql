@@ -0,0 +1,15 @@
+-- name: ListStatementEntries :many
+SELECT
+    e.id,
+    e.amount,
+    e.created_at,
+    e.transfer_id,
+    COALESCE(CASE WHEN t.from_account_id = e.account_id THEN t.to_account_id ELSE t.from_account_id END, 0)::bigint AS counterparty_account_id
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id
+WHERE e.account_id = sqlc.arg(account_id)
+AND e.created_at >= sqlc.arg(from_time)
+AND e.created_at < sqlc.arg(to_time)
+AND e.id > sqlc.arg(after_id)
+ORDER BY e.id
+LIMIT sqlc.arg(row_limit);
@@ -0,0 +1,44 @@
+-- name: SetTransferFee :one
+INSERT INTO transfer_fees (
+    currency,
+    scope,
+    flat_fee,
+    rate,
+    min_fee,
+    max_fee
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (currency, scope) DO UPDATE SET
+    flat_fee = EXCLUDED.flat_fee,
+    rate = EXCLUDED.rate,
+    min_fee = EXCLUDED.min_fee,
+    max_fee = EXCLUDED.max_fee
+RETURNING *;
+
+-- name: DeleteTransferFee :execrows
+DELETE FROM transfer_fees
+WHERE currency = $1 AND scope = $2;
+
+-- name: GetTransferFee :one
+SELECT * FROM transfer_fees
+WHERE currency IN ($1, '') AND scope IN ($2, 'any')
+ORDER BY currency = '', scope = 'any'
+LIMIT 1;
+
+-- name: ListTransferFees :many
+SELECT * FROM transfer_fees
+ORDER BY currency, scope;
+
+-- name: CreateTransferFeeCharge :one
+INSERT INTO transfer_fee_charges (
+    transfer_id,
+    fee_transfer_id
+) VALUES (
+  $1, $2
+)
+RETURNING *;
+
+-- name: GetTransferFeeCharge :one
+SELECT * FROM transfer_fee_charges
+WHERE transfer_id = $1 LIMIT 1;
@@ -0,0 +1,24 @@
+-- name: SetTransferLimit :one
+INSERT INTO transfer_limits (
+    username,
+    currency,
+    daily_limit,
+    monthly_limit
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT (username, currency) DO UPDATE
+SET daily_limit = EXCLUDED.daily_limit, monthly_limit = EXCLUDED.monthly_limit
+RETURNING *;
+
+-- name: GetTransferLimit :one
+SELECT * FROM transfer_limits
+WHERE username = $1 AND currency = $2 LIMIT 1;
+
+-- name: GetOutgoingTransferTotal :one
+SELECT COALESCE(SUM(t.amount), 0)::bigint AS total
+FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = $1 AND a.currency = $2 AND t.created_at >= $3
+AND NOT EXISTS (SELECT 1 FROM transfer_reversals r WHERE r.reversal_id = t.id)
+AND NOT EXISTS (SELECT 1 FROM accounts f WHERE f.id = t.to_account_id AND f.product = 'fee_income');
@@ -0,0 +1,21 @@
+-- name: CreateTransferReversal :one
+INSERT INTO transfer_reversals (
+    reversal_id,
+    transfer_id,
+    reversed_by
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: GetTransferReversal :one
+SELECT * FROM transfer_reversals
+WHERE reversal_id = $1 LIMIT 1;
+
+-- name: GetReversedAmounts :one
+SELECT
+    COALESCE(SUM(t.amount), 0)::bigint AS amount,
+    COALESCE(SUM(t.to_amount), 0)::bigint AS to_amount
+FROM transfer_reversals r
+JOIN transfers t ON t.id = r.reversal_id
+WHERE r.transfer_id = $1;
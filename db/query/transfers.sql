@@ -2,9 +2,11 @@
 INSERT INTO transfers (
     from_account_id,
     to_account_id,
-    amount
+    amount,
+    to_amount,
+    fx_rate
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5
 )
 RETURNING *;
 
@@ -13,10 +15,28 @@ SELECT * FROM transfers
 WHERE id = $1 LIMIT 1;
 
 -- name: ListTransfers :many
+SELECT t.*, fa.owner AS from_owner, ta.owner AS to_owner
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+JOIN accounts ta ON ta.id = t.to_account_id
+WHERE (
+    (sqlc.arg(direction)::text IN ('all', 'out') AND t.from_account_id = sqlc.arg(account_id))
+    OR (sqlc.arg(direction) IN ('all', 'in') AND t.to_account_id = sqlc.arg(account_id))
+)
+AND t.created_at >= sqlc.arg(from_time)
+AND t.created_at < sqlc.arg(to_time)
+AND (t.created_at, t.id) < (sqlc.arg(cursor_created_at)::timestamptz, sqlc.arg(cursor_id)::bigint)
+ORDER BY t.created_at DESC, t.id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: GetTransferForUpdate :one
 SELECT * FROM transfers
-WHERE 
-    from_account_id = $1 OR 
-    to_account_id = $2
-ORDER BY id
-LIMIT $3
-OFFSET $4;
\ No newline at end of file
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: GetTransferWithOwners :one
+SELECT t.*, fa.owner AS from_owner, ta.owner AS to_owner
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+JOIN accounts ta ON ta.id = t.to_account_id
+WHERE t.id = $1 LIMIT 1;
@@ -0,0 +1,91 @@
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (
+    owner,
+    url,
+    event_types,
+    secret
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetWebhookSubscription :one
+SELECT * FROM webhook_subscriptions
+WHERE id = $1 LIMIT 1;
+
+-- name: ListWebhookSubscriptions :many
+SELECT * FROM webhook_subscriptions
+WHERE owner = $1
+ORDER BY id;
+
+-- name: DeleteWebhookSubscription :exec
+DELETE FROM webhook_subscriptions WHERE id = $1;
+
+-- name: CreateWebhookDeliveries :execrows
+INSERT INTO webhook_deliveries (
+    subscription_id,
+    event_id,
+    event_type,
+    payload
+)
+SELECT id, sqlc.arg(event_id)::bigint, sqlc.arg(event_type)::varchar, sqlc.arg(payload)::jsonb
+FROM webhook_subscriptions
+WHERE owner = sqlc.arg(owner) AND sqlc.arg(event_type)::varchar = ANY(event_types)
+ON CONFLICT (subscription_id, event_id, event_type) DO NOTHING;
+
+-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_deliveries SET next_attempt_at = sqlc.arg(lease_until)
+WHERE id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE status = 'pending' AND next_attempt_at <= now()
+    ORDER BY next_attempt_at
+    LIMIT sqlc.arg('limit')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: GetWebhookDelivery :one
+SELECT * FROM webhook_deliveries
+WHERE id = $1 LIMIT 1;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE subscription_id = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: UpdateWebhookDeliveryAttempt :one
+UPDATE webhook_deliveries SET
+    status = $2,
+    attempts = attempts + 1,
+    next_attempt_at = $3,
+    last_status_code = $4,
+    last_error = $5,
+    delivered_at = $6
+WHERE id = $1
+RETURNING *;
+
+-- name: ReplayWebhookDelivery :one
+UPDATE webhook_deliveries SET
+    status = 'pending',
+    attempts = 0,
+    next_attempt_at = now(),
+    delivered_at = NULL
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateWebhookAttempt :one
+INSERT INTO webhook_attempts (
+    delivery_id,
+    status_code,
+    error
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: ListWebhookAttempts :many
+SELECT * FROM webhook_attempts
+WHERE delivery_id = $1
+ORDER BY id;
@@ -0,0 +1,120 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+// listenAccountEvents listens on AccountEventsChannel until the test ends
+func listenAccountEvents(t *testing.T) *pq.Listener {
+	listener := pq.NewListener(testDBSource, time.Second, time.Minute, nil)
+	require.NoError(t, listener.Listen(AccountEventsChannel))
+	t.Cleanup(func() { listener.Close() })
+	return listener
+}
+
+// receiveAccountEvents returns the events of an account received within a second, until n are received
+func receiveAccountEvents(t *testing.T, listener *pq.Listener, accountID int64, n int) []AccountEvent {
+	var events []AccountEvent
+	timeout := time.After(time.Second)
+	for len(events) < n {
+		select {
+		case notification := <-listener.Notify:
+			require.NotNil(t, notification)
+			var event AccountEvent
+			require.NoError(t, json.Unmarshal([]byte(notification.Extra), &event))
+			if event.AccountID == accountID {
+				events = append(events, event)
+			}
+		case <-timeout:
+			return events
+		}
+	}
+	return events
+}
+
+func TestTransferTxAccountEvents(t *testing.T) {
+	store := NewStore(testDB)
+	listener := listenAccountEvents(t)
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 100)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	fromEvents := receiveAccountEvents(t, listener, account1.ID, 1)
+	require.Len(t, fromEvents, 1)
+	require.Equal(t, result.FromEntry.ID, fromEvents[0].Entry.ID)
+	require.Equal(t, result.FromAccount.Balance, fromEvents[0].Balance)
+	require.Equal(t, result.FromAccount.AvailableBalance, fromEvents[0].AvailableBalance)
+
+	toEvents := receiveAccountEvents(t, listener, account2.ID, 1)
+	require.Len(t, toEvents, 1)
+	require.Equal(t, result.ToEntry.ID, toEvents[0].Entry.ID)
+	require.Equal(t, int64(110), toEvents[0].Balance)
+}
+
+func TestHoldAccountEvents(t *testing.T) {
+	store := NewStore(testDB)
+	listener := listenAccountEvents(t)
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccount(t)
+
+	// a hold changes the available balance only, without an entry
+	hold := createRandomHold(t, store, account1, account2, 60, time.Now().Add(time.Hour))
+	events := receiveAccountEvents(t, listener, account1.ID, 1)
+	require.Len(t, events, 1)
+	require.Nil(t, events[0].Entry)
+	require.Equal(t, int64(100), events[0].Balance)
+	require.Equal(t, int64(40), events[0].AvailableBalance)
+
+	_, err := store.VoidHoldTx(context.Background(), hold.ID)
+	require.NoError(t, err)
+	events = receiveAccountEvents(t, listener, account1.ID, 1)
+	require.Len(t, events, 1)
+	require.Nil(t, events[0].Entry)
+	require.Equal(t, int64(100), events[0].AvailableBalance)
+}
+
+func TestTransferTxAccountEventsRolledBack(t *testing.T) {
+	store := NewStore(testDB)
+	listener := listenAccountEvents(t)
+	account1 := createRandomAccountWithBalance(t, 10)
+	account2 := createRandomAccountWithBalance(t, 10)
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// notifications of a transaction that rolled back are never delivered
+	require.Empty(t, receiveAccountEvents(t, listener, account1.ID, 1))
+}
+
+func TestListEntriesAfter(t *testing.T) {
+	account := createRandomAccount(t)
+	entry1 := createRandomEntry(t, account)
+	entry2 := createRandomEntry(t, account)
+	entry3 := createRandomEntry(t, account)
+
+	entries, err := testQueries.ListEntriesAfter(context.Background(), ListEntriesAfterParams{
+		AccountID: account.ID,
+		AfterID:   entry1.ID,
+		Limit:     5,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, entry2.ID, entries[0].ID)
+	require.Equal(t, entry3.ID, entries[1].ID)
+}
@@ -0,0 +1,202 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"simplebank/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createSecondAccount opens another account of the owner of account in the same currency, under a new product
+func createSecondAccount(t *testing.T, account Account) Account {
+	product, err := testQueries.SetAccountProduct(context.Background(), SetAccountProductParams{
+		Code:       "pocket_" + util.RandomString(8),
+		Name:       "Pocket",
+		AnnualRate: "0",
+		DayCount:   util.DayCountACT365,
+	})
+	require.NoError(t, err)
+
+	second, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    account.Owner,
+		Balance:  0,
+		Currency: account.Currency,
+		Product:  product.Code,
+	})
+	require.NoError(t, err)
+	return second
+}
+
+func TestFreezeAccount(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createRandomAccountWithCurrency(t, 100, util.USD)
+	account2 := createRandomAccountWithCurrency(t, 100, util.USD)
+
+	result, err := store.ChangeAccountStatusTx(context.Background(), ChangeAccountStatusTxParams{
+		AccountID: account1.ID,
+		Status:    util.AccountFrozen,
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.AccountFrozen, result.Account.Status)
+	require.Nil(t, result.Sweep)
+
+	// a frozen account receives money, but can't pay
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, ErrAccountFrozen)
+
+	_, err = store.CreateHoldTx(context.Background(), CreateHoldParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      10,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.ErrorIs(t, err, ErrAccountFrozen)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account1.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	_, err = store.ChangeAccountStatusTx(context.Background(), ChangeAccountStatusTxParams{
+		AccountID: account1.ID,
+		Status:    util.AccountClosed,
+	})
+	require.ErrorIs(t, err, ErrInvalidStatusChange)
+
+	result, err = store.ChangeAccountStatusTx(context.Background(), ChangeAccountStatusTxParams{
+		AccountID: account1.ID,
+		Status:    util.AccountActive,
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.AccountActive, result.Account.Status)
+	require.Equal(t, int64(110), result.Account.Balance)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+}
+
+func TestCloseAccount(t *testing.T) {
+	store := NewStore(testDB)
+	account := createRandomAccountWithCurrency(t, 100, util.USD)
+	second := createSecondAccount(t, account)
+	other := createRandomAccountWithCurrency(t, 0, util.USD)
+
+	testCases := []struct {
+		name           string
+		sweepAccountID int64
+	}{
+		{name: "NoSweepAccount", sweepAccountID: 0},
+		{name: "SameAccount", sweepAccountID: account.ID},
+		{name: "OtherOwner", sweepAccountID: other.ID},
+		{name: "NotFound", sweepAccountID: second.ID + 1000000},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := store.ChangeAccountStatusTx(context.Background(), ChangeAccountStatusTxParams{
+				AccountID:      account.ID,
+				Status:         util.AccountClosed,
+				SweepAccountID: tc.sweepAccountID,
+			})
+			require.ErrorIs(t, err, ErrInvalidStatusChange)
+		})
+	}
+
+	result, err := store.ChangeAccountStatusTx(context.Background(), ChangeAccountStatusTxParams{
+		AccountID:      account.ID,
+		Status:         util.AccountClosed,
+		SweepAccountID: second.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.AccountClosed, result.Account.Status)
+	require.Zero(t, result.Account.Balance)
+
+	require.NotNil(t, result.Sweep)
+	require.Equal(t, account.ID, result.Sweep.Transfer.FromAccountID)
+	require.Equal(t, second.ID, result.Sweep.Transfer.ToAccountID)
+	require.Equal(t, int64(100), result.Sweep.Transfer.Amount)
+	require.Equal(t, int64(100), result.Sweep.ToAccount.Balance)
+
+	// a closed account neither pays nor receives, and stays closed
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: second.ID,
+		ToAccountID:   account.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, ErrAccountClosed)
+
+	_, err = store.ChangeAccountStatusTx(context.Background(), ChangeAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    util.AccountActive,
+	})
+	require.ErrorIs(t, err, ErrInvalidStatusChange)
+
+	// an empty account closes without a sweep
+	result, err = store.ChangeAccountStatusTx(context.Background(), ChangeAccountStatusTxParams{
+		AccountID: other.ID,
+		Status:    util.AccountClosed,
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.AccountClosed, result.Account.Status)
+	require.Nil(t, result.Sweep)
+}
+
+func TestCloseAccountSweepLockOrder(t *testing.T) {
+	// without retries a deadlock would fail the transactions
+	store := NewStoreWithConfig(testDB, StoreConfig{})
+
+	// the sweep account has the lower ID, so the transfers into the closing account lock it first
+	sweep := createRandomAccountWithCurrency(t, 100, util.USD)
+	account := createSecondAccount(t, sweep)
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: sweep.ID,
+		ToAccountID:   account.ID,
+		Amount:        50,
+	})
+	require.NoError(t, err)
+
+	n := 10
+	errs := make(chan error)
+	go func() {
+		_, err := store.ChangeAccountStatusTx(context.Background(), ChangeAccountStatusTxParams{
+			AccountID:      account.ID,
+			Status:         util.AccountClosed,
+			SweepAccountID: sweep.ID,
+		})
+		errs <- err
+	}()
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := store.TransferTx(context.Background(), TransferTxParams{
+				FromAccountID: sweep.ID,
+				ToAccountID:   account.ID,
+				Amount:        1,
+			})
+			if errors.Is(err, ErrAccountClosed) {
+				err = nil
+			}
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < n+1; i++ {
+		require.NoError(t, <-errs)
+	}
+
+	// everything ends up back on the sweep account
+	updatedSweep, err := store.GetAccount(context.Background(), sweep.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), updatedSweep.Balance)
+}
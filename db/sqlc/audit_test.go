@@ -0,0 +1,175 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"simplebank/util"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomAuditLog(t *testing.T, actor string, outcome string) AuditLog {
+	arg := CreateAuditLogParams{
+		Actor:     actor,
+		Ip:        "192.0.2.1",
+		UserAgent: "audit-test",
+		RequestID: util.RandomString(12),
+		Action:    "createTransfer",
+		Target:    fmt.Sprintf("/transfers/%d", util.RandomInt(1, 1000)),
+		Outcome:   outcome,
+	}
+
+	record, err := testQueries.CreateAuditLog(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, record.ID)
+	require.Equal(t, arg.Actor, record.Actor)
+	require.Equal(t, arg.Ip, record.Ip)
+	require.Equal(t, arg.UserAgent, record.UserAgent)
+	require.Equal(t, arg.RequestID, record.RequestID)
+	require.Equal(t, arg.Action, record.Action)
+	require.Equal(t, arg.Target, record.Target)
+	require.Equal(t, arg.Outcome, record.Outcome)
+	require.NotZero(t, record.CreatedAt)
+
+	return record
+}
+
+func TestListAuditLogs(t *testing.T) {
+	actor := util.RandomOwner()
+	success := createRandomAuditLog(t, actor, AuditSuccess)
+	failure := createRandomAuditLog(t, actor, AuditFailure)
+	createRandomAuditLog(t, util.RandomOwner(), AuditSuccess)
+
+	arg := ListAuditLogsParams{
+		Actor:  actor,
+		ToTime: time.Now().Add(time.Minute),
+		Limit:  10,
+	}
+	records, err := testQueries.ListAuditLogs(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, []AuditLog{failure, success}, records)
+
+	arg.Outcome = AuditSuccess
+	records, err = testQueries.ListAuditLogs(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, []AuditLog{success}, records)
+
+	arg = ListAuditLogsParams{
+		RequestID: failure.RequestID,
+		ToTime:    time.Now().Add(time.Minute),
+		Limit:     10,
+	}
+	records, err = testQueries.ListAuditLogs(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, []AuditLog{failure}, records)
+}
+
+func TestAuditLogIsAppendOnly(t *testing.T) {
+	record := createRandomAuditLog(t, util.RandomOwner(), AuditSuccess)
+
+	_, err := testDB.Exec(`UPDATE audit_log SET outcome = 'failure' WHERE id = $1`, record.ID)
+	var pqErr *pq.Error
+	require.ErrorAs(t, err, &pqErr)
+	require.Contains(t, pqErr.Message, "append-only")
+
+	_, err = testDB.Exec(`DELETE FROM audit_log WHERE id = $1`, record.ID)
+	require.ErrorAs(t, err, &pqErr)
+	require.Contains(t, pqErr.Message, "append-only")
+}
+
+func TestTransferTxAudit(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 100)
+
+	audit := &AuditParams{
+		Actor:     account1.Owner,
+		IP:        "192.0.2.1",
+		UserAgent: "audit-test",
+		RequestID: util.RandomString(12),
+		Action:    "createTransfer",
+	}
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		Audit:         audit,
+	})
+	require.NoError(t, err)
+
+	records, err := testQueries.ListAuditLogs(context.Background(), ListAuditLogsParams{
+		RequestID: audit.RequestID,
+		ToTime:    time.Now().Add(time.Minute),
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, audit.Actor, records[0].Actor)
+	require.Equal(t, audit.Action, records[0].Action)
+	require.Equal(t, fmt.Sprintf("/transfers/%d", result.Transfer.ID), records[0].Target)
+	require.Equal(t, AuditSuccess, records[0].Outcome)
+}
+
+func TestBatchTransferTxAudit(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccount(t)
+	account3 := createRandomAccount(t)
+
+	audit := &AuditParams{
+		Actor:     account1.Owner,
+		IP:        "192.0.2.1",
+		UserAgent: "audit-test",
+		RequestID: util.RandomString(12),
+		Action:    "createBatchTransfer",
+	}
+	result, err := store.BatchTransferTx(context.Background(), BatchTransferTxParams{
+		FromAccountID: account1.ID,
+		Legs: []BatchTransferLeg{
+			{ToAccountID: account2.ID, Amount: 10},
+			{ToAccountID: account3.ID, Amount: 20},
+		},
+		Audit: audit,
+	})
+	require.NoError(t, err)
+
+	arg := ListAuditLogsParams{
+		RequestID: audit.RequestID,
+		ToTime:    time.Now().Add(time.Minute),
+		Limit:     10,
+	}
+	records, err := testQueries.ListAuditLogs(context.Background(), arg)
+	require.NoError(t, err)
+
+	// one record for every transfer of the batch
+	var targets []string
+	for _, record := range records {
+		require.Equal(t, audit.Actor, record.Actor)
+		require.Equal(t, audit.Action, record.Action)
+		require.Equal(t, AuditSuccess, record.Outcome)
+		targets = append(targets, record.Target)
+	}
+	require.ElementsMatch(t, []string{
+		fmt.Sprintf("/transfers/%d", result.Transfers[0].ID),
+		fmt.Sprintf("/transfers/%d", result.Transfers[1].ID),
+	}, targets)
+
+	// a batch that is rolled back leaves no record
+	arg.RequestID = util.RandomString(12)
+	audit.RequestID = arg.RequestID
+	_, err = store.BatchTransferTx(context.Background(), BatchTransferTxParams{
+		FromAccountID: account1.ID,
+		Legs: []BatchTransferLeg{
+			{ToAccountID: account2.ID, Amount: 1000},
+		},
+		Audit: audit,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	records, err = testQueries.ListAuditLogs(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, records)
+}
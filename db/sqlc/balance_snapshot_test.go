@@ -0,0 +1,73 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createEntryAt(t *testing.T, account Account, amount int64, createdAt time.Time) {
+	_, err := testDB.Exec("INSERT INTO entries (account_id, amount, created_at) VALUES ($1, $2, $3)",
+		account.ID, amount, createdAt)
+	require.NoError(t, err)
+}
+
+func TestBalanceSnapshots(t *testing.T) {
+	account := createRandomAccount(t)
+
+	day1 := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	day3 := day1.AddDate(0, 0, 2)
+	createEntryAt(t, account, 100, day1.Add(10*time.Hour))
+	createEntryAt(t, account, -30, day2.Add(10*time.Hour))
+	createEntryAt(t, account, 5, day3.Add(10*time.Hour))
+
+	balanceBefore := func(before time.Time) int64 {
+		balance, err := testQueries.GetBalanceBefore(context.Background(), GetBalanceBeforeParams{
+			AccountID: account.ID,
+			Before:    before,
+		})
+		require.NoError(t, err)
+		return balance
+	}
+
+	require.Equal(t, int64(0), balanceBefore(day1))
+	require.Equal(t, int64(100), balanceBefore(day2))
+
+	n, err := testQueries.CreateBalanceSnapshots(context.Background(), day2)
+	require.NoError(t, err)
+	require.True(t, n >= 1)
+	n, err = testQueries.CreateBalanceSnapshots(context.Background(), day3)
+	require.NoError(t, err)
+	require.True(t, n >= 1)
+
+	// taking the same snapshot again changes nothing
+	_, err = testQueries.CreateBalanceSnapshots(context.Background(), day3)
+	require.NoError(t, err)
+
+	var snapshot int64
+	err = testDB.QueryRow("SELECT balance FROM balance_snapshots WHERE account_id = $1 AND snapshot_at = $2",
+		account.ID, day3).Scan(&snapshot)
+	require.NoError(t, err)
+	require.Equal(t, int64(70), snapshot)
+
+	// balances combine the latest snapshot with the entries after it
+	require.Equal(t, int64(100), balanceBefore(day2))
+	require.Equal(t, int64(70), balanceBefore(day3))
+	require.Equal(t, int64(75), balanceBefore(day3.AddDate(0, 0, 1)))
+	require.Equal(t, int64(100), balanceBefore(day2.Add(10*time.Hour)))
+
+	totals, err := testQueries.ListDailyEntryTotals(context.Background(), ListDailyEntryTotalsParams{
+		AccountID: account.ID,
+		FromTime:  day2,
+		ToTime:    day3.AddDate(0, 0, 1),
+	})
+	require.NoError(t, err)
+	require.Len(t, totals, 2)
+	require.True(t, day2.Equal(totals[0].Day))
+	require.Equal(t, int64(-30), totals[0].Total)
+	require.True(t, day3.Equal(totals[1].Day))
+	require.Equal(t, int64(5), totals[1].Total)
+}
@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createRandomAccount(t)
+	account3 := createRandomAccount(t)
+
+	result, err := store.BatchTransferTx(context.Background(), BatchTransferTxParams{
+		FromAccountID: account1.ID,
+		Legs: []BatchTransferLeg{
+			{ToAccountID: account2.ID, Amount: 10},
+			{ToAccountID: account3.ID, Amount: 20},
+			{ToAccountID: account2.ID, Amount: 5},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result.Transfers, 3)
+	require.Len(t, result.FromEntries, 3)
+	require.Len(t, result.ToEntries, 3)
+	require.Len(t, result.ToAccounts, 3)
+	for i, amount := range []int64{10, 20, 5} {
+		require.Equal(t, account1.ID, result.Transfers[i].FromAccountID)
+		require.Equal(t, amount, result.Transfers[i].Amount)
+		require.Equal(t, -amount, result.FromEntries[i].Amount)
+		require.Equal(t, amount, result.ToEntries[i].Amount)
+	}
+
+	require.Equal(t, account1.Balance-35, result.FromAccount.Balance)
+	require.Equal(t, account2.Balance+15, result.ToAccounts[0].Balance)
+	require.Equal(t, account3.Balance+20, result.ToAccounts[1].Balance)
+	require.Equal(t, result.ToAccounts[0], result.ToAccounts[2])
+}
+
+func TestBatchTransferTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 20)
+	account2 := createRandomAccount(t)
+	account3 := createRandomAccount(t)
+
+	// each leg is covered on its own, the total is not
+	_, err := store.BatchTransferTx(context.Background(), BatchTransferTxParams{
+		FromAccountID: account1.ID,
+		Legs: []BatchTransferLeg{
+			{ToAccountID: account2.ID, Amount: 15},
+			{ToAccountID: account3.ID, Amount: 15},
+		},
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// the whole batch must be rolled back
+	for _, account := range []Account{account1, account2, account3} {
+		updated, err := store.GetAccount(context.Background(), account.ID)
+		require.NoError(t, err)
+		require.Equal(t, account.Balance, updated.Balance)
+	}
+}
+
+func TestBatchTransferTxDeadlock(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createRandomAccountWithBalance(t, 1000)
+	account3 := createRandomAccountWithBalance(t, 1000)
+
+	n := 10
+	amount := int64(10)
+	errs := make(chan error)
+
+	for i := 0; i < n; i++ {
+		// legs are listed in opposing orders to provoke lock conflicts
+		fromAccountID, legs := account1.ID, []BatchTransferLeg{
+			{ToAccountID: account3.ID, Amount: amount},
+			{ToAccountID: account2.ID, Amount: amount},
+		}
+		if i%2 == 1 {
+			fromAccountID, legs = account3.ID, []BatchTransferLeg{
+				{ToAccountID: account1.ID, Amount: amount},
+				{ToAccountID: account2.ID, Amount: amount},
+			}
+		}
+		go func() {
+			_, err := store.BatchTransferTx(context.Background(), BatchTransferTxParams{
+				FromAccountID: fromAccountID,
+				Legs:          legs,
+			})
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		require.NoError(t, <-errs)
+	}
+
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	updatedAccount2, err := store.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+	updatedAccount3, err := store.GetAccount(context.Background(), account3.ID)
+	require.NoError(t, err)
+
+	require.Equal(t, account1.Balance-int64(n/2)*amount, updatedAccount1.Balance)
+	require.Equal(t, account2.Balance+int64(n)*amount, updatedAccount2.Balance)
+	require.Equal(t, account3.Balance-int64(n/2)*amount, updatedAccount3.Balance)
+}
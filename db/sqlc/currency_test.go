@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"simplebank/util"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetCurrency(t *testing.T) {
+	code := "Q" + strings.ToUpper(util.RandomString(2))
+	// the code may be left from an earlier run
+	_, err := testDB.Exec("DELETE FROM currencies WHERE code = $1", code)
+	require.NoError(t, err)
+
+	currency, err := testQueries.SetCurrency(context.Background(), SetCurrencyParams{
+		Code:       code,
+		MinorUnits: 3,
+		Enabled:    false,
+	})
+	require.NoError(t, err)
+	require.Equal(t, code, currency.Code)
+	require.Equal(t, int32(3), currency.MinorUnits)
+	require.False(t, currency.Enabled)
+	require.NotZero(t, currency.CreatedAt)
+
+	currency, err = testQueries.SetCurrency(context.Background(), SetCurrencyParams{
+		Code:       code,
+		MinorUnits: 3,
+		Enabled:    true,
+	})
+	require.NoError(t, err)
+	require.True(t, currency.Enabled)
+
+	// the minor units give the meaning of every amount, so they never change
+	_, err = testQueries.SetCurrency(context.Background(), SetCurrencyParams{
+		Code:       code,
+		MinorUnits: 2,
+		Enabled:    true,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	currencies, err := testQueries.ListCurrencies(context.Background())
+	require.NoError(t, err)
+	found := false
+	for _, c := range currencies {
+		if c.Code == code {
+			found = true
+			require.Equal(t, int32(3), c.MinorUnits)
+		}
+	}
+	require.True(t, found)
+
+	_, err = testDB.Exec("DELETE FROM currencies WHERE code = $1", code)
+	require.NoError(t, err)
+}
+
+func TestCreateAccountUnknownCurrency(t *testing.T) {
+	user := createRandomUser(t)
+	_, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Balance:  0,
+		Currency: "QQQ",
+		Product:  ProductCurrent,
+	})
+	require.Error(t, err)
+}
@@ -25,48 +25,318 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.addAccountBalanceStmt, err = db.PrepareContext(ctx, addAccountBalance); err != nil {
 		return nil, fmt.Errorf("error preparing query AddAccountBalance: %w", err)
 	}
+	if q.addAccountHeldAmountStmt, err = db.PrepareContext(ctx, addAccountHeldAmount); err != nil {
+		return nil, fmt.Errorf("error preparing query AddAccountHeldAmount: %w", err)
+	}
+	if q.cancelScheduledTransferStmt, err = db.PrepareContext(ctx, cancelScheduledTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query CancelScheduledTransfer: %w", err)
+	}
+	if q.cancelStandingOrderStmt, err = db.PrepareContext(ctx, cancelStandingOrder); err != nil {
+		return nil, fmt.Errorf("error preparing query CancelStandingOrder: %w", err)
+	}
+	if q.captureHoldStmt, err = db.PrepareContext(ctx, captureHold); err != nil {
+		return nil, fmt.Errorf("error preparing query CaptureHold: %w", err)
+	}
+	if q.claimDueScheduledTransfersStmt, err = db.PrepareContext(ctx, claimDueScheduledTransfers); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimDueScheduledTransfers: %w", err)
+	}
+	if q.claimDueWebhookDeliveriesStmt, err = db.PrepareContext(ctx, claimDueWebhookDeliveries); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimDueWebhookDeliveries: %w", err)
+	}
+	if q.completeScheduledTransferStmt, err = db.PrepareContext(ctx, completeScheduledTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query CompleteScheduledTransfer: %w", err)
+	}
+	if q.countPayeeTransfersStmt, err = db.PrepareContext(ctx, countPayeeTransfers); err != nil {
+		return nil, fmt.Errorf("error preparing query CountPayeeTransfers: %w", err)
+	}
 	if q.createAccountStmt, err = db.PrepareContext(ctx, createAccount); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateAccount: %w", err)
 	}
+	if q.createAuditLogStmt, err = db.PrepareContext(ctx, createAuditLog); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateAuditLog: %w", err)
+	}
+	if q.createBalanceSnapshotsStmt, err = db.PrepareContext(ctx, createBalanceSnapshots); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateBalanceSnapshots: %w", err)
+	}
+	if q.createBankAccountStmt, err = db.PrepareContext(ctx, createBankAccount); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateBankAccount: %w", err)
+	}
 	if q.createEntryStmt, err = db.PrepareContext(ctx, createEntry); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateEntry: %w", err)
 	}
+	if q.createFraudFlagStmt, err = db.PrepareContext(ctx, createFraudFlag); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFraudFlag: %w", err)
+	}
+	if q.createFxRateStmt, err = db.PrepareContext(ctx, createFxRate); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFxRate: %w", err)
+	}
+	if q.createHoldStmt, err = db.PrepareContext(ctx, createHold); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateHold: %w", err)
+	}
+	if q.createIdempotencyKeyStmt, err = db.PrepareContext(ctx, createIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateIdempotencyKey: %w", err)
+	}
+	if q.createInterestAccrualStmt, err = db.PrepareContext(ctx, createInterestAccrual); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateInterestAccrual: %w", err)
+	}
+	if q.createInterestPostingStmt, err = db.PrepareContext(ctx, createInterestPosting); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateInterestPosting: %w", err)
+	}
+	if q.createOutboxEventStmt, err = db.PrepareContext(ctx, createOutboxEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateOutboxEvent: %w", err)
+	}
+	if q.createScheduledTransferStmt, err = db.PrepareContext(ctx, createScheduledTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateScheduledTransfer: %w", err)
+	}
+	if q.createStandingOrderStmt, err = db.PrepareContext(ctx, createStandingOrder); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateStandingOrder: %w", err)
+	}
+	if q.createStandingOrderRunStmt, err = db.PrepareContext(ctx, createStandingOrderRun); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateStandingOrderRun: %w", err)
+	}
 	if q.createTransferStmt, err = db.PrepareContext(ctx, createTransfer); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateTransfer: %w", err)
 	}
+	if q.createTransferFeeChargeStmt, err = db.PrepareContext(ctx, createTransferFeeCharge); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTransferFeeCharge: %w", err)
+	}
+	if q.createTransferReversalStmt, err = db.PrepareContext(ctx, createTransferReversal); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTransferReversal: %w", err)
+	}
 	if q.createUserStmt, err = db.PrepareContext(ctx, createUser); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateUser: %w", err)
 	}
+	if q.createWebhookAttemptStmt, err = db.PrepareContext(ctx, createWebhookAttempt); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWebhookAttempt: %w", err)
+	}
+	if q.createWebhookDeliveriesStmt, err = db.PrepareContext(ctx, createWebhookDeliveries); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWebhookDeliveries: %w", err)
+	}
+	if q.createWebhookSubscriptionStmt, err = db.PrepareContext(ctx, createWebhookSubscription); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWebhookSubscription: %w", err)
+	}
 	if q.deleteAccountStmt, err = db.PrepareContext(ctx, deleteAccount); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteAccount: %w", err)
 	}
+	if q.deleteTransferFeeStmt, err = db.PrepareContext(ctx, deleteTransferFee); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteTransferFee: %w", err)
+	}
+	if q.deleteWebhookSubscriptionStmt, err = db.PrepareContext(ctx, deleteWebhookSubscription); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteWebhookSubscription: %w", err)
+	}
+	if q.failScheduledTransferStmt, err = db.PrepareContext(ctx, failScheduledTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query FailScheduledTransfer: %w", err)
+	}
 	if q.getAccountStmt, err = db.PrepareContext(ctx, getAccount); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAccount: %w", err)
 	}
+	if q.getAccountByProductStmt, err = db.PrepareContext(ctx, getAccountByProduct); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAccountByProduct: %w", err)
+	}
 	if q.getAccountForUpdateStmt, err = db.PrepareContext(ctx, getAccountForUpdate); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAccountForUpdate: %w", err)
 	}
+	if q.getAccountProductStmt, err = db.PrepareContext(ctx, getAccountProduct); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAccountProduct: %w", err)
+	}
+	if q.getBalanceBeforeStmt, err = db.PrepareContext(ctx, getBalanceBefore); err != nil {
+		return nil, fmt.Errorf("error preparing query GetBalanceBefore: %w", err)
+	}
 	if q.getEntryStmt, err = db.PrepareContext(ctx, getEntry); err != nil {
 		return nil, fmt.Errorf("error preparing query GetEntry: %w", err)
 	}
+	if q.getFxRateStmt, err = db.PrepareContext(ctx, getFxRate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFxRate: %w", err)
+	}
+	if q.getHoldStmt, err = db.PrepareContext(ctx, getHold); err != nil {
+		return nil, fmt.Errorf("error preparing query GetHold: %w", err)
+	}
+	if q.getHoldForUpdateStmt, err = db.PrepareContext(ctx, getHoldForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetHoldForUpdate: %w", err)
+	}
+	if q.getIdempotencyKeyStmt, err = db.PrepareContext(ctx, getIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query GetIdempotencyKey: %w", err)
+	}
+	if q.getLatestFxRateStmt, err = db.PrepareContext(ctx, getLatestFxRate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLatestFxRate: %w", err)
+	}
+	if q.getOutgoingTransferStatsStmt, err = db.PrepareContext(ctx, getOutgoingTransferStats); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOutgoingTransferStats: %w", err)
+	}
+	if q.getOutgoingTransferTotalStmt, err = db.PrepareContext(ctx, getOutgoingTransferTotal); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOutgoingTransferTotal: %w", err)
+	}
+	if q.getReversedAmountsStmt, err = db.PrepareContext(ctx, getReversedAmounts); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReversedAmounts: %w", err)
+	}
+	if q.getScheduledTransferStmt, err = db.PrepareContext(ctx, getScheduledTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query GetScheduledTransfer: %w", err)
+	}
+	if q.getStandingOrderStmt, err = db.PrepareContext(ctx, getStandingOrder); err != nil {
+		return nil, fmt.Errorf("error preparing query GetStandingOrder: %w", err)
+	}
 	if q.getTransferStmt, err = db.PrepareContext(ctx, getTransfer); err != nil {
 		return nil, fmt.Errorf("error preparing query GetTransfer: %w", err)
 	}
+	if q.getTransferFeeStmt, err = db.PrepareContext(ctx, getTransferFee); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTransferFee: %w", err)
+	}
+	if q.getTransferFeeChargeStmt, err = db.PrepareContext(ctx, getTransferFeeCharge); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTransferFeeCharge: %w", err)
+	}
+	if q.getTransferForUpdateStmt, err = db.PrepareContext(ctx, getTransferForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTransferForUpdate: %w", err)
+	}
+	if q.getTransferLimitStmt, err = db.PrepareContext(ctx, getTransferLimit); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTransferLimit: %w", err)
+	}
+	if q.getTransferReversalStmt, err = db.PrepareContext(ctx, getTransferReversal); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTransferReversal: %w", err)
+	}
+	if q.getTransferWithOwnersStmt, err = db.PrepareContext(ctx, getTransferWithOwners); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTransferWithOwners: %w", err)
+	}
 	if q.getUserStmt, err = db.PrepareContext(ctx, getUser); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUser: %w", err)
 	}
+	if q.getUserForUpdateStmt, err = db.PrepareContext(ctx, getUserForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserForUpdate: %w", err)
+	}
+	if q.getWebhookDeliveryStmt, err = db.PrepareContext(ctx, getWebhookDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWebhookDelivery: %w", err)
+	}
+	if q.getWebhookSubscriptionStmt, err = db.PrepareContext(ctx, getWebhookSubscription); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWebhookSubscription: %w", err)
+	}
+	if q.listAccountEntriesStmt, err = db.PrepareContext(ctx, listAccountEntries); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAccountEntries: %w", err)
+	}
 	if q.listAccountsStmt, err = db.PrepareContext(ctx, listAccounts); err != nil {
 		return nil, fmt.Errorf("error preparing query ListAccounts: %w", err)
 	}
+	if q.listAccountsToAccrueStmt, err = db.PrepareContext(ctx, listAccountsToAccrue); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAccountsToAccrue: %w", err)
+	}
+	if q.listAuditLogsStmt, err = db.PrepareContext(ctx, listAuditLogs); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAuditLogs: %w", err)
+	}
+	if q.listBalanceMismatchesStmt, err = db.PrepareContext(ctx, listBalanceMismatches); err != nil {
+		return nil, fmt.Errorf("error preparing query ListBalanceMismatches: %w", err)
+	}
+	if q.listCurrenciesStmt, err = db.PrepareContext(ctx, listCurrencies); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCurrencies: %w", err)
+	}
+	if q.listCurrencyImbalancesStmt, err = db.PrepareContext(ctx, listCurrencyImbalances); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCurrencyImbalances: %w", err)
+	}
+	if q.listDailyEntryTotalsStmt, err = db.PrepareContext(ctx, listDailyEntryTotals); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDailyEntryTotals: %w", err)
+	}
+	if q.listDueStandingOrdersStmt, err = db.PrepareContext(ctx, listDueStandingOrders); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDueStandingOrders: %w", err)
+	}
 	if q.listEntriesStmt, err = db.PrepareContext(ctx, listEntries); err != nil {
 		return nil, fmt.Errorf("error preparing query ListEntries: %w", err)
 	}
+	if q.listEntriesAfterStmt, err = db.PrepareContext(ctx, listEntriesAfter); err != nil {
+		return nil, fmt.Errorf("error preparing query ListEntriesAfter: %w", err)
+	}
+	if q.listExpiredHoldsStmt, err = db.PrepareContext(ctx, listExpiredHolds); err != nil {
+		return nil, fmt.Errorf("error preparing query ListExpiredHolds: %w", err)
+	}
+	if q.listFraudFlagsStmt, err = db.PrepareContext(ctx, listFraudFlags); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFraudFlags: %w", err)
+	}
+	if q.listInterestAccrualsStmt, err = db.PrepareContext(ctx, listInterestAccruals); err != nil {
+		return nil, fmt.Errorf("error preparing query ListInterestAccruals: %w", err)
+	}
+	if q.listPendingOutboxEventsStmt, err = db.PrepareContext(ctx, listPendingOutboxEvents); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPendingOutboxEvents: %w", err)
+	}
+	if q.listScheduledTransfersStmt, err = db.PrepareContext(ctx, listScheduledTransfers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListScheduledTransfers: %w", err)
+	}
+	if q.listStandingOrderRunsStmt, err = db.PrepareContext(ctx, listStandingOrderRuns); err != nil {
+		return nil, fmt.Errorf("error preparing query ListStandingOrderRuns: %w", err)
+	}
+	if q.listStandingOrdersStmt, err = db.PrepareContext(ctx, listStandingOrders); err != nil {
+		return nil, fmt.Errorf("error preparing query ListStandingOrders: %w", err)
+	}
+	if q.listStatementEntriesStmt, err = db.PrepareContext(ctx, listStatementEntries); err != nil {
+		return nil, fmt.Errorf("error preparing query ListStatementEntries: %w", err)
+	}
+	if q.listTransferEntryMismatchesStmt, err = db.PrepareContext(ctx, listTransferEntryMismatches); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTransferEntryMismatches: %w", err)
+	}
+	if q.listTransferFeesStmt, err = db.PrepareContext(ctx, listTransferFees); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTransferFees: %w", err)
+	}
 	if q.listTransfersStmt, err = db.PrepareContext(ctx, listTransfers); err != nil {
 		return nil, fmt.Errorf("error preparing query ListTransfers: %w", err)
 	}
+	if q.listUnpostedInterestStmt, err = db.PrepareContext(ctx, listUnpostedInterest); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUnpostedInterest: %w", err)
+	}
+	if q.listWebhookAttemptsStmt, err = db.PrepareContext(ctx, listWebhookAttempts); err != nil {
+		return nil, fmt.Errorf("error preparing query ListWebhookAttempts: %w", err)
+	}
+	if q.listWebhookDeliveriesStmt, err = db.PrepareContext(ctx, listWebhookDeliveries); err != nil {
+		return nil, fmt.Errorf("error preparing query ListWebhookDeliveries: %w", err)
+	}
+	if q.listWebhookSubscriptionsStmt, err = db.PrepareContext(ctx, listWebhookSubscriptions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListWebhookSubscriptions: %w", err)
+	}
+	if q.markOutboxEventPublishedStmt, err = db.PrepareContext(ctx, markOutboxEventPublished); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkOutboxEventPublished: %w", err)
+	}
+	if q.notifyStmt, err = db.PrepareContext(ctx, notify); err != nil {
+		return nil, fmt.Errorf("error preparing query Notify: %w", err)
+	}
+	if q.replayWebhookDeliveryStmt, err = db.PrepareContext(ctx, replayWebhookDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query ReplayWebhookDelivery: %w", err)
+	}
+	if q.setAccountProductStmt, err = db.PrepareContext(ctx, setAccountProduct); err != nil {
+		return nil, fmt.Errorf("error preparing query SetAccountProduct: %w", err)
+	}
+	if q.setCurrencyStmt, err = db.PrepareContext(ctx, setCurrency); err != nil {
+		return nil, fmt.Errorf("error preparing query SetCurrency: %w", err)
+	}
+	if q.setInterestPostingTransferStmt, err = db.PrepareContext(ctx, setInterestPostingTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query SetInterestPostingTransfer: %w", err)
+	}
+	if q.setTransferFeeStmt, err = db.PrepareContext(ctx, setTransferFee); err != nil {
+		return nil, fmt.Errorf("error preparing query SetTransferFee: %w", err)
+	}
+	if q.setTransferLimitStmt, err = db.PrepareContext(ctx, setTransferLimit); err != nil {
+		return nil, fmt.Errorf("error preparing query SetTransferLimit: %w", err)
+	}
+	if q.tryAdvisoryXactLockStmt, err = db.PrepareContext(ctx, tryAdvisoryXactLock); err != nil {
+		return nil, fmt.Errorf("error preparing query TryAdvisoryXactLock: %w", err)
+	}
 	if q.updateAccountStmt, err = db.PrepareContext(ctx, updateAccount); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateAccount: %w", err)
 	}
+	if q.updateAccountOverdraftLimitStmt, err = db.PrepareContext(ctx, updateAccountOverdraftLimit); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateAccountOverdraftLimit: %w", err)
+	}
+	if q.updateAccountStatusStmt, err = db.PrepareContext(ctx, updateAccountStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateAccountStatus: %w", err)
+	}
+	if q.updateHoldStatusStmt, err = db.PrepareContext(ctx, updateHoldStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateHoldStatus: %w", err)
+	}
+	if q.updateStandingOrderStmt, err = db.PrepareContext(ctx, updateStandingOrder); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateStandingOrder: %w", err)
+	}
+	if q.updateStandingOrderScheduleStmt, err = db.PrepareContext(ctx, updateStandingOrderSchedule); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateStandingOrderSchedule: %w", err)
+	}
+	if q.updateUserRoleStmt, err = db.PrepareContext(ctx, updateUserRole); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateUserRole: %w", err)
+	}
+	if q.updateWebhookDeliveryAttemptStmt, err = db.PrepareContext(ctx, updateWebhookDeliveryAttempt); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateWebhookDeliveryAttempt: %w", err)
+	}
 	return &q, nil
 }
 
@@ -77,76 +347,526 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing addAccountBalanceStmt: %w", cerr)
 		}
 	}
+	if q.addAccountHeldAmountStmt != nil {
+		if cerr := q.addAccountHeldAmountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addAccountHeldAmountStmt: %w", cerr)
+		}
+	}
+	if q.cancelScheduledTransferStmt != nil {
+		if cerr := q.cancelScheduledTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing cancelScheduledTransferStmt: %w", cerr)
+		}
+	}
+	if q.cancelStandingOrderStmt != nil {
+		if cerr := q.cancelStandingOrderStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing cancelStandingOrderStmt: %w", cerr)
+		}
+	}
+	if q.captureHoldStmt != nil {
+		if cerr := q.captureHoldStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing captureHoldStmt: %w", cerr)
+		}
+	}
+	if q.claimDueScheduledTransfersStmt != nil {
+		if cerr := q.claimDueScheduledTransfersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimDueScheduledTransfersStmt: %w", cerr)
+		}
+	}
+	if q.claimDueWebhookDeliveriesStmt != nil {
+		if cerr := q.claimDueWebhookDeliveriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimDueWebhookDeliveriesStmt: %w", cerr)
+		}
+	}
+	if q.completeScheduledTransferStmt != nil {
+		if cerr := q.completeScheduledTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing completeScheduledTransferStmt: %w", cerr)
+		}
+	}
+	if q.countPayeeTransfersStmt != nil {
+		if cerr := q.countPayeeTransfersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countPayeeTransfersStmt: %w", cerr)
+		}
+	}
 	if q.createAccountStmt != nil {
 		if cerr := q.createAccountStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createAccountStmt: %w", cerr)
 		}
 	}
+	if q.createAuditLogStmt != nil {
+		if cerr := q.createAuditLogStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createAuditLogStmt: %w", cerr)
+		}
+	}
+	if q.createBalanceSnapshotsStmt != nil {
+		if cerr := q.createBalanceSnapshotsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createBalanceSnapshotsStmt: %w", cerr)
+		}
+	}
+	if q.createBankAccountStmt != nil {
+		if cerr := q.createBankAccountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createBankAccountStmt: %w", cerr)
+		}
+	}
 	if q.createEntryStmt != nil {
 		if cerr := q.createEntryStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createEntryStmt: %w", cerr)
 		}
 	}
+	if q.createFraudFlagStmt != nil {
+		if cerr := q.createFraudFlagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFraudFlagStmt: %w", cerr)
+		}
+	}
+	if q.createFxRateStmt != nil {
+		if cerr := q.createFxRateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFxRateStmt: %w", cerr)
+		}
+	}
+	if q.createHoldStmt != nil {
+		if cerr := q.createHoldStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createHoldStmt: %w", cerr)
+		}
+	}
+	if q.createIdempotencyKeyStmt != nil {
+		if cerr := q.createIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createIdempotencyKeyStmt: %w", cerr)
+		}
+	}
+	if q.createInterestAccrualStmt != nil {
+		if cerr := q.createInterestAccrualStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createInterestAccrualStmt: %w", cerr)
+		}
+	}
+	if q.createInterestPostingStmt != nil {
+		if cerr := q.createInterestPostingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createInterestPostingStmt: %w", cerr)
+		}
+	}
+	if q.createOutboxEventStmt != nil {
+		if cerr := q.createOutboxEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createOutboxEventStmt: %w", cerr)
+		}
+	}
+	if q.createScheduledTransferStmt != nil {
+		if cerr := q.createScheduledTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createScheduledTransferStmt: %w", cerr)
+		}
+	}
+	if q.createStandingOrderStmt != nil {
+		if cerr := q.createStandingOrderStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createStandingOrderStmt: %w", cerr)
+		}
+	}
+	if q.createStandingOrderRunStmt != nil {
+		if cerr := q.createStandingOrderRunStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createStandingOrderRunStmt: %w", cerr)
+		}
+	}
 	if q.createTransferStmt != nil {
 		if cerr := q.createTransferStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createTransferStmt: %w", cerr)
 		}
 	}
+	if q.createTransferFeeChargeStmt != nil {
+		if cerr := q.createTransferFeeChargeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTransferFeeChargeStmt: %w", cerr)
+		}
+	}
+	if q.createTransferReversalStmt != nil {
+		if cerr := q.createTransferReversalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTransferReversalStmt: %w", cerr)
+		}
+	}
 	if q.createUserStmt != nil {
 		if cerr := q.createUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createUserStmt: %w", cerr)
 		}
 	}
+	if q.createWebhookAttemptStmt != nil {
+		if cerr := q.createWebhookAttemptStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWebhookAttemptStmt: %w", cerr)
+		}
+	}
+	if q.createWebhookDeliveriesStmt != nil {
+		if cerr := q.createWebhookDeliveriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWebhookDeliveriesStmt: %w", cerr)
+		}
+	}
+	if q.createWebhookSubscriptionStmt != nil {
+		if cerr := q.createWebhookSubscriptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWebhookSubscriptionStmt: %w", cerr)
+		}
+	}
 	if q.deleteAccountStmt != nil {
 		if cerr := q.deleteAccountStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteAccountStmt: %w", cerr)
 		}
 	}
+	if q.deleteTransferFeeStmt != nil {
+		if cerr := q.deleteTransferFeeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteTransferFeeStmt: %w", cerr)
+		}
+	}
+	if q.deleteWebhookSubscriptionStmt != nil {
+		if cerr := q.deleteWebhookSubscriptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteWebhookSubscriptionStmt: %w", cerr)
+		}
+	}
+	if q.failScheduledTransferStmt != nil {
+		if cerr := q.failScheduledTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing failScheduledTransferStmt: %w", cerr)
+		}
+	}
 	if q.getAccountStmt != nil {
 		if cerr := q.getAccountStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getAccountStmt: %w", cerr)
 		}
 	}
+	if q.getAccountByProductStmt != nil {
+		if cerr := q.getAccountByProductStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAccountByProductStmt: %w", cerr)
+		}
+	}
 	if q.getAccountForUpdateStmt != nil {
 		if cerr := q.getAccountForUpdateStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getAccountForUpdateStmt: %w", cerr)
 		}
 	}
+	if q.getAccountProductStmt != nil {
+		if cerr := q.getAccountProductStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAccountProductStmt: %w", cerr)
+		}
+	}
+	if q.getBalanceBeforeStmt != nil {
+		if cerr := q.getBalanceBeforeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getBalanceBeforeStmt: %w", cerr)
+		}
+	}
 	if q.getEntryStmt != nil {
 		if cerr := q.getEntryStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getEntryStmt: %w", cerr)
 		}
 	}
+	if q.getFxRateStmt != nil {
+		if cerr := q.getFxRateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFxRateStmt: %w", cerr)
+		}
+	}
+	if q.getHoldStmt != nil {
+		if cerr := q.getHoldStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getHoldStmt: %w", cerr)
+		}
+	}
+	if q.getHoldForUpdateStmt != nil {
+		if cerr := q.getHoldForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getHoldForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getIdempotencyKeyStmt != nil {
+		if cerr := q.getIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getIdempotencyKeyStmt: %w", cerr)
+		}
+	}
+	if q.getLatestFxRateStmt != nil {
+		if cerr := q.getLatestFxRateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLatestFxRateStmt: %w", cerr)
+		}
+	}
+	if q.getOutgoingTransferStatsStmt != nil {
+		if cerr := q.getOutgoingTransferStatsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOutgoingTransferStatsStmt: %w", cerr)
+		}
+	}
+	if q.getOutgoingTransferTotalStmt != nil {
+		if cerr := q.getOutgoingTransferTotalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOutgoingTransferTotalStmt: %w", cerr)
+		}
+	}
+	if q.getReversedAmountsStmt != nil {
+		if cerr := q.getReversedAmountsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReversedAmountsStmt: %w", cerr)
+		}
+	}
+	if q.getScheduledTransferStmt != nil {
+		if cerr := q.getScheduledTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getScheduledTransferStmt: %w", cerr)
+		}
+	}
+	if q.getStandingOrderStmt != nil {
+		if cerr := q.getStandingOrderStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getStandingOrderStmt: %w", cerr)
+		}
+	}
 	if q.getTransferStmt != nil {
 		if cerr := q.getTransferStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getTransferStmt: %w", cerr)
 		}
 	}
+	if q.getTransferFeeStmt != nil {
+		if cerr := q.getTransferFeeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTransferFeeStmt: %w", cerr)
+		}
+	}
+	if q.getTransferFeeChargeStmt != nil {
+		if cerr := q.getTransferFeeChargeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTransferFeeChargeStmt: %w", cerr)
+		}
+	}
+	if q.getTransferForUpdateStmt != nil {
+		if cerr := q.getTransferForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTransferForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getTransferLimitStmt != nil {
+		if cerr := q.getTransferLimitStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTransferLimitStmt: %w", cerr)
+		}
+	}
+	if q.getTransferReversalStmt != nil {
+		if cerr := q.getTransferReversalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTransferReversalStmt: %w", cerr)
+		}
+	}
+	if q.getTransferWithOwnersStmt != nil {
+		if cerr := q.getTransferWithOwnersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTransferWithOwnersStmt: %w", cerr)
+		}
+	}
 	if q.getUserStmt != nil {
 		if cerr := q.getUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getUserStmt: %w", cerr)
 		}
 	}
+	if q.getUserForUpdateStmt != nil {
+		if cerr := q.getUserForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getWebhookDeliveryStmt != nil {
+		if cerr := q.getWebhookDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWebhookDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.getWebhookSubscriptionStmt != nil {
+		if cerr := q.getWebhookSubscriptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWebhookSubscriptionStmt: %w", cerr)
+		}
+	}
+	if q.listAccountEntriesStmt != nil {
+		if cerr := q.listAccountEntriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAccountEntriesStmt: %w", cerr)
+		}
+	}
 	if q.listAccountsStmt != nil {
 		if cerr := q.listAccountsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listAccountsStmt: %w", cerr)
 		}
 	}
+	if q.listAccountsToAccrueStmt != nil {
+		if cerr := q.listAccountsToAccrueStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAccountsToAccrueStmt: %w", cerr)
+		}
+	}
+	if q.listAuditLogsStmt != nil {
+		if cerr := q.listAuditLogsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAuditLogsStmt: %w", cerr)
+		}
+	}
+	if q.listBalanceMismatchesStmt != nil {
+		if cerr := q.listBalanceMismatchesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listBalanceMismatchesStmt: %w", cerr)
+		}
+	}
+	if q.listCurrenciesStmt != nil {
+		if cerr := q.listCurrenciesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCurrenciesStmt: %w", cerr)
+		}
+	}
+	if q.listCurrencyImbalancesStmt != nil {
+		if cerr := q.listCurrencyImbalancesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCurrencyImbalancesStmt: %w", cerr)
+		}
+	}
+	if q.listDailyEntryTotalsStmt != nil {
+		if cerr := q.listDailyEntryTotalsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDailyEntryTotalsStmt: %w", cerr)
+		}
+	}
+	if q.listDueStandingOrdersStmt != nil {
+		if cerr := q.listDueStandingOrdersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDueStandingOrdersStmt: %w", cerr)
+		}
+	}
 	if q.listEntriesStmt != nil {
 		if cerr := q.listEntriesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listEntriesStmt: %w", cerr)
 		}
 	}
+	if q.listEntriesAfterStmt != nil {
+		if cerr := q.listEntriesAfterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listEntriesAfterStmt: %w", cerr)
+		}
+	}
+	if q.listExpiredHoldsStmt != nil {
+		if cerr := q.listExpiredHoldsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listExpiredHoldsStmt: %w", cerr)
+		}
+	}
+	if q.listFraudFlagsStmt != nil {
+		if cerr := q.listFraudFlagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFraudFlagsStmt: %w", cerr)
+		}
+	}
+	if q.listInterestAccrualsStmt != nil {
+		if cerr := q.listInterestAccrualsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listInterestAccrualsStmt: %w", cerr)
+		}
+	}
+	if q.listPendingOutboxEventsStmt != nil {
+		if cerr := q.listPendingOutboxEventsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPendingOutboxEventsStmt: %w", cerr)
+		}
+	}
+	if q.listScheduledTransfersStmt != nil {
+		if cerr := q.listScheduledTransfersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listScheduledTransfersStmt: %w", cerr)
+		}
+	}
+	if q.listStandingOrderRunsStmt != nil {
+		if cerr := q.listStandingOrderRunsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listStandingOrderRunsStmt: %w", cerr)
+		}
+	}
+	if q.listStandingOrdersStmt != nil {
+		if cerr := q.listStandingOrdersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listStandingOrdersStmt: %w", cerr)
+		}
+	}
+	if q.listStatementEntriesStmt != nil {
+		if cerr := q.listStatementEntriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listStatementEntriesStmt: %w", cerr)
+		}
+	}
+	if q.listTransferEntryMismatchesStmt != nil {
+		if cerr := q.listTransferEntryMismatchesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTransferEntryMismatchesStmt: %w", cerr)
+		}
+	}
+	if q.listTransferFeesStmt != nil {
+		if cerr := q.listTransferFeesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTransferFeesStmt: %w", cerr)
+		}
+	}
 	if q.listTransfersStmt != nil {
 		if cerr := q.listTransfersStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listTransfersStmt: %w", cerr)
 		}
 	}
+	if q.listUnpostedInterestStmt != nil {
+		if cerr := q.listUnpostedInterestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUnpostedInterestStmt: %w", cerr)
+		}
+	}
+	if q.listWebhookAttemptsStmt != nil {
+		if cerr := q.listWebhookAttemptsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listWebhookAttemptsStmt: %w", cerr)
+		}
+	}
+	if q.listWebhookDeliveriesStmt != nil {
+		if cerr := q.listWebhookDeliveriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listWebhookDeliveriesStmt: %w", cerr)
+		}
+	}
+	if q.listWebhookSubscriptionsStmt != nil {
+		if cerr := q.listWebhookSubscriptionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listWebhookSubscriptionsStmt: %w", cerr)
+		}
+	}
+	if q.markOutboxEventPublishedStmt != nil {
+		if cerr := q.markOutboxEventPublishedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markOutboxEventPublishedStmt: %w", cerr)
+		}
+	}
+	if q.notifyStmt != nil {
+		if cerr := q.notifyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing notifyStmt: %w", cerr)
+		}
+	}
+	if q.replayWebhookDeliveryStmt != nil {
+		if cerr := q.replayWebhookDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing replayWebhookDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.setAccountProductStmt != nil {
+		if cerr := q.setAccountProductStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setAccountProductStmt: %w", cerr)
+		}
+	}
+	if q.setCurrencyStmt != nil {
+		if cerr := q.setCurrencyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setCurrencyStmt: %w", cerr)
+		}
+	}
+	if q.setInterestPostingTransferStmt != nil {
+		if cerr := q.setInterestPostingTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setInterestPostingTransferStmt: %w", cerr)
+		}
+	}
+	if q.setTransferFeeStmt != nil {
+		if cerr := q.setTransferFeeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setTransferFeeStmt: %w", cerr)
+		}
+	}
+	if q.setTransferLimitStmt != nil {
+		if cerr := q.setTransferLimitStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setTransferLimitStmt: %w", cerr)
+		}
+	}
+	if q.tryAdvisoryXactLockStmt != nil {
+		if cerr := q.tryAdvisoryXactLockStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing tryAdvisoryXactLockStmt: %w", cerr)
+		}
+	}
 	if q.updateAccountStmt != nil {
 		if cerr := q.updateAccountStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateAccountStmt: %w", cerr)
 		}
 	}
+	if q.updateAccountOverdraftLimitStmt != nil {
+		if cerr := q.updateAccountOverdraftLimitStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateAccountOverdraftLimitStmt: %w", cerr)
+		}
+	}
+	if q.updateAccountStatusStmt != nil {
+		if cerr := q.updateAccountStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateAccountStatusStmt: %w", cerr)
+		}
+	}
+	if q.updateHoldStatusStmt != nil {
+		if cerr := q.updateHoldStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateHoldStatusStmt: %w", cerr)
+		}
+	}
+	if q.updateStandingOrderStmt != nil {
+		if cerr := q.updateStandingOrderStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateStandingOrderStmt: %w", cerr)
+		}
+	}
+	if q.updateStandingOrderScheduleStmt != nil {
+		if cerr := q.updateStandingOrderScheduleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateStandingOrderScheduleStmt: %w", cerr)
+		}
+	}
+	if q.updateUserRoleStmt != nil {
+		if cerr := q.updateUserRoleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateUserRoleStmt: %w", cerr)
+		}
+	}
+	if q.updateWebhookDeliveryAttemptStmt != nil {
+		if cerr := q.updateWebhookDeliveryAttemptStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateWebhookDeliveryAttemptStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -184,43 +904,223 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                      DBTX
-	tx                      *sql.Tx
-	addAccountBalanceStmt   *sql.Stmt
-	createAccountStmt       *sql.Stmt
-	createEntryStmt         *sql.Stmt
-	createTransferStmt      *sql.Stmt
-	createUserStmt          *sql.Stmt
-	deleteAccountStmt       *sql.Stmt
-	getAccountStmt          *sql.Stmt
-	getAccountForUpdateStmt *sql.Stmt
-	getEntryStmt            *sql.Stmt
-	getTransferStmt         *sql.Stmt
-	getUserStmt             *sql.Stmt
-	listAccountsStmt        *sql.Stmt
-	listEntriesStmt         *sql.Stmt
-	listTransfersStmt       *sql.Stmt
-	updateAccountStmt       *sql.Stmt
+	db                               DBTX
+	tx                               *sql.Tx
+	addAccountBalanceStmt            *sql.Stmt
+	addAccountHeldAmountStmt         *sql.Stmt
+	cancelScheduledTransferStmt      *sql.Stmt
+	cancelStandingOrderStmt          *sql.Stmt
+	captureHoldStmt                  *sql.Stmt
+	claimDueScheduledTransfersStmt   *sql.Stmt
+	claimDueWebhookDeliveriesStmt    *sql.Stmt
+	completeScheduledTransferStmt    *sql.Stmt
+	countPayeeTransfersStmt          *sql.Stmt
+	createAccountStmt                *sql.Stmt
+	createAuditLogStmt               *sql.Stmt
+	createBalanceSnapshotsStmt       *sql.Stmt
+	createBankAccountStmt            *sql.Stmt
+	createEntryStmt                  *sql.Stmt
+	createFraudFlagStmt              *sql.Stmt
+	createFxRateStmt                 *sql.Stmt
+	createHoldStmt                   *sql.Stmt
+	createIdempotencyKeyStmt         *sql.Stmt
+	createInterestAccrualStmt        *sql.Stmt
+	createInterestPostingStmt        *sql.Stmt
+	createOutboxEventStmt            *sql.Stmt
+	createScheduledTransferStmt      *sql.Stmt
+	createStandingOrderStmt          *sql.Stmt
+	createStandingOrderRunStmt       *sql.Stmt
+	createTransferStmt               *sql.Stmt
+	createTransferFeeChargeStmt      *sql.Stmt
+	createTransferReversalStmt       *sql.Stmt
+	createUserStmt                   *sql.Stmt
+	createWebhookAttemptStmt         *sql.Stmt
+	createWebhookDeliveriesStmt      *sql.Stmt
+	createWebhookSubscriptionStmt    *sql.Stmt
+	deleteAccountStmt                *sql.Stmt
+	deleteTransferFeeStmt            *sql.Stmt
+	deleteWebhookSubscriptionStmt    *sql.Stmt
+	failScheduledTransferStmt        *sql.Stmt
+	getAccountStmt                   *sql.Stmt
+	getAccountByProductStmt          *sql.Stmt
+	getAccountForUpdateStmt          *sql.Stmt
+	getAccountProductStmt            *sql.Stmt
+	getBalanceBeforeStmt             *sql.Stmt
+	getEntryStmt                     *sql.Stmt
+	getFxRateStmt                    *sql.Stmt
+	getHoldStmt                      *sql.Stmt
+	getHoldForUpdateStmt             *sql.Stmt
+	getIdempotencyKeyStmt            *sql.Stmt
+	getLatestFxRateStmt              *sql.Stmt
+	getOutgoingTransferStatsStmt     *sql.Stmt
+	getOutgoingTransferTotalStmt     *sql.Stmt
+	getReversedAmountsStmt           *sql.Stmt
+	getScheduledTransferStmt         *sql.Stmt
+	getStandingOrderStmt             *sql.Stmt
+	getTransferStmt                  *sql.Stmt
+	getTransferFeeStmt               *sql.Stmt
+	getTransferFeeChargeStmt         *sql.Stmt
+	getTransferForUpdateStmt         *sql.Stmt
+	getTransferLimitStmt             *sql.Stmt
+	getTransferReversalStmt          *sql.Stmt
+	getTransferWithOwnersStmt        *sql.Stmt
+	getUserStmt                      *sql.Stmt
+	getUserForUpdateStmt             *sql.Stmt
+	getWebhookDeliveryStmt           *sql.Stmt
+	getWebhookSubscriptionStmt       *sql.Stmt
+	listAccountEntriesStmt           *sql.Stmt
+	listAccountsStmt                 *sql.Stmt
+	listAccountsToAccrueStmt         *sql.Stmt
+	listAuditLogsStmt                *sql.Stmt
+	listBalanceMismatchesStmt        *sql.Stmt
+	listCurrenciesStmt               *sql.Stmt
+	listCurrencyImbalancesStmt       *sql.Stmt
+	listDailyEntryTotalsStmt         *sql.Stmt
+	listDueStandingOrdersStmt        *sql.Stmt
+	listEntriesStmt                  *sql.Stmt
+	listEntriesAfterStmt             *sql.Stmt
+	listExpiredHoldsStmt             *sql.Stmt
+	listFraudFlagsStmt               *sql.Stmt
+	listInterestAccrualsStmt         *sql.Stmt
+	listPendingOutboxEventsStmt      *sql.Stmt
+	listScheduledTransfersStmt       *sql.Stmt
+	listStandingOrderRunsStmt        *sql.Stmt
+	listStandingOrdersStmt           *sql.Stmt
+	listStatementEntriesStmt         *sql.Stmt
+	listTransferEntryMismatchesStmt  *sql.Stmt
+	listTransferFeesStmt             *sql.Stmt
+	listTransfersStmt                *sql.Stmt
+	listUnpostedInterestStmt         *sql.Stmt
+	listWebhookAttemptsStmt          *sql.Stmt
+	listWebhookDeliveriesStmt        *sql.Stmt
+	listWebhookSubscriptionsStmt     *sql.Stmt
+	markOutboxEventPublishedStmt     *sql.Stmt
+	notifyStmt                       *sql.Stmt
+	replayWebhookDeliveryStmt        *sql.Stmt
+	setAccountProductStmt            *sql.Stmt
+	setCurrencyStmt                  *sql.Stmt
+	setInterestPostingTransferStmt   *sql.Stmt
+	setTransferFeeStmt               *sql.Stmt
+	setTransferLimitStmt             *sql.Stmt
+	tryAdvisoryXactLockStmt          *sql.Stmt
+	updateAccountStmt                *sql.Stmt
+	updateAccountOverdraftLimitStmt  *sql.Stmt
+	updateAccountStatusStmt          *sql.Stmt
+	updateHoldStatusStmt             *sql.Stmt
+	updateStandingOrderStmt          *sql.Stmt
+	updateStandingOrderScheduleStmt  *sql.Stmt
+	updateUserRoleStmt               *sql.Stmt
+	updateWebhookDeliveryAttemptStmt *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                      tx,
-		tx:                      tx,
-		addAccountBalanceStmt:   q.addAccountBalanceStmt,
-		createAccountStmt:       q.createAccountStmt,
-		createEntryStmt:         q.createEntryStmt,
-		createTransferStmt:      q.createTransferStmt,
-		createUserStmt:          q.createUserStmt,
-		deleteAccountStmt:       q.deleteAccountStmt,
-		getAccountStmt:          q.getAccountStmt,
-		getAccountForUpdateStmt: q.getAccountForUpdateStmt,
-		getEntryStmt:            q.getEntryStmt,
-		getTransferStmt:         q.getTransferStmt,
-		getUserStmt:             q.getUserStmt,
-		listAccountsStmt:        q.listAccountsStmt,
-		listEntriesStmt:         q.listEntriesStmt,
-		listTransfersStmt:       q.listTransfersStmt,
-		updateAccountStmt:       q.updateAccountStmt,
+		db:                               tx,
+		tx:                               tx,
+		addAccountBalanceStmt:            q.addAccountBalanceStmt,
+		addAccountHeldAmountStmt:         q.addAccountHeldAmountStmt,
+		cancelScheduledTransferStmt:      q.cancelScheduledTransferStmt,
+		cancelStandingOrderStmt:          q.cancelStandingOrderStmt,
+		captureHoldStmt:                  q.captureHoldStmt,
+		claimDueScheduledTransfersStmt:   q.claimDueScheduledTransfersStmt,
+		claimDueWebhookDeliveriesStmt:    q.claimDueWebhookDeliveriesStmt,
+		completeScheduledTransferStmt:    q.completeScheduledTransferStmt,
+		countPayeeTransfersStmt:          q.countPayeeTransfersStmt,
+		createAccountStmt:                q.createAccountStmt,
+		createAuditLogStmt:               q.createAuditLogStmt,
+		createBalanceSnapshotsStmt:       q.createBalanceSnapshotsStmt,
+		createBankAccountStmt:            q.createBankAccountStmt,
+		createEntryStmt:                  q.createEntryStmt,
+		createFraudFlagStmt:              q.createFraudFlagStmt,
+		createFxRateStmt:                 q.createFxRateStmt,
+		createHoldStmt:                   q.createHoldStmt,
+		createIdempotencyKeyStmt:         q.createIdempotencyKeyStmt,
+		createInterestAccrualStmt:        q.createInterestAccrualStmt,
+		createInterestPostingStmt:        q.createInterestPostingStmt,
+		createOutboxEventStmt:            q.createOutboxEventStmt,
+		createScheduledTransferStmt:      q.createScheduledTransferStmt,
+		createStandingOrderStmt:          q.createStandingOrderStmt,
+		createStandingOrderRunStmt:       q.createStandingOrderRunStmt,
+		createTransferStmt:               q.createTransferStmt,
+		createTransferFeeChargeStmt:      q.createTransferFeeChargeStmt,
+		createTransferReversalStmt:       q.createTransferReversalStmt,
+		createUserStmt:                   q.createUserStmt,
+		createWebhookAttemptStmt:         q.createWebhookAttemptStmt,
+		createWebhookDeliveriesStmt:      q.createWebhookDeliveriesStmt,
+		createWebhookSubscriptionStmt:    q.createWebhookSubscriptionStmt,
+		deleteAccountStmt:                q.deleteAccountStmt,
+		deleteTransferFeeStmt:            q.deleteTransferFeeStmt,
+		deleteWebhookSubscriptionStmt:    q.deleteWebhookSubscriptionStmt,
+		failScheduledTransferStmt:        q.failScheduledTransferStmt,
+		getAccountStmt:                   q.getAccountStmt,
+		getAccountByProductStmt:          q.getAccountByProductStmt,
+		getAccountForUpdateStmt:          q.getAccountForUpdateStmt,
+		getAccountProductStmt:            q.getAccountProductStmt,
+		getBalanceBeforeStmt:             q.getBalanceBeforeStmt,
+		getEntryStmt:                     q.getEntryStmt,
+		getFxRateStmt:                    q.getFxRateStmt,
+		getHoldStmt:                      q.getHoldStmt,
+		getHoldForUpdateStmt:             q.getHoldForUpdateStmt,
+		getIdempotencyKeyStmt:            q.getIdempotencyKeyStmt,
+		getLatestFxRateStmt:              q.getLatestFxRateStmt,
+		getOutgoingTransferStatsStmt:     q.getOutgoingTransferStatsStmt,
+		getOutgoingTransferTotalStmt:     q.getOutgoingTransferTotalStmt,
+		getReversedAmountsStmt:           q.getReversedAmountsStmt,
+		getScheduledTransferStmt:         q.getScheduledTransferStmt,
+		getStandingOrderStmt:             q.getStandingOrderStmt,
+		getTransferStmt:                  q.getTransferStmt,
+		getTransferFeeStmt:               q.getTransferFeeStmt,
+		getTransferFeeChargeStmt:         q.getTransferFeeChargeStmt,
+		getTransferForUpdateStmt:         q.getTransferForUpdateStmt,
+		getTransferLimitStmt:             q.getTransferLimitStmt,
+		getTransferReversalStmt:          q.getTransferReversalStmt,
+		getTransferWithOwnersStmt:        q.getTransferWithOwnersStmt,
+		getUserStmt:                      q.getUserStmt,
+		getUserForUpdateStmt:             q.getUserForUpdateStmt,
+		getWebhookDeliveryStmt:           q.getWebhookDeliveryStmt,
+		getWebhookSubscriptionStmt:       q.getWebhookSubscriptionStmt,
+		listAccountEntriesStmt:           q.listAccountEntriesStmt,
+		listAccountsStmt:                 q.listAccountsStmt,
+		listAccountsToAccrueStmt:         q.listAccountsToAccrueStmt,
+		listAuditLogsStmt:                q.listAuditLogsStmt,
+		listBalanceMismatchesStmt:        q.listBalanceMismatchesStmt,
+		listCurrenciesStmt:               q.listCurrenciesStmt,
+		listCurrencyImbalancesStmt:       q.listCurrencyImbalancesStmt,
+		listDailyEntryTotalsStmt:         q.listDailyEntryTotalsStmt,
+		listDueStandingOrdersStmt:        q.listDueStandingOrdersStmt,
+		listEntriesStmt:                  q.listEntriesStmt,
+		listEntriesAfterStmt:             q.listEntriesAfterStmt,
+		listExpiredHoldsStmt:             q.listExpiredHoldsStmt,
+		listFraudFlagsStmt:               q.listFraudFlagsStmt,
+		listInterestAccrualsStmt:         q.listInterestAccrualsStmt,
+		listPendingOutboxEventsStmt:      q.listPendingOutboxEventsStmt,
+		listScheduledTransfersStmt:       q.listScheduledTransfersStmt,
+		listStandingOrderRunsStmt:        q.listStandingOrderRunsStmt,
+		listStandingOrdersStmt:           q.listStandingOrdersStmt,
+		listStatementEntriesStmt:         q.listStatementEntriesStmt,
+		listTransferEntryMismatchesStmt:  q.listTransferEntryMismatchesStmt,
+		listTransferFeesStmt:             q.listTransferFeesStmt,
+		listTransfersStmt:                q.listTransfersStmt,
+		listUnpostedInterestStmt:         q.listUnpostedInterestStmt,
+		listWebhookAttemptsStmt:          q.listWebhookAttemptsStmt,
+		listWebhookDeliveriesStmt:        q.listWebhookDeliveriesStmt,
+		listWebhookSubscriptionsStmt:     q.listWebhookSubscriptionsStmt,
+		markOutboxEventPublishedStmt:     q.markOutboxEventPublishedStmt,
+		notifyStmt:                       q.notifyStmt,
+		replayWebhookDeliveryStmt:        q.replayWebhookDeliveryStmt,
+		setAccountProductStmt:            q.setAccountProductStmt,
+		setCurrencyStmt:                  q.setCurrencyStmt,
+		setInterestPostingTransferStmt:   q.setInterestPostingTransferStmt,
+		setTransferFeeStmt:               q.setTransferFeeStmt,
+		setTransferLimitStmt:             q.setTransferLimitStmt,
+		tryAdvisoryXactLockStmt:          q.tryAdvisoryXactLockStmt,
+		updateAccountStmt:                q.updateAccountStmt,
+		updateAccountOverdraftLimitStmt:  q.updateAccountOverdraftLimitStmt,
+		updateAccountStatusStmt:          q.updateAccountStatusStmt,
+		updateHoldStatusStmt:             q.updateHoldStatusStmt,
+		updateStandingOrderStmt:          q.updateStandingOrderStmt,
+		updateStandingOrderScheduleStmt:  q.updateStandingOrderScheduleStmt,
+		updateUserRoleStmt:               q.updateUserRoleStmt,
+		updateWebhookDeliveryAttemptStmt: q.updateWebhookDeliveryAttemptStmt,
 	}
 }
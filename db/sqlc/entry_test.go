@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"math"
 	"simplebank/util"
 	"testing"
 	"time"
@@ -67,3 +68,51 @@ func TestListEntries(t *testing.T) {
 		require.Equal(t, account.ID, entry.AccountID)
 	}
 }
+
+func TestListAccountEntries(t *testing.T) {
+	account := createRandomAccount(t)
+
+	t0 := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+	t2 := t0.Add(2 * time.Hour)
+	t3 := t0.Add(3 * time.Hour)
+	createEntryAt(t, account, 10, t0)
+	// two entries created at the same time are ordered by id
+	createEntryAt(t, account, -15, t1)
+	createEntryAt(t, account, -20, t1)
+	createEntryAt(t, account, 30, t2)
+	createEntryAt(t, account, -5, t3)
+
+	list := func(arg ListAccountEntriesParams) []int64 {
+		arg.AccountID = account.ID
+		if arg.ToTime.IsZero() {
+			arg.ToTime = t3.Add(time.Hour)
+		}
+		if arg.MaxAmount == 0 {
+			arg.MaxAmount = math.MaxInt64
+		}
+		arg.CursorCreatedAt = arg.ToTime
+		arg.RowLimit = 2
+
+		var amounts []int64
+		for {
+			entries, err := testQueries.ListAccountEntries(context.Background(), arg)
+			require.NoError(t, err)
+			for _, entry := range entries {
+				amounts = append(amounts, entry.Amount)
+			}
+			if len(entries) < int(arg.RowLimit) {
+				return amounts
+			}
+			last := entries[len(entries)-1]
+			arg.CursorCreatedAt = last.CreatedAt
+			arg.CursorID = last.ID
+		}
+	}
+
+	require.Equal(t, []int64{-5, 30, -20, -15, 10}, list(ListAccountEntriesParams{}))
+	require.Equal(t, []int64{-5, -20, -15}, list(ListAccountEntriesParams{Direction: "debit"}))
+	require.Equal(t, []int64{30, 10}, list(ListAccountEntriesParams{Direction: "credit"}))
+	require.Equal(t, []int64{-20, -15}, list(ListAccountEntriesParams{Direction: "debit", MinAmount: 10, MaxAmount: 20}))
+	require.Equal(t, []int64{30, -20, -15}, list(ListAccountEntriesParams{FromTime: t1, ToTime: t3}))
+}
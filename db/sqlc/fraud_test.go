@@ -0,0 +1,230 @@
+package db
+
+import (
+	"context"
+	"simplebank/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountPayeeTransfers(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	account3 := createRandomAccount(t)
+
+	arg := CountPayeeTransfersParams{
+		Owner:       account1.Owner,
+		ToAccountID: account2.ID,
+	}
+	n, err := testQueries.CountPayeeTransfers(context.Background(), arg)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	createRandomTransfer(t, account1, account2)
+	createRandomTransfer(t, account1, account2)
+	createRandomTransfer(t, account1, account3)
+	createRandomTransfer(t, account3, account2)
+
+	n, err = testQueries.CountPayeeTransfers(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+}
+
+func TestGetOutgoingTransferStats(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	transfer1 := createRandomTransfer(t, account1, account2)
+	transfer2 := createRandomTransfer(t, account1, account2)
+	createRandomTransfer(t, account2, account1)
+
+	stats, err := testQueries.GetOutgoingTransferStats(context.Background(), GetOutgoingTransferStatsParams{
+		FromAccountID: account1.ID,
+		CreatedAt:     time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), stats.Count)
+	require.Equal(t, transfer1.Amount+transfer2.Amount, stats.Total)
+
+	stats, err = testQueries.GetOutgoingTransferStats(context.Background(), GetOutgoingTransferStatsParams{
+		FromAccountID: account1.ID,
+		CreatedAt:     time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Zero(t, stats.Count)
+	require.Zero(t, stats.Total)
+}
+
+func TestTransferTxFraudFlag(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 100)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		Fraud: &FraudFlagParams{
+			Username: account1.Owner,
+			Decision: "review",
+			Rule:     "new_payee",
+		},
+	})
+	require.NoError(t, err)
+
+	flags, err := testQueries.ListFraudFlags(context.Background(), ListFraudFlagsParams{
+		Username: account1.Owner,
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Len(t, flags, 1)
+
+	flag := flags[0]
+	require.Equal(t, account1.ID, flag.FromAccountID)
+	require.Equal(t, account2.ID, flag.ToAccountID)
+	require.Equal(t, int64(10), flag.Amount)
+	require.Equal(t, account1.Currency, flag.Currency)
+	require.Equal(t, "review", flag.Decision)
+	require.Equal(t, "new_payee", flag.Rule)
+	require.True(t, flag.TransferID.Valid)
+	require.Equal(t, result.Transfer.ID, flag.TransferID.Int64)
+}
+
+func TestBatchAndCaptureFraudFlags(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 100)
+	account3 := createRandomAccountWithBalance(t, 100)
+	flag := &FraudFlagParams{
+		Username: account1.Owner,
+		Decision: "review",
+		Rule:     "new_payee",
+	}
+
+	// only the flagged leg gets a flag
+	batch, err := store.BatchTransferTx(context.Background(), BatchTransferTxParams{
+		FromAccountID: account1.ID,
+		Legs: []BatchTransferLeg{
+			{ToAccountID: account2.ID, Amount: 10},
+			{ToAccountID: account3.ID, Amount: 20, Fraud: flag},
+		},
+	})
+	require.NoError(t, err)
+
+	hold := createRandomHold(t, store, account1, account2, 30, time.Now().Add(time.Hour))
+	capture, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: hold.ID,
+		Fraud:  flag,
+	})
+	require.NoError(t, err)
+
+	flags, err := testQueries.ListFraudFlags(context.Background(), ListFraudFlagsParams{
+		Username: account1.Owner,
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Len(t, flags, 2)
+
+	require.Equal(t, capture.Transfer.ID, flags[0].TransferID.Int64)
+	require.Equal(t, account2.ID, flags[0].ToAccountID)
+	require.Equal(t, int64(30), flags[0].Amount)
+
+	require.Equal(t, batch.Transfers[1].ID, flags[1].TransferID.Int64)
+	require.Equal(t, account3.ID, flags[1].ToAccountID)
+	require.Equal(t, int64(20), flags[1].Amount)
+}
+
+func TestScheduledFraudFlags(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 100)
+	flag := &FraudFlagParams{
+		Username: account1.Owner,
+		Decision: "review",
+		Rule:     "new_payee",
+	}
+
+	_, err := store.CreateScheduledTransferTx(context.Background(), CreateScheduledTransferTxParams{
+		CreateScheduledTransferParams: CreateScheduledTransferParams{
+			Owner:         account1.Owner,
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        10,
+			ExecuteAt:     time.Now().Add(time.Hour),
+		},
+		Fraud: flag,
+	})
+	require.NoError(t, err)
+
+	_, err = store.CreateStandingOrderTx(context.Background(), CreateStandingOrderTxParams{
+		CreateStandingOrderParams: CreateStandingOrderParams{
+			Owner:         account1.Owner,
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        20,
+			Frequency:     util.Monthly,
+			DayOfMonth:    1,
+			NextRunAt:     time.Now().Add(time.Hour),
+		},
+		Fraud: flag,
+	})
+	require.NoError(t, err)
+
+	flags, err := testQueries.ListFraudFlags(context.Background(), ListFraudFlagsParams{
+		Username: account1.Owner,
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Len(t, flags, 2)
+
+	// no transfer is made yet
+	for _, flag := range flags {
+		require.False(t, flag.TransferID.Valid)
+		require.Equal(t, account2.ID, flag.ToAccountID)
+		require.Equal(t, account1.Currency, flag.Currency)
+		require.Equal(t, "review", flag.Decision)
+	}
+	require.Equal(t, int64(20), flags[0].Amount)
+	require.Equal(t, int64(10), flags[1].Amount)
+}
+
+func TestListFraudFlags(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	arg := CreateFraudFlagParams{
+		Username:      account1.Owner,
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        500,
+		Currency:      account1.Currency,
+		Decision:      "deny",
+		Rule:          "empties_balance",
+	}
+	denied, err := testQueries.CreateFraudFlag(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, denied.ID)
+	require.False(t, denied.TransferID.Valid)
+	require.NotZero(t, denied.CreatedAt)
+
+	arg.Decision = "review"
+	reviewed, err := testQueries.CreateFraudFlag(context.Background(), arg)
+	require.NoError(t, err)
+
+	flags, err := testQueries.ListFraudFlags(context.Background(), ListFraudFlagsParams{
+		Username: account1.Owner,
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []FraudFlag{reviewed, denied}, flags)
+
+	flags, err = testQueries.ListFraudFlags(context.Background(), ListFraudFlagsParams{
+		Username: account1.Owner,
+		Decision: "deny",
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []FraudFlag{denied}, flags)
+}
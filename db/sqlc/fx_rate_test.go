@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"simplebank/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomFxRate(t *testing.T, fromCurrency, toCurrency string) FxRate {
+	arg := CreateFxRateParams{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+		Rate:         "1.25",
+	}
+
+	rate, err := testQueries.CreateFxRate(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, rate)
+
+	require.Equal(t, arg.FromCurrency, rate.FromCurrency)
+	require.Equal(t, arg.ToCurrency, rate.ToCurrency)
+	require.Equal(t, arg.Rate, rate.Rate)
+
+	require.NotZero(t, rate.ID)
+	require.NotZero(t, rate.CreatedAt)
+
+	return rate
+}
+
+func TestCreateFxRate(t *testing.T) {
+	createRandomFxRate(t, util.USD, util.EUR)
+}
+
+func TestGetFxRate(t *testing.T) {
+	rate1 := createRandomFxRate(t, util.USD, util.EUR)
+	rate2, err := testQueries.GetFxRate(context.Background(), rate1.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, rate2)
+
+	require.Equal(t, rate1.ID, rate2.ID)
+	require.Equal(t, rate1.FromCurrency, rate2.FromCurrency)
+	require.Equal(t, rate1.ToCurrency, rate2.ToCurrency)
+	require.Equal(t, rate1.Rate, rate2.Rate)
+	require.WithinDuration(t, rate1.CreatedAt, rate2.CreatedAt, time.Second)
+}
+
+func TestGetLatestFxRate(t *testing.T) {
+	createRandomFxRate(t, util.EUR, util.USD)
+	rate1 := createRandomFxRate(t, util.EUR, util.USD)
+
+	rate2, err := testQueries.GetLatestFxRate(context.Background(), GetLatestFxRateParams{
+		FromCurrency: util.EUR,
+		ToCurrency:   util.USD,
+	})
+	require.NoError(t, err)
+	require.Equal(t, rate1.ID, rate2.ID)
+}
+
+func TestCreateFxRateSameCurrency(t *testing.T) {
+	_, err := testQueries.CreateFxRate(context.Background(), CreateFxRateParams{
+		FromCurrency: util.USD,
+		ToCurrency:   util.USD,
+		Rate:         "1",
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,171 @@
+package db
+
+import (
+	"context"
+	"simplebank/util"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomHold(t *testing.T, store Store, account1, account2 Account, amount int64, expiresAt time.Time) Hold {
+	arg := CreateHoldParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      amount,
+		ExpiresAt:   expiresAt,
+	}
+
+	hold, err := store.CreateHoldTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, hold)
+
+	require.Equal(t, arg.AccountID, hold.AccountID)
+	require.Equal(t, arg.ToAccountID, hold.ToAccountID)
+	require.Equal(t, arg.Amount, hold.Amount)
+	require.WithinDuration(t, arg.ExpiresAt, hold.ExpiresAt, time.Second)
+	require.Equal(t, HoldActive, hold.Status)
+	require.False(t, hold.TransferID.Valid)
+
+	require.NotZero(t, hold.ID)
+	require.NotZero(t, hold.CreatedAt)
+
+	return hold
+}
+
+func TestCreateHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccount(t)
+	createRandomHold(t, store, account1, account2, 60, time.Now().Add(time.Hour))
+
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), updatedAccount1.Balance)
+	require.Equal(t, int64(60), updatedAccount1.HeldAmount)
+	require.Equal(t, int64(40), updatedAccount1.AvailableBalance)
+
+	// held money can't be held again or transferred
+	_, err = store.CreateHoldTx(context.Background(), CreateHoldParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      41,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        41,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestCaptureHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccount(t)
+	hold := createRandomHold(t, store, account1, account2, 60, time.Now().Add(time.Hour))
+
+	_, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID, Amount: 61})
+	require.ErrorIs(t, err, ErrCaptureExceedsHold)
+
+	result, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID, Amount: 50})
+	require.NoError(t, err)
+	require.Equal(t, HoldCaptured, result.Hold.Status)
+	require.Equal(t, int64(50), result.Hold.CapturedAmount)
+	require.Equal(t, result.Transfer.ID, result.Hold.TransferID.Int64)
+	require.Equal(t, int64(50), result.Transfer.Amount)
+
+	// the uncaptured rest is available again
+	require.Equal(t, int64(50), result.FromAccount.Balance)
+	require.Zero(t, result.FromAccount.HeldAmount)
+	require.Equal(t, int64(50), result.FromAccount.AvailableBalance)
+	require.Equal(t, account2.Balance+50, result.ToAccount.Balance)
+
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID})
+	require.ErrorIs(t, err, ErrHoldNotActive)
+	_, err = store.VoidHoldTx(context.Background(), hold.ID)
+	require.ErrorIs(t, err, ErrHoldNotActive)
+}
+
+func TestCaptureHoldTxFee(t *testing.T) {
+	store := NewStore(testDB)
+	addTransferFee(t, SetTransferFeeParams{Currency: util.USD, Scope: util.FeeScopeOther, FlatFee: 10, Rate: "0"})
+
+	account1 := createRandomAccountWithCurrency(t, 100, util.USD)
+	account2 := createRandomAccountWithCurrency(t, 0, util.USD)
+	hold := createRandomHold(t, store, account1, account2, 60, time.Now().Add(time.Hour))
+
+	// a capture is a transfer, with its fee and its event
+	result, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(10), result.Fee)
+	require.NotNil(t, result.FeeTransfer)
+	require.Equal(t, int64(100-60-10), result.FromAccount.Balance)
+	require.Zero(t, result.FromAccount.HeldAmount)
+	require.Equal(t, int64(60), result.ToAccount.Balance)
+
+	events := findEvents(relayAll(t, store, publishAll), AggregateTransfer, strconv.FormatInt(result.Transfer.ID, 10))
+	require.Len(t, events, 1)
+	require.Equal(t, EventTransferCompleted, events[0].EventType)
+
+	// the fee is charged on top of the held amount
+	hold = createRandomHold(t, store, account1, account2, 30, time.Now().Add(time.Hour))
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestVoidHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccount(t)
+	hold := createRandomHold(t, store, account1, account2, 60, time.Now().Add(time.Hour))
+
+	voided, err := store.VoidHoldTx(context.Background(), hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldVoided, voided.Status)
+
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), updatedAccount1.Balance)
+	require.Equal(t, int64(100), updatedAccount1.AvailableBalance)
+
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID})
+	require.ErrorIs(t, err, ErrHoldNotActive)
+}
+
+func TestExpireHolds(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccount(t)
+	expired := createRandomHold(t, store, account1, account2, 30, time.Now().Add(-time.Second))
+	active := createRandomHold(t, store, account1, account2, 20, time.Now().Add(time.Hour))
+
+	// an expired hold can't be captured even before the worker releases it
+	_, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: expired.ID})
+	require.ErrorIs(t, err, ErrHoldNotActive)
+
+	_, err = store.ExpireHolds(context.Background(), 1000)
+	require.NoError(t, err)
+
+	expired, err = store.GetHold(context.Background(), expired.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldExpired, expired.Status)
+
+	active, err = store.GetHold(context.Background(), active.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldActive, active.Status)
+
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(20), updatedAccount1.HeldAmount)
+	require.Equal(t, int64(80), updatedAccount1.AvailableBalance)
+}
@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"simplebank/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomIdempotencyKey(t *testing.T, user User) IdempotencyKey {
+	arg := CreateIdempotencyKeyParams{
+		Username:     user.Username,
+		Key:          util.RandomString(16),
+		RequestHash:  util.RandomString(64),
+		ResponseBody: json.RawMessage(`{"id":1}`),
+	}
+
+	key, err := testQueries.CreateIdempotencyKey(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, key)
+
+	require.Equal(t, arg.Username, key.Username)
+	require.Equal(t, arg.Key, key.Key)
+	require.Equal(t, arg.RequestHash, key.RequestHash)
+	require.JSONEq(t, string(arg.ResponseBody), string(key.ResponseBody))
+	require.NotZero(t, key.CreatedAt)
+
+	return key
+}
+
+func TestCreateIdempotencyKey(t *testing.T) {
+	createRandomIdempotencyKey(t, createRandomUser(t))
+}
+
+func TestGetIdempotencyKey(t *testing.T) {
+	key1 := createRandomIdempotencyKey(t, createRandomUser(t))
+	key2, err := testQueries.GetIdempotencyKey(context.Background(), GetIdempotencyKeyParams{
+		Username: key1.Username,
+		Key:      key1.Key,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, key2)
+
+	require.Equal(t, key1.Username, key2.Username)
+	require.Equal(t, key1.Key, key2.Key)
+	require.Equal(t, key1.RequestHash, key2.RequestHash)
+	require.JSONEq(t, string(key1.ResponseBody), string(key2.ResponseBody))
+	require.WithinDuration(t, key1.CreatedAt, key2.CreatedAt, time.Second)
+}
+
+func TestIdempotencyKeyScopedToUser(t *testing.T) {
+	key1 := createRandomIdempotencyKey(t, createRandomUser(t))
+	user2 := createRandomUser(t)
+
+	_, err := testQueries.GetIdempotencyKey(context.Background(), GetIdempotencyKeyParams{
+		Username: user2.Username,
+		Key:      key1.Key,
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,126 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"simplebank/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomSavingsAccount(t *testing.T, annualRate string, createdAt time.Time) Account {
+	product, err := testQueries.SetAccountProduct(context.Background(), SetAccountProductParams{
+		Code:       "savings_" + util.RandomString(8),
+		Name:       "Savings account",
+		AnnualRate: annualRate,
+		DayCount:   util.DayCountACT365,
+	})
+	require.NoError(t, err)
+	require.False(t, product.Internal)
+
+	user := createRandomUser(t)
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Balance:  0,
+		Currency: util.RandomCurrency(),
+		Product:  product.Code,
+	})
+	require.NoError(t, err)
+	require.Equal(t, product.Code, account.Product)
+
+	_, err = testDB.Exec("UPDATE accounts SET created_at = $1 WHERE id = $2", createdAt, account.ID)
+	require.NoError(t, err)
+	return account
+}
+
+func TestSetAccountProductInternal(t *testing.T) {
+	_, err := testQueries.SetAccountProduct(context.Background(), SetAccountProductParams{
+		Code:       ProductInterestExpense,
+		Name:       "Interest expense",
+		AnnualRate: "0.5",
+		DayCount:   util.DayCountACT365,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	product, err := testQueries.GetAccountProduct(context.Background(), ProductInterestExpense)
+	require.NoError(t, err)
+	require.True(t, product.Internal)
+	require.Equal(t, "0", product.AnnualRate)
+}
+
+func TestInterest(t *testing.T) {
+	store := NewStore(testDB)
+
+	day1 := time.Date(2000, 1, 30, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	month := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	account := createRandomSavingsAccount(t, "0.0365", day1.AddDate(0, 0, -1))
+	createEntryAt(t, account, 100000, day1.Add(10*time.Hour))
+
+	for _, day := range []time.Time{day1, day2, day1} {
+		_, err := store.AccrueInterest(context.Background(), day)
+		require.NoError(t, err)
+	}
+
+	accruals, err := testQueries.ListInterestAccruals(context.Background(), ListInterestAccrualsParams{
+		AccountID: account.ID,
+		FromDay:   month,
+		ToDay:     month.AddDate(0, 1, 0),
+	})
+	require.NoError(t, err)
+	require.Len(t, accruals, 2)
+	for i, accrual := range accruals {
+		require.WithinDuration(t, day1.AddDate(0, 0, i), accrual.Day, time.Second)
+		require.Equal(t, int64(100000), accrual.Balance)
+		require.Equal(t, util.DayCountACT365, accrual.DayCount)
+		require.Equal(t, "10.0000000000", accrual.Amount)
+	}
+
+	postings, err := store.PostInterest(context.Background(), month, 1000)
+	require.NoError(t, err)
+	var posting InterestPosting
+	for _, p := range postings {
+		if p.AccountID == account.ID {
+			posting = p
+		}
+	}
+	require.Equal(t, account.ID, posting.AccountID)
+	require.Equal(t, int64(20), posting.Amount)
+	require.True(t, posting.TransferID.Valid)
+
+	expense, err := testQueries.GetAccountByProduct(context.Background(), GetAccountByProductParams{
+		Owner:    BankUsername,
+		Currency: account.Currency,
+		Product:  ProductInterestExpense,
+	})
+	require.NoError(t, err)
+
+	transfer, err := testQueries.GetTransfer(context.Background(), posting.TransferID.Int64)
+	require.NoError(t, err)
+	require.Equal(t, expense.ID, transfer.FromAccountID)
+	require.Equal(t, account.ID, transfer.ToAccountID)
+	require.Equal(t, int64(20), transfer.Amount)
+
+	// posting again pays nothing, and the posted month accrues nothing more
+	postings, err = store.PostInterest(context.Background(), month, 1000)
+	require.NoError(t, err)
+	for _, p := range postings {
+		require.NotEqual(t, account.ID, p.AccountID)
+	}
+	_, err = store.AccrueInterest(context.Background(), day1.AddDate(0, 0, -1))
+	require.NoError(t, err)
+
+	accruals, err = testQueries.ListInterestAccruals(context.Background(), ListInterestAccrualsParams{
+		AccountID: account.ID,
+		FromDay:   month,
+		ToDay:     month.AddDate(0, 1, 0),
+	})
+	require.NoError(t, err)
+	require.Len(t, accruals, 2)
+
+	account, err = testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(20), account.Balance)
+}
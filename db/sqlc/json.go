@@ -0,0 +1,95 @@
+package db
+
+import (
+	"encoding/json"
+	"simplebank/util"
+)
+
+// MarshalJSON adds the minor units of the currency of the account, so clients know
+// how many decimals its amounts have, and the amounts as decimal strings like "12.34"
+// next to the ones in minor units. They are left out for a currency missing from the registry
+func (account Account) MarshalJSON() ([]byte, error) {
+	// plain has the fields of Account, but not this method
+	type plain Account
+	out := struct {
+		plain
+		MinorUnits              *int32 `json:"minor_units,omitempty"`
+		BalanceDecimal          string `json:"balance_decimal,omitempty"`
+		OverdraftLimitDecimal   string `json:"overdraft_limit_decimal,omitempty"`
+		HeldAmountDecimal       string `json:"held_amount_decimal,omitempty"`
+		AvailableBalanceDecimal string `json:"available_balance_decimal,omitempty"`
+	}{plain: plain(account)}
+
+	if currency, ok := util.LookupCurrency(account.Currency); ok {
+		out.MinorUnits = &currency.MinorUnits
+		out.BalanceDecimal = util.FormatAmount(account.Balance, currency.MinorUnits)
+		out.OverdraftLimitDecimal = util.FormatAmount(account.OverdraftLimit, currency.MinorUnits)
+		out.HeldAmountDecimal = util.FormatAmount(account.HeldAmount, currency.MinorUnits)
+		out.AvailableBalanceDecimal = util.FormatAmount(account.AvailableBalance, currency.MinorUnits)
+	}
+	return json.Marshal(out)
+}
+
+// MarshalJSON adds the amount, the amount credited and the fee of the transfer as decimal strings
+// in the currencies of the source and destination accounts
+func (result TransferTxResult) MarshalJSON() ([]byte, error) {
+	// plain has the fields of TransferTxResult, but not this method
+	type plain TransferTxResult
+	return json.Marshal(struct {
+		plain
+		AmountDecimal   string `json:"amount_decimal,omitempty"`
+		ToAmountDecimal string `json:"to_amount_decimal,omitempty"`
+		FeeDecimal      string `json:"fee_decimal,omitempty"`
+	}{
+		plain:           plain(result),
+		AmountDecimal:   util.FormatMoney(result.Transfer.Amount, result.FromAccount.Currency),
+		ToAmountDecimal: util.FormatMoney(result.Transfer.ToAmount, result.ToAccount.Currency),
+		FeeDecimal:      util.FormatMoney(result.Fee, result.FromAccount.Currency),
+	})
+}
+
+// MarshalJSON keeps the reversal_of and fee refund fields, which the method promoted from TransferTxResult would drop
+func (result ReverseTransferTxResult) MarshalJSON() ([]byte, error) {
+	return marshalWithTransferTxResult(result.TransferTxResult, struct {
+		ReversalOf        int64     `json:"reversal_of"`
+		FeeRefund         int64     `json:"fee_refund"`
+		FeeRefundDecimal  string    `json:"fee_refund_decimal,omitempty"`
+		FeeRefundTransfer *Transfer `json:"fee_refund_transfer,omitempty"`
+		FeeRefundEntry    *Entry    `json:"fee_refund_entry,omitempty"`
+	}{
+		ReversalOf:        result.ReversalOf,
+		FeeRefund:         result.FeeRefund,
+		FeeRefundDecimal:  util.FormatMoney(result.FeeRefund, result.ToAccount.Currency),
+		FeeRefundTransfer: result.FeeRefundTransfer,
+		FeeRefundEntry:    result.FeeRefundEntry,
+	})
+}
+
+// MarshalJSON keeps the hold field, which the method promoted from TransferTxResult would drop
+func (result CaptureHoldTxResult) MarshalJSON() ([]byte, error) {
+	return marshalWithTransferTxResult(result.TransferTxResult, struct {
+		Hold Hold `json:"hold"`
+	}{result.Hold})
+}
+
+// marshalWithTransferTxResult marshals the fields of a result that embeds TransferTxResult
+// together with the ones of extra
+func marshalWithTransferTxResult(result TransferTxResult, extra interface{}) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	data, err = json.Marshal(extra)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
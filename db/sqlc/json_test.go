@@ -0,0 +1,114 @@
+package db
+
+import (
+	"encoding/json"
+	"simplebank/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountMarshalJSON(t *testing.T) {
+	account := Account{
+		ID:       1,
+		Owner:    "alice",
+		Balance:  1234,
+		Currency: util.USD,
+		Status:   util.AccountActive,
+
+		OverdraftLimit:   500,
+		HeldAmount:       34,
+		AvailableBalance: 1200,
+	}
+
+	data, err := json.Marshal(account)
+	require.NoError(t, err)
+
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &fields))
+	require.Equal(t, float64(2), fields["minor_units"])
+	require.Equal(t, float64(1234), fields["balance"])
+	require.Equal(t, util.USD, fields["currency"])
+	require.Equal(t, "12.34", fields["balance_decimal"])
+	require.Equal(t, "5.00", fields["overdraft_limit_decimal"])
+	require.Equal(t, "0.34", fields["held_amount_decimal"])
+	require.Equal(t, "12.00", fields["available_balance_decimal"])
+
+	var got Account
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.Equal(t, account, got)
+
+	account.Currency = "XXX"
+	data, err = json.Marshal(account)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "minor_units")
+	require.NotContains(t, string(data), "balance_decimal")
+}
+
+func TestTransferTxResultMarshalJSON(t *testing.T) {
+	result := TransferTxResult{
+		Transfer:    Transfer{ID: 1, Amount: 1050, ToAmount: 950},
+		FromAccount: Account{Currency: util.USD},
+		ToAccount:   Account{Currency: util.EUR},
+		Fee:         25,
+	}
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &fields))
+	require.Equal(t, "10.50", fields["amount_decimal"])
+	require.Equal(t, "9.50", fields["to_amount_decimal"])
+	require.Equal(t, "0.25", fields["fee_decimal"])
+
+	var got TransferTxResult
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.Equal(t, result, got)
+}
+
+func TestReverseTransferTxResultMarshalJSON(t *testing.T) {
+	result := ReverseTransferTxResult{
+		TransferTxResult: TransferTxResult{
+			Transfer:    Transfer{ID: 2, Amount: 1050, ToAmount: 1050},
+			FromAccount: Account{Currency: util.USD},
+			ToAccount:   Account{Currency: util.USD},
+		},
+		ReversalOf: 1,
+	}
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &fields))
+	require.Equal(t, "10.50", fields["amount_decimal"])
+	require.Equal(t, float64(1), fields["reversal_of"])
+
+	var got ReverseTransferTxResult
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.Equal(t, result, got)
+}
+
+func TestCaptureHoldTxResultMarshalJSON(t *testing.T) {
+	result := CaptureHoldTxResult{
+		Hold: Hold{ID: 3, Amount: 1050, CapturedAmount: 1050},
+		TransferTxResult: TransferTxResult{
+			Transfer:    Transfer{ID: 2, Amount: 1050, ToAmount: 1050},
+			FromAccount: Account{Currency: util.USD},
+			ToAccount:   Account{Currency: util.USD},
+		},
+	}
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &fields))
+	require.Equal(t, "10.50", fields["amount_decimal"])
+	require.Contains(t, fields, "hold")
+
+	var got CaptureHoldTxResult
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.Equal(t, result, got)
+}
@@ -11,12 +11,14 @@ import (
 
 var testQueries *Queries
 var testDB *sql.DB
+var testDBSource string
 
 func TestMain(m *testing.M) {
 	config, err := util.LoadConfig("../..")
 	if err != nil {
 		log.Fatal("cannot read config:", err)
 	}
+	testDBSource = config.DBSource
 	testDB, err = sql.Open(config.DBDriver, config.DBSource)
 	if err != nil {
 		log.Fatal("cannot connect to db:", err)
@@ -3,6 +3,8 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 )
 
@@ -12,6 +14,63 @@ type Account struct {
 	Balance   int64     `json:"balance"`
 	Currency  string    `json:"currency"`
 	CreatedAt time.Time `json:"created_at"`
+	// how far below zero the balance may go
+	OverdraftLimit int64 `json:"overdraft_limit"`
+	// sum of the active holds on the account
+	HeldAmount int64 `json:"held_amount"`
+	// balance that is not held
+	AvailableBalance int64  `json:"available_balance"`
+	Product          string `json:"product"`
+	// active, frozen: no debits, or closed: no movements
+	Status string `json:"status"`
+}
+
+type AccountProduct struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	// nominal yearly interest rate, 0.05 is 5%
+	AnnualRate string `json:"annual_rate"`
+	// ACT/365 or 30/360
+	DayCount string `json:"day_count"`
+	// only for accounts of the bank
+	Internal  bool      `json:"internal"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type AuditLog struct {
+	ID int64 `json:"id"`
+	// username of the caller, empty for an anonymous request
+	Actor     string `json:"actor"`
+	Ip        string `json:"ip"`
+	UserAgent string `json:"user_agent"`
+	RequestID string `json:"request_id"`
+	// API handler, e.g. createTransfer
+	Action string `json:"action"`
+	// path of the affected resource, e.g. /accounts/1
+	Target string `json:"target"`
+	// success or failure
+	Outcome string `json:"outcome"`
+	// the error of a failed request
+	Detail    string    `json:"detail"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type BalanceSnapshot struct {
+	AccountID  int64     `json:"account_id"`
+	SnapshotAt time.Time `json:"snapshot_at"`
+	// sum of the entries of the account created before snapshot_at
+	Balance   int64     `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Currency struct {
+	// ISO 4217 alphabetic code
+	Code string `json:"code"`
+	// decimals of an amount, 2 for USD, 0 for JPY, 3 for KWD
+	MinorUnits int32 `json:"minor_units"`
+	// new accounts, transfers and rates are only accepted in enabled currencies
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type Entry struct {
@@ -20,6 +79,143 @@ type Entry struct {
 	// can be negative or positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// the transfer the entry belongs to
+	TransferID sql.NullInt64 `json:"transfer_id"`
+}
+
+type FraudFlag struct {
+	ID            int64  `json:"id"`
+	Username      string `json:"username"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+	// review: the transfer was made or scheduled, deny: it was blocked
+	Decision string `json:"decision"`
+	// name of the fraud rule that decided
+	Rule string `json:"rule"`
+	// the transfer made after a review decision, null if it was denied or is only scheduled
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type FxRate struct {
+	ID           int64  `json:"id"`
+	FromCurrency string `json:"from_currency"`
+	ToCurrency   string `json:"to_currency"`
+	// units of to_currency for one unit of from_currency
+	Rate      string    `json:"rate"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Hold struct {
+	ID int64 `json:"id"`
+	// account the amount is reserved on
+	AccountID int64 `json:"account_id"`
+	// account credited on capture
+	ToAccountID int64 `json:"to_account_id"`
+	Amount      int64 `json:"amount"`
+	// active, captured, voided or expired
+	Status         string    `json:"status"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	CapturedAmount int64     `json:"captured_amount"`
+	// set once the hold is captured
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type IdempotencyKey struct {
+	Username string `json:"username"`
+	Key      string `json:"key"`
+	// sha256 of method, path and request body
+	RequestHash  string          `json:"request_hash"`
+	ResponseBody json.RawMessage `json:"response_body"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+type InterestAccrual struct {
+	AccountID int64     `json:"account_id"`
+	Day       time.Time `json:"day"`
+	// closing balance of the day
+	Balance    int64  `json:"balance"`
+	AnnualRate string `json:"annual_rate"`
+	DayCount   string `json:"day_count"`
+	// interest earned that day, not rounded
+	Amount    string    `json:"amount"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type InterestPosting struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// first day of the month the interest was accrued in
+	Month time.Time `json:"month"`
+	// sum of the accruals of the month, rounded
+	Amount int64 `json:"amount"`
+	// transfer from the interest expense account, null when the amount rounds to zero
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type OutboxEvent struct {
+	ID int64 `json:"id"`
+	// transfer, account or user
+	AggregateType string `json:"aggregate_type"`
+	AggregateID   string `json:"aggregate_id"`
+	// e.g. transfer.completed, account.created, user.registered
+	EventType string          `json:"event_type"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+	// null until the relay has published the event
+	PublishedAt sql.NullTime `json:"published_at"`
+}
+
+type ScheduledTransfer struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	// must be positive
+	Amount    int64     `json:"amount"`
+	ExecuteAt time.Time `json:"execute_at"`
+	// pending, completed, failed or canceled
+	Status string `json:"status"`
+	// set once the transfer is executed
+	TransferID    sql.NullInt64 `json:"transfer_id"`
+	FailureReason string        `json:"failure_reason"`
+	CreatedAt     time.Time     `json:"created_at"`
+	// a worker that claimed the transfer runs it until then, after that another worker may claim it again
+	ClaimedUntil sql.NullTime `json:"claimed_until"`
+}
+
+type StandingOrder struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	// daily, weekly or monthly
+	Frequency string `json:"frequency"`
+	// monthly runs happen on this day, or on the last day of shorter months
+	DayOfMonth int32     `json:"day_of_month"`
+	NextRunAt  time.Time `json:"next_run_at"`
+	// no runs are made after it
+	EndAt sql.NullTime `json:"end_at"`
+	// active, canceled or finished
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type StandingOrderRun struct {
+	ID              int64 `json:"id"`
+	StandingOrderID int64 `json:"standing_order_id"`
+	// the period the run pays for
+	RunAt time.Time `json:"run_at"`
+	// completed or failed
+	Status        string        `json:"status"`
+	TransferID    sql.NullInt64 `json:"transfer_id"`
+	FailureReason string        `json:"failure_reason"`
+	CreatedAt     time.Time     `json:"created_at"`
 }
 
 type Transfer struct {
@@ -29,6 +225,51 @@ type Transfer struct {
 	// must be positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// amount credited to the destination account, in its currency
+	ToAmount int64 `json:"to_amount"`
+	// rate applied to amount to get to_amount
+	FxRate string `json:"fx_rate"`
+}
+
+type TransferFee struct {
+	// currency of the source account, empty for any currency
+	Currency string `json:"currency"`
+	// any, own for transfers between accounts of the same user, other otherwise
+	Scope   string `json:"scope"`
+	FlatFee int64  `json:"flat_fee"`
+	// share of the amount, 0.01 is 1%
+	Rate   string `json:"rate"`
+	MinFee int64  `json:"min_fee"`
+	// 0 for no maximum
+	MaxFee    int64     `json:"max_fee"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TransferFeeCharge struct {
+	// the transfer the fee was charged for
+	TransferID int64 `json:"transfer_id"`
+	// the transfer of the fee to the fee income account, a reversal refunds it in part or in full
+	FeeTransferID int64     `json:"fee_transfer_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type TransferLimit struct {
+	Username string `json:"username"`
+	Currency string `json:"currency"`
+	// overrides the default daily limit, 0 means no limit
+	DailyLimit sql.NullInt64 `json:"daily_limit"`
+	// overrides the default monthly limit, 0 means no limit
+	MonthlyLimit sql.NullInt64 `json:"monthly_limit"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+type TransferReversal struct {
+	// the compensating transfer
+	ReversalID int64 `json:"reversal_id"`
+	// the transfer being reversed
+	TransferID int64     `json:"transfer_id"`
+	ReversedBy string    `json:"reversed_by"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
 type User struct {
@@ -38,4 +279,43 @@ type User struct {
 	Email             string    `json:"email"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
+	// depositor or admin
+	Role string `json:"role"`
+}
+
+type WebhookAttempt struct {
+	ID         int64 `json:"id"`
+	DeliveryID int64 `json:"delivery_id"`
+	// HTTP status of the response, 0 if there was none
+	StatusCode int32     `json:"status_code"`
+	Error      string    `json:"error"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	EventID        int64           `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	// pending, delivered or failed after the last attempt
+	Status        string    `json:"status"`
+	Attempts      int32     `json:"attempts"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	// HTTP status of the last attempt, 0 if there was no response
+	LastStatusCode int32        `json:"last_status_code"`
+	LastError      string       `json:"last_error"`
+	CreatedAt      time.Time    `json:"created_at"`
+	DeliveredAt    sql.NullTime `json:"delivered_at"`
+}
+
+type WebhookSubscription struct {
+	ID    int64  `json:"id"`
+	Owner string `json:"owner"`
+	Url   string `json:"url"`
+	// transfer.received, transfer.sent or account.created
+	EventTypes []string `json:"event_types"`
+	// key of the HMAC-SHA256 signature of every delivery
+	Secret    string    `json:"secret"`
+	CreatedAt time.Time `json:"created_at"`
 }
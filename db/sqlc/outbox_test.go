@@ -0,0 +1,189 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"simplebank/util"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// relayAll publishes every pending event it can with publish and returns the published ones
+func relayAll(t *testing.T, store Store, publish func(OutboxEvent) error) []OutboxEvent {
+	var published []OutboxEvent
+	for {
+		n, err := store.RelayOutboxEvents(context.Background(), 100, func(ctx context.Context, event OutboxEvent) error {
+			if err := publish(event); err != nil {
+				return err
+			}
+			published = append(published, event)
+			return nil
+		})
+		require.NoError(t, err)
+		if n < 100 {
+			return published
+		}
+	}
+}
+
+func publishAll(event OutboxEvent) error {
+	return nil
+}
+
+// findEvents returns the published events of an aggregate in the order they were published
+func findEvents(events []OutboxEvent, aggregateType string, aggregateID string) []OutboxEvent {
+	var found []OutboxEvent
+	for _, event := range events {
+		if event.AggregateType == aggregateType && event.AggregateID == aggregateID {
+			found = append(found, event)
+		}
+	}
+	return found
+}
+
+func TestTransferTxOutboxEvent(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 100)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	events := findEvents(relayAll(t, store, publishAll), AggregateTransfer, strconv.FormatInt(result.Transfer.ID, 10))
+	require.Len(t, events, 1)
+	require.Equal(t, EventTransferCompleted, events[0].EventType)
+
+	var payload TransferTxResult
+	require.NoError(t, json.Unmarshal(events[0].Payload, &payload))
+	require.Equal(t, result.Transfer.ID, payload.Transfer.ID)
+	require.Equal(t, result.FromAccount.Balance, payload.FromAccount.Balance)
+
+}
+
+func TestBatchTransferTxOutboxEvents(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 100)
+	account3 := createRandomAccountWithBalance(t, 100)
+
+	result, err := store.BatchTransferTx(context.Background(), BatchTransferTxParams{
+		FromAccountID: account1.ID,
+		Legs: []BatchTransferLeg{
+			{ToAccountID: account2.ID, Amount: 10},
+			{ToAccountID: account3.ID, Amount: 20},
+		},
+	})
+	require.NoError(t, err)
+
+	published := relayAll(t, store, publishAll)
+	for i, transfer := range result.Transfers {
+		events := findEvents(published, AggregateTransfer, strconv.FormatInt(transfer.ID, 10))
+		require.Len(t, events, 1)
+		require.Equal(t, EventTransferCompleted, events[0].EventType)
+
+		var payload TransferTxResult
+		require.NoError(t, json.Unmarshal(events[0].Payload, &payload))
+		require.Equal(t, transfer.ID, payload.Transfer.ID)
+		require.Equal(t, result.ToAccounts[i].ID, payload.ToAccount.ID)
+		require.Equal(t, result.ToEntries[i].ID, payload.ToEntry.ID)
+	}
+}
+
+func TestReverseTransferTxOutboxEvent(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 100)
+	transfer, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	result, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		ReversedBy: account2.Owner,
+	})
+	require.NoError(t, err)
+
+	events := findEvents(relayAll(t, store, publishAll), AggregateTransfer, strconv.FormatInt(result.Transfer.ID, 10))
+	require.Len(t, events, 1)
+	require.Equal(t, EventTransferCompleted, events[0].EventType)
+}
+
+func TestCreateAccountTxOutboxEvent(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	account, err := store.CreateAccountTx(context.Background(), CreateAccountTxParams{
+		CreateAccountParams: CreateAccountParams{
+			Owner:    user.Username,
+			Currency: util.USD,
+			Product:  ProductCurrent,
+		},
+	})
+	require.NoError(t, err)
+
+	events := findEvents(relayAll(t, store, publishAll), AggregateAccount, strconv.FormatInt(account.ID, 10))
+	require.Len(t, events, 1)
+	require.Equal(t, EventAccountCreated, events[0].EventType)
+}
+
+func TestCreateUserTxOutboxEvent(t *testing.T) {
+	store := NewStore(testDB)
+	hashedPassword, err := util.HashPassword(util.RandomString(6))
+	require.NoError(t, err)
+
+	user, err := store.CreateUserTx(context.Background(), CreateUserTxParams{
+		CreateUserParams: CreateUserParams{
+			Username:       util.RandomOwner(),
+			HashedPassword: hashedPassword,
+			FullName:       util.RandomOwner(),
+			Email:          util.RandomEmail(),
+		},
+	})
+	require.NoError(t, err)
+
+	events := findEvents(relayAll(t, store, publishAll), AggregateUser, user.Username)
+	require.Len(t, events, 1)
+	require.Equal(t, EventUserRegistered, events[0].EventType)
+	require.NotContains(t, string(events[0].Payload), hashedPassword)
+
+	var payload UserRegisteredEvent
+	require.NoError(t, json.Unmarshal(events[0].Payload, &payload))
+	require.Equal(t, user.Email, payload.Email)
+}
+
+func TestRelayOutboxEventsOrderPerAggregate(t *testing.T) {
+	store := NewStore(testDB)
+	blockedID := util.RandomString(12)
+	otherID := util.RandomString(12)
+
+	for _, aggregateID := range []string{blockedID, otherID, blockedID} {
+		err := addOutboxEvent(context.Background(), testQueries, "test", aggregateID, "test.happened", struct{}{})
+		require.NoError(t, err)
+	}
+
+	// the first event of blockedID fails, so the second one must wait for it
+	published := relayAll(t, store, func(event OutboxEvent) error {
+		if event.AggregateID == blockedID {
+			return errors.New("downstream is down")
+		}
+		return nil
+	})
+	require.Empty(t, findEvents(published, "test", blockedID))
+	require.Len(t, findEvents(published, "test", otherID), 1)
+
+	published = relayAll(t, store, publishAll)
+	events := findEvents(published, "test", blockedID)
+	require.Len(t, events, 2)
+	require.Less(t, events[0].ID, events[1].ID)
+	require.Empty(t, findEvents(published, "test", otherID))
+}
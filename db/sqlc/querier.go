@@ -4,24 +4,115 @@ package db
 
 import (
 	"context"
+	"time"
 )
 
 type Querier interface {
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	AddAccountHeldAmount(ctx context.Context, arg AddAccountHeldAmountParams) (Account, error)
+	CancelScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error)
+	CancelStandingOrder(ctx context.Context, id int64) (StandingOrder, error)
+	CaptureHold(ctx context.Context, arg CaptureHoldParams) (Hold, error)
+	ClaimDueScheduledTransfers(ctx context.Context, arg ClaimDueScheduledTransfersParams) ([]ScheduledTransfer, error)
+	ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	CompleteScheduledTransfer(ctx context.Context, arg CompleteScheduledTransferParams) (ScheduledTransfer, error)
+	CountPayeeTransfers(ctx context.Context, arg CountPayeeTransfersParams) (int64, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) (AuditLog, error)
+	CreateBalanceSnapshots(ctx context.Context, snapshotAt time.Time) (int64, error)
+	CreateBankAccount(ctx context.Context, arg CreateBankAccountParams) error
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateFraudFlag(ctx context.Context, arg CreateFraudFlagParams) (FraudFlag, error)
+	CreateFxRate(ctx context.Context, arg CreateFxRateParams) (FxRate, error)
+	CreateHold(ctx context.Context, arg CreateHoldParams) (Hold, error)
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error)
+	CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (int64, error)
+	CreateInterestPosting(ctx context.Context, arg CreateInterestPostingParams) (InterestPosting, error)
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) (OutboxEvent, error)
+	CreateScheduledTransfer(ctx context.Context, arg CreateScheduledTransferParams) (ScheduledTransfer, error)
+	CreateStandingOrder(ctx context.Context, arg CreateStandingOrderParams) (StandingOrder, error)
+	CreateStandingOrderRun(ctx context.Context, arg CreateStandingOrderRunParams) (StandingOrderRun, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateTransferFeeCharge(ctx context.Context, arg CreateTransferFeeChargeParams) (TransferFeeCharge, error)
+	CreateTransferReversal(ctx context.Context, arg CreateTransferReversalParams) (TransferReversal, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateWebhookAttempt(ctx context.Context, arg CreateWebhookAttemptParams) (WebhookAttempt, error)
+	CreateWebhookDeliveries(ctx context.Context, arg CreateWebhookDeliveriesParams) (int64, error)
+	CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error)
 	DeleteAccount(ctx context.Context, id int64) error
+	DeleteTransferFee(ctx context.Context, arg DeleteTransferFeeParams) (int64, error)
+	DeleteWebhookSubscription(ctx context.Context, id int64) error
+	FailScheduledTransfer(ctx context.Context, arg FailScheduledTransferParams) (ScheduledTransfer, error)
 	GetAccount(ctx context.Context, id int64) (Account, error)
+	GetAccountByProduct(ctx context.Context, arg GetAccountByProductParams) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetAccountProduct(ctx context.Context, code string) (AccountProduct, error)
+	GetBalanceBefore(ctx context.Context, arg GetBalanceBeforeParams) (int64, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetFxRate(ctx context.Context, id int64) (FxRate, error)
+	GetHold(ctx context.Context, id int64) (Hold, error)
+	GetHoldForUpdate(ctx context.Context, id int64) (Hold, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetLatestFxRate(ctx context.Context, arg GetLatestFxRateParams) (FxRate, error)
+	GetOutgoingTransferStats(ctx context.Context, arg GetOutgoingTransferStatsParams) (GetOutgoingTransferStatsRow, error)
+	GetOutgoingTransferTotal(ctx context.Context, arg GetOutgoingTransferTotalParams) (int64, error)
+	GetReversedAmounts(ctx context.Context, transferId int64) (GetReversedAmountsRow, error)
+	GetScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error)
+	GetStandingOrder(ctx context.Context, id int64) (StandingOrder, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
+	GetTransferFee(ctx context.Context, arg GetTransferFeeParams) (TransferFee, error)
+	GetTransferFeeCharge(ctx context.Context, transferId int64) (TransferFeeCharge, error)
+	GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error)
+	GetTransferLimit(ctx context.Context, arg GetTransferLimitParams) (TransferLimit, error)
+	GetTransferReversal(ctx context.Context, reversalId int64) (TransferReversal, error)
+	GetTransferWithOwners(ctx context.Context, id int64) (GetTransferWithOwnersRow, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserForUpdate(ctx context.Context, username string) (User, error)
+	GetWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error)
+	GetWebhookSubscription(ctx context.Context, id int64) (WebhookSubscription, error)
+	ListAccountEntries(ctx context.Context, arg ListAccountEntriesParams) ([]Entry, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListAccountsToAccrue(ctx context.Context, arg ListAccountsToAccrueParams) ([]ListAccountsToAccrueRow, error)
+	ListAuditLogs(ctx context.Context, arg ListAuditLogsParams) ([]AuditLog, error)
+	ListBalanceMismatches(ctx context.Context) ([]ListBalanceMismatchesRow, error)
+	ListCurrencies(ctx context.Context) ([]Currency, error)
+	ListCurrencyImbalances(ctx context.Context) ([]ListCurrencyImbalancesRow, error)
+	ListDailyEntryTotals(ctx context.Context, arg ListDailyEntryTotalsParams) ([]ListDailyEntryTotalsRow, error)
+	ListDueStandingOrders(ctx context.Context, limit int32) ([]StandingOrder, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
-	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
+	ListEntriesAfter(ctx context.Context, arg ListEntriesAfterParams) ([]Entry, error)
+	ListExpiredHolds(ctx context.Context, limit int32) ([]Hold, error)
+	ListFraudFlags(ctx context.Context, arg ListFraudFlagsParams) ([]FraudFlag, error)
+	ListInterestAccruals(ctx context.Context, arg ListInterestAccrualsParams) ([]InterestAccrual, error)
+	ListPendingOutboxEvents(ctx context.Context, limit int32) ([]OutboxEvent, error)
+	ListScheduledTransfers(ctx context.Context, arg ListScheduledTransfersParams) ([]ScheduledTransfer, error)
+	ListStandingOrderRuns(ctx context.Context, arg ListStandingOrderRunsParams) ([]StandingOrderRun, error)
+	ListStandingOrders(ctx context.Context, arg ListStandingOrdersParams) ([]StandingOrder, error)
+	ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error)
+	ListTransferEntryMismatches(ctx context.Context) ([]ListTransferEntryMismatchesRow, error)
+	ListTransferFees(ctx context.Context) ([]TransferFee, error)
+	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]ListTransfersRow, error)
+	ListUnpostedInterest(ctx context.Context, arg ListUnpostedInterestParams) ([]ListUnpostedInterestRow, error)
+	ListWebhookAttempts(ctx context.Context, deliveryId int64) ([]WebhookAttempt, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWebhookSubscriptions(ctx context.Context, owner string) ([]WebhookSubscription, error)
+	MarkOutboxEventPublished(ctx context.Context, id int64) error
+	Notify(ctx context.Context, arg NotifyParams) error
+	ReplayWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error)
+	SetAccountProduct(ctx context.Context, arg SetAccountProductParams) (AccountProduct, error)
+	SetCurrency(ctx context.Context, arg SetCurrencyParams) (Currency, error)
+	SetInterestPostingTransfer(ctx context.Context, arg SetInterestPostingTransferParams) (InterestPosting, error)
+	SetTransferFee(ctx context.Context, arg SetTransferFeeParams) (TransferFee, error)
+	SetTransferLimit(ctx context.Context, arg SetTransferLimitParams) (TransferLimit, error)
+	TryAdvisoryXactLock(ctx context.Context, key int64) (bool, error)
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateAccountOverdraftLimit(ctx context.Context, arg UpdateAccountOverdraftLimitParams) (Account, error)
+	UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error)
+	UpdateHoldStatus(ctx context.Context, arg UpdateHoldStatusParams) (Hold, error)
+	UpdateStandingOrder(ctx context.Context, arg UpdateStandingOrderParams) (StandingOrder, error)
+	UpdateStandingOrderSchedule(ctx context.Context, arg UpdateStandingOrderScheduleParams) (StandingOrder, error)
+	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error)
+	UpdateWebhookDeliveryAttempt(ctx context.Context, arg UpdateWebhookDeliveryAttemptParams) (WebhookDelivery, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"simplebank/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createReconciledAccount creates an account whose opening balance is backed by an entry
+func createReconciledAccount(t *testing.T) Account {
+	account := createRandomAccountWithBalance(t, 1000)
+	_, err := testQueries.CreateEntry(context.Background(), CreateEntryParams{
+		AccountID: account.ID,
+		Amount:    account.Balance,
+	})
+	require.NoError(t, err)
+	return account
+}
+
+func TestReconcile(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createReconciledAccount(t)
+	account2 := createReconciledAccount(t)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, result.Transfer.ID, result.FromEntry.TransferID.Int64)
+	require.Equal(t, result.Transfer.ID, result.ToEntry.TransferID.Int64)
+
+	report, err := store.Reconcile(context.Background())
+	require.NoError(t, err)
+	for _, m := range report.BalanceMismatches {
+		require.NotContains(t, []int64{account1.ID, account2.ID}, m.AccountID)
+	}
+	for _, m := range report.TransferMismatches {
+		require.NotEqual(t, result.Transfer.ID, m.TransferID)
+	}
+
+	// a balance updated without an entry and a transfer missing an entry are reported
+	_, err = testQueries.AddAccountBalance(context.Background(), AddAccountBalanceParams{
+		ID:     account1.ID,
+		Amount: 5,
+	})
+	require.NoError(t, err)
+	_, err = testDB.Exec("DELETE FROM entries WHERE id = $1", result.ToEntry.ID)
+	require.NoError(t, err)
+
+	report, err = store.Reconcile(context.Background())
+	require.NoError(t, err)
+	require.False(t, report.Balanced())
+	require.Contains(t, report.BalanceMismatches, ListBalanceMismatchesRow{
+		AccountID:    account1.ID,
+		Currency:     account1.Currency,
+		Balance:      account1.Balance - 10 + 5,
+		EntriesTotal: account1.Balance - 10,
+	})
+	require.Contains(t, report.TransferMismatches, ListTransferEntryMismatchesRow{
+		TransferID:  result.Transfer.ID,
+		Entries:     1,
+		FromEntries: 1,
+		ToEntries:   0,
+	})
+}
+
+func TestReconcileCrossCurrencyAtParity(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithCurrency(t, 1000, util.USD)
+	account2 := createRandomAccountWithCurrency(t, 1000, util.EUR)
+	for _, account := range []Account{account1, account2} {
+		_, err := testQueries.CreateEntry(context.Background(), CreateEntryParams{
+			AccountID: account.ID,
+			Amount:    account.Balance,
+		})
+		require.NoError(t, err)
+	}
+
+	before, err := store.Reconcile(context.Background())
+	require.NoError(t, err)
+
+	// a rate of exactly 1 still moves money between two currencies
+	rate, err := store.CreateFxRate(context.Background(), CreateFxRateParams{
+		FromCurrency: util.USD,
+		ToCurrency:   util.EUR,
+		Rate:         "1",
+	})
+	require.NoError(t, err)
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+		FxRateID:      rate.ID,
+	})
+	require.NoError(t, err)
+
+	after, err := store.Reconcile(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, before.CurrencyImbalances, after.CurrencyImbalances)
+}
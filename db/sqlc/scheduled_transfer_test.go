@@ -0,0 +1,272 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"simplebank/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomScheduledTransfer(t *testing.T, account1, account2 Account, amount int64, executeAt time.Time) ScheduledTransfer {
+	arg := CreateScheduledTransferParams{
+		Owner:         account1.Owner,
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+		ExecuteAt:     executeAt,
+	}
+
+	scheduled, err := testQueries.CreateScheduledTransfer(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, scheduled)
+
+	require.Equal(t, arg.Owner, scheduled.Owner)
+	require.Equal(t, arg.FromAccountID, scheduled.FromAccountID)
+	require.Equal(t, arg.ToAccountID, scheduled.ToAccountID)
+	require.Equal(t, arg.Amount, scheduled.Amount)
+	require.WithinDuration(t, arg.ExecuteAt, scheduled.ExecuteAt, time.Second)
+	require.Equal(t, ScheduledTransferPending, scheduled.Status)
+	require.False(t, scheduled.TransferID.Valid)
+
+	require.NotZero(t, scheduled.ID)
+	require.NotZero(t, scheduled.CreatedAt)
+
+	return scheduled
+}
+
+func TestCreateScheduledTransfer(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	createRandomScheduledTransfer(t, account1, account2, util.RandomMoney(), time.Now().Add(time.Hour))
+}
+
+func TestGetScheduledTransfer(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	scheduled1 := createRandomScheduledTransfer(t, account1, account2, util.RandomMoney(), time.Now().Add(time.Hour))
+
+	scheduled2, err := testQueries.GetScheduledTransfer(context.Background(), scheduled1.ID)
+	require.NoError(t, err)
+	require.Equal(t, scheduled1.ID, scheduled2.ID)
+	require.Equal(t, scheduled1.Amount, scheduled2.Amount)
+	require.Equal(t, scheduled1.Status, scheduled2.Status)
+	require.WithinDuration(t, scheduled1.ExecuteAt, scheduled2.ExecuteAt, time.Second)
+}
+
+func TestListScheduledTransfers(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	for i := 0; i < 5; i++ {
+		createRandomScheduledTransfer(t, account1, account2, util.RandomMoney(), time.Now().Add(time.Hour))
+	}
+
+	scheduled, err := testQueries.ListScheduledTransfers(context.Background(), ListScheduledTransfersParams{
+		Owner:  account1.Owner,
+		Status: ScheduledTransferPending,
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, scheduled, 5)
+
+	for _, s := range scheduled {
+		require.Equal(t, account1.Owner, s.Owner)
+	}
+}
+
+func TestCancelScheduledTransfer(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	scheduled := createRandomScheduledTransfer(t, account1, account2, util.RandomMoney(), time.Now().Add(time.Hour))
+
+	canceled, err := testQueries.CancelScheduledTransfer(context.Background(), scheduled.ID)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferCanceled, canceled.Status)
+
+	// only a pending transfer can be canceled
+	_, err = testQueries.CancelScheduledTransfer(context.Background(), scheduled.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestClaimDueScheduledTransfers(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	due := createRandomScheduledTransfer(t, account1, account2, util.RandomMoney(), time.Now().Add(-time.Minute))
+
+	leaseUntil := time.Now().Add(time.Hour)
+	claimed, err := testQueries.ClaimDueScheduledTransfers(context.Background(), ClaimDueScheduledTransfersParams{
+		LeaseUntil: leaseUntil,
+		Limit:      1000,
+	})
+	require.NoError(t, err)
+	var found bool
+	for _, c := range claimed {
+		if c.ID == due.ID {
+			found = true
+			require.True(t, c.ClaimedUntil.Valid)
+			require.WithinDuration(t, leaseUntil, c.ClaimedUntil.Time, time.Second)
+		}
+	}
+	require.True(t, found)
+
+	// another worker doesn't get the transfer until the lease runs out
+	claimed, err = testQueries.ClaimDueScheduledTransfers(context.Background(), ClaimDueScheduledTransfersParams{
+		LeaseUntil: leaseUntil,
+		Limit:      1000,
+	})
+	require.NoError(t, err)
+	for _, c := range claimed {
+		require.NotEqual(t, due.ID, c.ID)
+	}
+
+	// nor can it be canceled while it may be executing
+	_, err = testQueries.CancelScheduledTransfer(context.Background(), due.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestExecuteScheduledTransfers(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccount(t)
+
+	due := createRandomScheduledTransfer(t, account1, account2, 10, time.Now().Add(-time.Minute))
+	tooLarge := createRandomScheduledTransfer(t, account1, account2, 1000, time.Now().Add(-time.Minute))
+	later := createRandomScheduledTransfer(t, account1, account2, 10, time.Now().Add(time.Hour))
+
+	_, err := store.ExecuteScheduledTransfers(context.Background(), 1000)
+	require.NoError(t, err)
+
+	due, err = store.GetScheduledTransfer(context.Background(), due.ID)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferCompleted, due.Status)
+	require.True(t, due.TransferID.Valid)
+
+	transfer, err := store.GetTransfer(context.Background(), due.TransferID.Int64)
+	require.NoError(t, err)
+	require.Equal(t, due.Amount, transfer.Amount)
+
+	tooLarge, err = store.GetScheduledTransfer(context.Background(), tooLarge.ID)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferFailed, tooLarge.Status)
+	require.Contains(t, tooLarge.FailureReason, ErrInsufficientFunds.Error())
+
+	later, err = store.GetScheduledTransfer(context.Background(), later.ID)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferPending, later.Status)
+
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-10, updatedAccount1.Balance)
+
+	// running again must not pay twice
+	_, err = store.ExecuteScheduledTransfers(context.Background(), 1000)
+	require.NoError(t, err)
+
+	updatedAccount1, err = store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-10, updatedAccount1.Balance)
+}
+
+func TestExecuteScheduledTransferAlreadyPaid(t *testing.T) {
+	store := NewStore(testDB)
+
+	// the transfer takes everything, so making it again would fail with insufficient funds
+	account1 := createRandomAccountWithBalance(t, 10)
+	account2 := createRandomAccount(t)
+	scheduled := createRandomScheduledTransfer(t, account1, account2, 10, time.Now().Add(-time.Minute))
+
+	// simulate a worker that committed the transfer but crashed before updating the status
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		Idempotency: &IdempotencyParams{
+			Username:    scheduled.Owner,
+			Key:         fmt.Sprintf("scheduled-transfer-%d", scheduled.ID),
+			RequestHash: fmt.Sprintf("scheduled-transfer:%d", scheduled.ID),
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = store.ExecuteScheduledTransfers(context.Background(), 1000)
+	require.NoError(t, err)
+
+	scheduled, err = store.GetScheduledTransfer(context.Background(), scheduled.ID)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferCompleted, scheduled.Status)
+	require.Equal(t, result.Transfer.ID, scheduled.TransferID.Int64)
+
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-10, updatedAccount1.Balance)
+}
+
+// fraudCheckByAmount denies the transfers of account of at least deny and flags those of at least review
+func fraudCheckByAmount(account Account, review, deny int64) FraudCheck {
+	return func(ctx context.Context, store Store, username string, arg TransferTxParams) (*FraudFlagParams, error) {
+		switch {
+		case arg.FromAccountID != account.ID:
+			return nil, nil
+		case arg.Amount >= deny:
+			return &FraudFlagParams{Username: username, Decision: FraudDecisionDeny, Rule: "large"}, nil
+		case arg.Amount >= review:
+			return &FraudFlagParams{Username: username, Decision: "review", Rule: "medium"}, nil
+		}
+		return nil, nil
+	}
+}
+
+func TestExecuteScheduledTransfersFraud(t *testing.T) {
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccount(t)
+	store := NewStoreWithConfig(testDB, StoreConfig{
+		TxRetry:    DefaultTxRetryConfig,
+		FraudCheck: fraudCheckByAmount(account1, 20, 50),
+	})
+
+	allowed := createRandomScheduledTransfer(t, account1, account2, 10, time.Now().Add(-time.Minute))
+	reviewed := createRandomScheduledTransfer(t, account1, account2, 20, time.Now().Add(-time.Minute))
+	denied := createRandomScheduledTransfer(t, account1, account2, 50, time.Now().Add(-time.Minute))
+
+	_, err := store.ExecuteScheduledTransfers(context.Background(), 1000)
+	require.NoError(t, err)
+
+	allowed, err = store.GetScheduledTransfer(context.Background(), allowed.ID)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferCompleted, allowed.Status)
+
+	reviewed, err = store.GetScheduledTransfer(context.Background(), reviewed.ID)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferCompleted, reviewed.Status)
+
+	denied, err = store.GetScheduledTransfer(context.Background(), denied.ID)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferFailed, denied.Status)
+	require.Equal(t, ErrTransferDenied.Error(), denied.FailureReason)
+
+	flags, err := store.ListFraudFlags(context.Background(), ListFraudFlagsParams{
+		Username: account1.Owner,
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Len(t, flags, 2)
+	for _, flag := range flags {
+		switch flag.Decision {
+		case FraudDecisionDeny:
+			require.Equal(t, denied.Amount, flag.Amount)
+			require.False(t, flag.TransferID.Valid)
+		default:
+			require.Equal(t, reviewed.Amount, flag.Amount)
+			require.Equal(t, reviewed.TransferID, flag.TransferID)
+		}
+	}
+
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-30, updatedAccount1.Balance)
+}
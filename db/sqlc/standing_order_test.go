@@ -0,0 +1,222 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"simplebank/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomStandingOrder(t *testing.T, account1, account2 Account, amount int64, nextRunAt time.Time, endAt sql.NullTime) StandingOrder {
+	arg := CreateStandingOrderParams{
+		Owner:         account1.Owner,
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+		Frequency:     util.Monthly,
+		DayOfMonth:    int32(nextRunAt.Day()),
+		NextRunAt:     nextRunAt,
+		EndAt:         endAt,
+	}
+
+	order, err := testQueries.CreateStandingOrder(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, order)
+
+	require.Equal(t, arg.Owner, order.Owner)
+	require.Equal(t, arg.FromAccountID, order.FromAccountID)
+	require.Equal(t, arg.ToAccountID, order.ToAccountID)
+	require.Equal(t, arg.Amount, order.Amount)
+	require.Equal(t, arg.Frequency, order.Frequency)
+	require.Equal(t, arg.DayOfMonth, order.DayOfMonth)
+	require.WithinDuration(t, arg.NextRunAt, order.NextRunAt, time.Second)
+	require.Equal(t, arg.EndAt.Valid, order.EndAt.Valid)
+	require.Equal(t, StandingOrderActive, order.Status)
+
+	require.NotZero(t, order.ID)
+	require.NotZero(t, order.CreatedAt)
+
+	return order
+}
+
+func TestCreateStandingOrder(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	createRandomStandingOrder(t, account1, account2, util.RandomMoney(), time.Now().Add(time.Hour), sql.NullTime{})
+}
+
+func TestListStandingOrders(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	for i := 0; i < 5; i++ {
+		createRandomStandingOrder(t, account1, account2, util.RandomMoney(), time.Now().Add(time.Hour), sql.NullTime{})
+	}
+
+	orders, err := testQueries.ListStandingOrders(context.Background(), ListStandingOrdersParams{
+		Owner:  account1.Owner,
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, orders, 5)
+
+	for _, order := range orders {
+		require.Equal(t, account1.Owner, order.Owner)
+	}
+}
+
+func TestUpdateStandingOrder(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	order := createRandomStandingOrder(t, account1, account2, util.RandomMoney(), time.Now().Add(time.Hour), sql.NullTime{})
+
+	endAt := time.Now().AddDate(1, 0, 0)
+	updated, err := testQueries.UpdateStandingOrder(context.Background(), UpdateStandingOrderParams{
+		ID:     order.ID,
+		Amount: 42,
+		EndAt:  sql.NullTime{Time: endAt, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(42), updated.Amount)
+	require.WithinDuration(t, endAt, updated.EndAt.Time, time.Second)
+
+	canceled, err := testQueries.CancelStandingOrder(context.Background(), order.ID)
+	require.NoError(t, err)
+	require.Equal(t, StandingOrderCanceled, canceled.Status)
+
+	// only an active order can be changed or canceled
+	_, err = testQueries.UpdateStandingOrder(context.Background(), UpdateStandingOrderParams{ID: order.ID, Amount: 1})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+	_, err = testQueries.CancelStandingOrder(context.Background(), order.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestExecuteStandingOrders(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccount(t)
+
+	runAt := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+	order := createRandomStandingOrder(t, account1, account2, 10, runAt, sql.NullTime{})
+	// the next run is after the end date, so this order makes its last run
+	last := createRandomStandingOrder(t, account1, account2, 1000, runAt, sql.NullTime{Time: runAt.Add(time.Hour), Valid: true})
+
+	_, err := store.ExecuteStandingOrders(context.Background(), 1000)
+	require.NoError(t, err)
+
+	runs, err := store.ListStandingOrderRuns(context.Background(), ListStandingOrderRunsParams{
+		StandingOrderID: order.ID,
+		Limit:           5,
+	})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	require.Equal(t, StandingOrderRunCompleted, runs[0].Status)
+	require.WithinDuration(t, runAt, runs[0].RunAt, time.Second)
+
+	transfer, err := store.GetTransfer(context.Background(), runs[0].TransferID.Int64)
+	require.NoError(t, err)
+	require.Equal(t, order.Amount, transfer.Amount)
+
+	order, err = store.GetStandingOrder(context.Background(), order.ID)
+	require.NoError(t, err)
+	require.Equal(t, StandingOrderActive, order.Status)
+	nextRun, err := util.NextRun(util.Monthly, int(order.DayOfMonth), runAt)
+	require.NoError(t, err)
+	require.WithinDuration(t, nextRun, order.NextRunAt, time.Second)
+
+	runs, err = store.ListStandingOrderRuns(context.Background(), ListStandingOrderRunsParams{
+		StandingOrderID: last.ID,
+		Limit:           5,
+	})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	require.Equal(t, StandingOrderRunFailed, runs[0].Status)
+	require.Contains(t, runs[0].FailureReason, ErrInsufficientFunds.Error())
+
+	last, err = store.GetStandingOrder(context.Background(), last.ID)
+	require.NoError(t, err)
+	require.Equal(t, StandingOrderFinished, last.Status)
+
+	// neither order is due anymore, so running again pays nothing
+	_, err = store.ExecuteStandingOrders(context.Background(), 1000)
+	require.NoError(t, err)
+
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-10, updatedAccount1.Balance)
+}
+
+func TestExecuteStandingOrderAlreadyPaid(t *testing.T) {
+	store := NewStore(testDB)
+
+	// the period takes everything, so paying it again would fail with insufficient funds
+	account1 := createRandomAccountWithBalance(t, 10)
+	account2 := createRandomAccount(t)
+
+	runAt := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+	order := createRandomStandingOrder(t, account1, account2, 10, runAt, sql.NullTime{})
+
+	// simulate a worker that paid the period but crashed before recording the run
+	period := order.NextRunAt.UTC().Format("2006-01-02T15:04:05Z")
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		Idempotency: &IdempotencyParams{
+			Username:    order.Owner,
+			Key:         fmt.Sprintf("standing-order-%d-%s", order.ID, period),
+			RequestHash: fmt.Sprintf("standing-order:%d:%s", order.ID, period),
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = store.ExecuteStandingOrders(context.Background(), 1000)
+	require.NoError(t, err)
+
+	runs, err := store.ListStandingOrderRuns(context.Background(), ListStandingOrderRunsParams{
+		StandingOrderID: order.ID,
+		Limit:           5,
+	})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	require.Equal(t, StandingOrderRunCompleted, runs[0].Status)
+	require.Equal(t, result.Transfer.ID, runs[0].TransferID.Int64)
+}
+
+func TestExecuteStandingOrdersFraud(t *testing.T) {
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccount(t)
+	store := NewStoreWithConfig(testDB, StoreConfig{
+		TxRetry:    DefaultTxRetryConfig,
+		FraudCheck: fraudCheckByAmount(account1, 20, 50),
+	})
+
+	runAt := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+	order := createRandomStandingOrder(t, account1, account2, 50, runAt, sql.NullTime{})
+
+	_, err := store.ExecuteStandingOrders(context.Background(), 1000)
+	require.NoError(t, err)
+
+	runs, err := store.ListStandingOrderRuns(context.Background(), ListStandingOrderRunsParams{
+		StandingOrderID: order.ID,
+		Limit:           5,
+	})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	require.Equal(t, StandingOrderRunFailed, runs[0].Status)
+	require.Equal(t, ErrTransferDenied.Error(), runs[0].FailureReason)
+
+	// the order itself stays active for its next period
+	order, err = store.GetStandingOrder(context.Background(), order.ID)
+	require.NoError(t, err)
+	require.Equal(t, StandingOrderActive, order.Status)
+
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+}
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// recordingStatementWriter keeps everything written to it
+type recordingStatementWriter struct {
+	summary StatementSummary
+	entries []StatementEntry
+	ended   bool
+}
+
+func (w *recordingStatementWriter) Begin(summary StatementSummary) error {
+	w.summary = summary
+	return nil
+}
+
+func (w *recordingStatementWriter) Entry(entry StatementEntry) error {
+	w.entries = append(w.entries, entry)
+	return nil
+}
+
+func (w *recordingStatementWriter) End() error {
+	w.ended = true
+	return nil
+}
+
+func TestExportStatement(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createRandomAccountWithCurrency(t, 0, account1.Currency)
+
+	from := time.Now().UTC().Truncate(24 * time.Hour)
+	to := from.AddDate(0, 0, 1)
+	// before the statement, counts towards the opening balance
+	createEntryAt(t, account1, 1000, from.Add(-time.Hour))
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        30,
+	})
+	require.NoError(t, err)
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account1.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	var w recordingStatementWriter
+	err = store.ExportStatement(context.Background(), ExportStatementParams{
+		Account: account1,
+		From:    from,
+		To:      to,
+	}, &w)
+	require.NoError(t, err)
+
+	require.True(t, w.ended)
+	require.Equal(t, account1.ID, w.summary.Account.ID)
+	require.Equal(t, int64(1000), w.summary.OpeningBalance)
+	require.Equal(t, int64(980), w.summary.ClosingBalance)
+
+	require.Len(t, w.entries, 2)
+	debit := w.entries[0]
+	require.Equal(t, result.FromEntry.ID, debit.ID)
+	require.Equal(t, int64(-30), debit.Amount)
+	require.Equal(t, int64(970), debit.Balance)
+	require.Equal(t, result.Transfer.ID, debit.TransferID.Int64)
+	require.Equal(t, account2.ID, debit.CounterpartyAccountID)
+
+	credit := w.entries[1]
+	require.Equal(t, int64(10), credit.Amount)
+	require.Equal(t, int64(980), credit.Balance)
+	require.Equal(t, account2.ID, credit.CounterpartyAccountID)
+
+	// a period without entries has equal balances
+	w = recordingStatementWriter{}
+	err = store.ExportStatement(context.Background(), ExportStatementParams{
+		Account: account1,
+		From:    from.AddDate(0, 0, -2),
+		To:      from.AddDate(0, 0, -1),
+	}, &w)
+	require.NoError(t, err)
+	require.True(t, w.ended)
+	require.Empty(t, w.entries)
+	require.Equal(t, int64(0), w.summary.OpeningBalance)
+	require.Equal(t, int64(0), w.summary.ClosingBalance)
+}
@@ -3,30 +3,129 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"simplebank/util"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrInsufficientFunds is returned by TransferTx when the debit would take
+	// the available balance of the source account below its overdraft limit
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrDuplicateIdempotencyKey is returned when a concurrent request already
+	// stored a response under the same idempotency key
+	ErrDuplicateIdempotencyKey = errors.New("duplicate idempotency key")
+	// ErrInvalidFxRate is returned by TransferTx when the quoted fx rate
+	// doesn't exist, doesn't match the account currencies or is no longer current
+	ErrInvalidFxRate = errors.New("invalid fx rate")
+	// ErrInvalidReversal is returned by ReverseTransferTx for a transfer that
+	// is itself a reversal, or when the amount exceeds what is left to reverse
+	ErrInvalidReversal = errors.New("invalid reversal")
+	// ErrAccountFrozen is returned when money would be taken from a frozen account
+	ErrAccountFrozen = errors.New("account is frozen")
+	// ErrAccountClosed is returned when money would move in or out of a closed account
+	ErrAccountClosed = errors.New("account is closed")
 )
 
 type Store interface {
 	Querier
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	BatchTransferTx(ctx context.Context, arg BatchTransferTxParams) (BatchTransferTxResult, error)
+	CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (Account, error)
+	CreateFxRatesTx(ctx context.Context, arg []CreateFxRateParams) ([]FxRate, error)
+	CreateScheduledTransferTx(ctx context.Context, arg CreateScheduledTransferTxParams) (ScheduledTransfer, error)
+	CreateStandingOrderTx(ctx context.Context, arg CreateStandingOrderTxParams) (StandingOrder, error)
+	ExecuteScheduledTransfers(ctx context.Context, limit int32) ([]ScheduledTransfer, error)
+	ExecuteStandingOrders(ctx context.Context, limit int32) ([]StandingOrderRun, error)
+	ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error)
+	CreateHoldTx(ctx context.Context, arg CreateHoldParams) (Hold, error)
+	CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error)
+	VoidHoldTx(ctx context.Context, holdID int64) (Hold, error)
+	ExpireHolds(ctx context.Context, limit int32) ([]Hold, error)
+	Reconcile(ctx context.Context) (ReconciliationReport, error)
+	ExportStatement(ctx context.Context, arg ExportStatementParams, w StatementWriter) error
+	ChangeAccountStatusTx(ctx context.Context, arg ChangeAccountStatusTxParams) (ChangeAccountStatusTxResult, error)
+	AccrueInterest(ctx context.Context, day time.Time) (int64, error)
+	PostInterest(ctx context.Context, month time.Time, limit int32) ([]InterestPosting, error)
+	TxRetryStats() TxRetryStats
+	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (User, error)
+	RelayOutboxEvents(ctx context.Context, limit int32, publish func(context.Context, OutboxEvent) error) (int, error)
+	RecordWebhookAttemptTx(ctx context.Context, arg RecordWebhookAttemptTxParams) (WebhookDelivery, error)
 }
 
 type SQLStore struct {
 	*Queries
-	db *sql.DB
+	db         *sql.DB
+	retry      TxRetryConfig
+	limits     TransferLimits
+	fraudCheck FraudCheck
+	counters   txRetryCounters
+}
+
+// StoreConfig sets how a Store retries transactions, which transfer limits it enforces
+// and how it checks the transfers its workers make
+type StoreConfig struct {
+	TxRetry TxRetryConfig
+	// TransferLimits apply to the users without an override in transfer_limits
+	TransferLimits TransferLimits
+	// FraudCheck, if set, runs on every scheduled transfer and standing order payment when it is executed
+	FraudCheck FraudCheck
 }
 
-// NewStore create a Store
+// NewStore create a Store that retries transactions with DefaultTxRetryConfig, without default transfer limits
 func NewStore(db *sql.DB) Store {
+	return NewStoreWithConfig(db, StoreConfig{TxRetry: DefaultTxRetryConfig})
+}
+
+// NewStoreWithConfig create a Store set up by config
+func NewStoreWithConfig(db *sql.DB, config StoreConfig) Store {
 	return &SQLStore{
-		db:      db,
-		Queries: New(db),
+		db:         db,
+		Queries:    New(db),
+		retry:      config.TxRetry,
+		limits:     config.TransferLimits,
+		fraudCheck: config.FraudCheck,
 	}
 }
 
-// execTx executes a function within a database transaction
-func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
-	tx, err := store.db.BeginTx(ctx, nil)
+// TxRetryStats returns how many transactions were retried since the store was created
+func (store *SQLStore) TxRetryStats() TxRetryStats {
+	return store.counters.stats()
+}
+
+// execTx executes a function within a database transaction started with opts, nil for the defaults.
+// A transaction that fails with a serialization failure or a deadlock is run again after a backoff,
+// up to the configured number of retries. fn may therefore be called more than once
+// and must not keep anything from a previous call
+func (store *SQLStore) execTx(ctx context.Context, opts *sql.TxOptions, fn func(*Queries) error) error {
+	for retry := 0; ; retry++ {
+		err := store.runTx(ctx, opts, fn)
+		if err == nil || !isRetryableTxError(err) {
+			return err
+		}
+		if retry >= store.retry.MaxRetries {
+			atomic.AddInt64(&store.counters.exhausted, 1)
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(store.retry.backoff(retry)):
+		}
+		atomic.AddInt64(&store.counters.retries, 1)
+	}
+}
+
+// runTx makes one attempt of execTx
+func (store *SQLStore) runTx(ctx context.Context, opts *sql.TxOptions, fn func(*Queries) error) error {
+	tx, err := store.db.BeginTx(ctx, opts)
 	if err != nil {
 		return err
 	}
@@ -43,11 +142,82 @@ func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) erro
 	return tx.Commit()
 }
 
+// IdempotencyParams identifies a request that a client may retry.
+// The response of the transaction is stored under the key before commit
+type IdempotencyParams struct {
+	Username    string
+	Key         string
+	RequestHash string
+}
+
+// saveIdempotentResponse stores the response of a transaction under its idempotency key
+func saveIdempotentResponse(ctx context.Context, q *Queries, idem *IdempotencyParams, response interface{}) error {
+	body, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.CreateIdempotencyKey(ctx, CreateIdempotencyKeyParams{
+		Username:     idem.Username,
+		Key:          idem.Key,
+		RequestHash:  idem.RequestHash,
+		ResponseBody: body,
+	})
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+		return ErrDuplicateIdempotencyKey
+	}
+	return err
+}
+
+// CreateAccountTxParams contains the input parametres of the create account transaction
+type CreateAccountTxParams struct {
+	CreateAccountParams
+	Idempotency *IdempotencyParams `json:"-"`
+	Audit       *AuditParams       `json:"-"`
+}
+
+// CreateAccountTx creates an account, writes an account.created event and, if requested,
+// an audit log record and saves the response under the idempotency key
+func (store *SQLStore) CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (Account, error) {
+	var account Account
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		var err error
+
+		account, err = q.CreateAccount(ctx, arg.CreateAccountParams)
+		if err != nil {
+			return err
+		}
+		accountID := strconv.FormatInt(account.ID, 10)
+		err = addOutboxEvent(ctx, q, AggregateAccount, accountID, EventAccountCreated, account)
+		if err != nil {
+			return err
+		}
+		err = addAuditLog(ctx, q, arg.Audit, "/accounts/"+accountID)
+		if err != nil {
+			return err
+		}
+
+		if arg.Idempotency != nil {
+			return saveIdempotentResponse(ctx, q, arg.Idempotency, account)
+		}
+		return nil
+	})
+	return account, err
+}
+
 // TransferTxParams contains the input parametres of the transfer transaction
 type TransferTxParams struct {
 	FromAccountID int64 `json:"from_account_id"`
 	ToAccountID   int64 `json:"to_account_id"`
 	Amount        int64 `json:"amount"`
+	// FxRateID is the quoted rate of a cross-currency transfer, zero otherwise
+	FxRateID    int64              `json:"fx_rate_id"`
+	Idempotency *IdempotencyParams `json:"-"`
+	// Audit, if set, is recorded in the audit log with the transfer
+	Audit *AuditParams `json:"-"`
+	// Fraud, if set, flags the transfer for review by an analyst
+	Fraud *FraudFlagParams `json:"-"`
 }
 
 // TransferTxResult is the result of the transfer transaction
@@ -57,48 +227,239 @@ type TransferTxResult struct {
 	ToAccount   Account  `json:"to_account"`
 	FromEntry   Entry    `json:"from_entry"`
 	ToEntry     Entry    `json:"to_entry"`
+	// Fee charged to the source account on top of the amount, in its currency
+	Fee         int64     `json:"fee"`
+	FeeTransfer *Transfer `json:"fee_transfer,omitempty"`
+	FeeEntry    *Entry    `json:"fee_entry,omitempty"`
 }
 
 // TransferTx performs a money transfer from one account to the other
 // It create a transfer record, add account enties, and update account's ballance within a single database transaction
+// The fee from the fee schedule, if any, is moved to the fee income account of the bank in the same transaction,
+// which also writes a transfer.completed event to the outbox and sends the new entries to AccountEventsChannel.
+// The transaction is rolled back with ErrInsufficientFunds if the available balance of the source account would end up below its overdraft limit,
+// with ErrTransferLimitExceeded if the amount is over what is left of the transfer limits of the owner of the source account,
+// with ErrAccountFrozen if the source account is frozen and with ErrAccountClosed if either account is closed
 func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
 	var result TransferTxResult
 
-	err := store.execTx(ctx, func(q *Queries) error {
+	err := store.execTx(ctx, nil, func(q *Queries) error {
 		var err error
 
-		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
-			FromAccountID: arg.FromAccountID,
-			ToAccountID:   arg.ToAccountID,
-			Amount:        arg.Amount,
-		})
+		result, err = store.transfer(ctx, q, arg, 0)
 		if err != nil {
 			return err
 		}
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
+
+		if arg.Idempotency != nil {
+			return saveIdempotentResponse(ctx, q, arg.Idempotency, result)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// transfer makes the transfer of TransferTx within the transaction of q, without the idempotency key.
+// released is the amount of a hold on the source account that the transfer releases, zero if none
+func (store *SQLStore) transfer(ctx context.Context, q *Queries, arg TransferTxParams, released int64) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	err := store.checkTransferLimits(ctx, q, arg.FromAccountID, arg.Amount)
+	if err != nil {
+		return result, err
+	}
+
+	toAmount, fxRate, err := quotedAmount(ctx, q, arg)
+	if err != nil {
+		return result, err
+	}
+
+	result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		ToAmount:      toAmount,
+		FxRate:        fxRate,
+	})
+	if err != nil {
+		return result, err
+	}
+	err = moveMoney(ctx, q, &result)
+	if err != nil {
+		return result, err
+	}
+	if released > 0 {
+		// released after the balances are updated, so the accounts are locked in the same order as any transfer
+		result.FromAccount, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+			ID:     arg.FromAccountID,
+			Amount: -released,
 		})
 		if err != nil {
-			return err
+			return result, err
 		}
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
-		})
+	}
+	fee, err := transferFee(ctx, q, result.FromAccount, result.ToAccount, arg.Amount)
+	if err != nil {
+		return result, err
+	}
+	if fee > 0 {
+		err = store.chargeFee(ctx, q, &result, fee)
 		if err != nil {
-			return err
+			return result, err
 		}
-		if arg.FromAccountID < arg.ToAccountID {
-			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
-		} else {
-			result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
+	}
+	err = checkFunds(result.FromAccount, arg.Amount+fee)
+	if err != nil {
+		return result, err
+	}
+	err = notifyAccountEvents(ctx, q, &result)
+	if err != nil {
+		return result, err
+	}
+	err = addTransferEvent(ctx, q, &result)
+	if err != nil {
+		return result, err
+	}
+	err = addAuditLog(ctx, q, arg.Audit, fmt.Sprintf("/transfers/%d", result.Transfer.ID))
+	if err != nil {
+		return result, err
+	}
+	return result, addFraudFlag(ctx, q, arg.Fraud, &result)
+}
+
+// moveMoney updates both balances and creates the account entries of result.Transfer.
+// The entries are created once both accounts are locked, so the entries of an account
+// get their IDs in the order their transactions commit
+func moveMoney(ctx context.Context, q *Queries, result *TransferTxResult) error {
+	transfer := result.Transfer
+
+	var err error
+	if transfer.FromAccountID < transfer.ToAccountID {
+		result.FromAccount, result.ToAccount, err = addMoney(ctx, q, transfer.FromAccountID, -transfer.Amount, transfer.ToAccountID, transfer.ToAmount)
+	} else {
+		result.ToAccount, result.FromAccount, err = addMoney(ctx, q, transfer.ToAccountID, transfer.ToAmount, transfer.FromAccountID, -transfer.Amount)
+	}
+	if err != nil {
+		return err
+	}
+
+	result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID:  transfer.FromAccountID,
+		Amount:     -transfer.Amount,
+		TransferID: sql.NullInt64{Int64: transfer.ID, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID:  transfer.ToAccountID,
+		Amount:     transfer.ToAmount,
+		TransferID: sql.NullInt64{Int64: transfer.ID, Valid: true},
+	})
+	return err
+}
+
+// checkFunds fails with ErrInsufficientFunds if the available balance of an account,
+// after amount was taken from it, is below its overdraft limit
+func checkFunds(account Account, amount int64) error {
+	if account.AvailableBalance < -account.OverdraftLimit {
+		return fmt.Errorf("%w: account [%d] available balance %d, overdraft limit %d, amount %d",
+			ErrInsufficientFunds, account.ID, account.AvailableBalance+amount, account.OverdraftLimit, amount)
+	}
+	return nil
+}
+
+// checkStatus fails with ErrAccountClosed if the balance of a closed account changed by amount,
+// and with ErrAccountFrozen if amount was taken from a frozen account
+func checkStatus(account Account, amount int64) error {
+	switch {
+	case account.Status == util.AccountClosed:
+		return fmt.Errorf("%w: account [%d]", ErrAccountClosed, account.ID)
+	case account.Status == util.AccountFrozen && amount < 0:
+		return fmt.Errorf("%w: account [%d]", ErrAccountFrozen, account.ID)
+	}
+	return nil
+}
+
+// quotedAmount returns the amount to credit to the destination account and the rate applied.
+// A cross-currency transfer must quote the latest rate between the two account currencies
+func quotedAmount(ctx context.Context, q *Queries, arg TransferTxParams) (int64, string, error) {
+	if arg.FxRateID == 0 {
+		return arg.Amount, "1", nil
+	}
+
+	rate, err := q.GetFxRate(ctx, arg.FxRateID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, "", fmt.Errorf("%w: rate [%d] not found", ErrInvalidFxRate, arg.FxRateID)
+		}
+		return 0, "", err
+	}
+
+	fromAccount, err := q.GetAccount(ctx, arg.FromAccountID)
+	if err != nil {
+		return 0, "", err
+	}
+	toAccount, err := q.GetAccount(ctx, arg.ToAccountID)
+	if err != nil {
+		return 0, "", err
+	}
+	if rate.FromCurrency != fromAccount.Currency || rate.ToCurrency != toAccount.Currency {
+		return 0, "", fmt.Errorf("%w: rate [%d] is %s/%s, accounts are %s/%s", ErrInvalidFxRate,
+			rate.ID, rate.FromCurrency, rate.ToCurrency, fromAccount.Currency, toAccount.Currency)
+	}
+
+	latest, err := q.GetLatestFxRate(ctx, GetLatestFxRateParams{
+		FromCurrency: rate.FromCurrency,
+		ToCurrency:   rate.ToCurrency,
+	})
+	if err != nil {
+		return 0, "", err
+	}
+	if latest.ID != rate.ID {
+		return 0, "", fmt.Errorf("%w: rate [%d] is no longer current, latest is [%d]", ErrInvalidFxRate, rate.ID, latest.ID)
+	}
+
+	// the rate is quoted per major unit, the amounts are in minor units of each currency
+	from, ok := util.LookupCurrency(fromAccount.Currency)
+	if !ok {
+		return 0, "", fmt.Errorf("%w: unknown currency %s", ErrInvalidFxRate, fromAccount.Currency)
+	}
+	to, ok := util.LookupCurrency(toAccount.Currency)
+	if !ok {
+		return 0, "", fmt.Errorf("%w: unknown currency %s", ErrInvalidFxRate, toAccount.Currency)
+	}
+	toAmount, err := util.ConvertAmount(arg.Amount, rate.Rate, from.MinorUnits, to.MinorUnits)
+	if err != nil {
+		return 0, "", err
+	}
+	if toAmount <= 0 {
+		return 0, "", fmt.Errorf("%w: amount %d is too small to convert", ErrInvalidFxRate, arg.Amount)
+	}
+	return toAmount, rate.Rate, nil
+}
+
+// CreateFxRatesTx stores a set of fx rates, either all of them or none
+func (store *SQLStore) CreateFxRatesTx(ctx context.Context, arg []CreateFxRateParams) ([]FxRate, error) {
+	rates := make([]FxRate, 0, len(arg))
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		// start over if the transaction is retried
+		rates = rates[:0]
+
+		for _, params := range arg {
+			rate, err := q.CreateFxRate(ctx, params)
+			if err != nil {
+				return err
+			}
+			rates = append(rates, rate)
 		}
 		return nil
 	})
-	return result, err
+	return rates, err
 }
 
+// addMoney adds the amounts to the balances of two accounts, failing if the status of either forbids it
 func addMoney(
 	ctx context.Context,
 	q *Queries,
@@ -114,11 +475,19 @@ func addMoney(
 	if err != nil {
 		return
 	}
+	err = checkStatus(account1, amount1)
+	if err != nil {
+		return
+	}
 
 	account2, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
 		ID:     accountID2,
 		Amount: amount2,
 	})
+	if err != nil {
+		return
+	}
+	err = checkStatus(account2, amount2)
 
 	return
 }
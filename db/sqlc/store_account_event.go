@@ -0,0 +1,53 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// AccountEventsChannel is the Postgres channel that carries the changes of the account balances
+const AccountEventsChannel = "account_events"
+
+// AccountEvent is sent on AccountEventsChannel for every entry a transfer adds to an account,
+// and when a hold changes the available balance. Postgres delivers it to the listeners only when the transaction commits
+type AccountEvent struct {
+	AccountID int64 `json:"account_id"`
+	// Entry is nil if only the available balance changed
+	Entry *Entry `json:"entry,omitempty"`
+	// Balance and AvailableBalance of the account after the transaction
+	Balance          int64 `json:"balance"`
+	AvailableBalance int64 `json:"available_balance"`
+}
+
+// notifyAccountEvents sends an AccountEvent for each entry of result to the listeners of AccountEventsChannel
+func notifyAccountEvents(ctx context.Context, q *Queries, result *TransferTxResult) error {
+	err := notifyAccountEvent(ctx, q, result.FromAccount, &result.FromEntry)
+	if err != nil {
+		return err
+	}
+	err = notifyAccountEvent(ctx, q, result.ToAccount, &result.ToEntry)
+	if err != nil {
+		return err
+	}
+	if result.FeeEntry != nil {
+		return notifyAccountEvent(ctx, q, result.FromAccount, result.FeeEntry)
+	}
+	return nil
+}
+
+// notifyAccountEvent sends an AccountEvent with the balances of account, entry is nil if it has no new entry
+func notifyAccountEvent(ctx context.Context, q *Queries, account Account, entry *Entry) error {
+	payload, err := json.Marshal(AccountEvent{
+		AccountID:        account.ID,
+		Entry:            entry,
+		Balance:          account.Balance,
+		AvailableBalance: account.AvailableBalance,
+	})
+	if err != nil {
+		return err
+	}
+	return q.Notify(ctx, NotifyParams{
+		Channel: AccountEventsChannel,
+		Payload: string(payload),
+	})
+}
@@ -0,0 +1,46 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"math"
+)
+
+// products of an account. Internal products are only used by accounts of the bank
+const (
+	ProductCurrent         = "current"
+	ProductInterestExpense = "interest_expense"
+	ProductFeeIncome       = "fee_income"
+)
+
+// BankUsername owns the internal accounts of the bank. It can't be registered, nor log in
+const BankUsername = "_bank"
+
+// bankOverdraftLimit lets an internal account go below zero by whatever the bank pays out of it
+const bankOverdraftLimit = math.MaxInt64
+
+// bankAccount returns the internal account of the bank with product in currency, creating it on first use.
+// It is created outside of any transaction, so transfers running in their own transactions see it at once
+func (store *SQLStore) bankAccount(ctx context.Context, product, currency string) (Account, error) {
+	arg := GetAccountByProductParams{
+		Owner:    BankUsername,
+		Currency: currency,
+		Product:  product,
+	}
+	account, err := store.GetAccountByProduct(ctx, arg)
+	if err != sql.ErrNoRows {
+		return account, err
+	}
+
+	err = store.CreateBankAccount(ctx, CreateBankAccountParams{
+		Owner:          BankUsername,
+		Currency:       currency,
+		Product:        product,
+		OverdraftLimit: bankOverdraftLimit,
+	})
+	if err != nil {
+		return account, err
+	}
+	// a concurrent call may have created it first
+	return store.GetAccountByProduct(ctx, arg)
+}
@@ -0,0 +1,142 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"simplebank/util"
+)
+
+// ErrInvalidStatusChange is returned by ChangeAccountStatusTx for a transition that isn't allowed,
+// or when an account can't be closed as it is
+var ErrInvalidStatusChange = errors.New("invalid account status change")
+
+// ChangeAccountStatusTxParams contains the input parametres of the account status change transaction
+type ChangeAccountStatusTxParams struct {
+	AccountID int64  `json:"account_id"`
+	Status    string `json:"status"`
+	// SweepAccountID receives the balance of an account being closed. It must belong to the same owner,
+	// in the same currency. Zero requires the balance to be zero already
+	SweepAccountID int64 `json:"sweep_account_id"`
+}
+
+// ChangeAccountStatusTxResult is the result of the account status change transaction
+type ChangeAccountStatusTxResult struct {
+	Account Account `json:"account"`
+	// Sweep is the transfer of the balance of a closed account and its fee, if there was any balance.
+	// A balance no larger than the fee is taken as the fee in full, and the sweep has no transfer then
+	Sweep *TransferTxResult `json:"sweep,omitempty"`
+}
+
+// ChangeAccountStatusTx moves an account to another status, if the transition is allowed.
+// An account is closed only without held money and with a zero balance, or a positive balance
+// that is swept to another account of the owner in the same transaction. The sweep is charged
+// the fee of the fee schedule like any transfer, out of the balance swept
+func (store *SQLStore) ChangeAccountStatusTx(ctx context.Context, arg ChangeAccountStatusTxParams) (ChangeAccountStatusTxResult, error) {
+	var result ChangeAccountStatusTxResult
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		// start over if the transaction is retried
+		result = ChangeAccountStatusTxResult{}
+
+		// the sweep account is locked along with the account in ID order, like moveMoney locks the accounts
+		// of any transfer, so closing can't deadlock with a transfer between the two
+		if arg.Status == util.AccountClosed && arg.SweepAccountID != 0 && arg.SweepAccountID < arg.AccountID {
+			_, err := q.GetAccountForUpdate(ctx, arg.SweepAccountID)
+			if err != nil && err != sql.ErrNoRows {
+				return err
+			}
+		}
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if !util.CanChangeAccountStatus(account.Status, arg.Status) {
+			return fmt.Errorf("%w: account [%d] is %s, it can't become %s", ErrInvalidStatusChange, account.ID, account.Status, arg.Status)
+		}
+
+		if arg.Status == util.AccountClosed {
+			result.Sweep, err = store.sweepBalance(ctx, q, account, arg.SweepAccountID)
+			if err != nil {
+				return err
+			}
+		}
+
+		result.Account, err = q.UpdateAccountStatus(ctx, UpdateAccountStatusParams{
+			ID:     account.ID,
+			Status: arg.Status,
+		})
+		return err
+	})
+	return result, err
+}
+
+// sweepBalance transfers the whole balance of an account that is being closed to the sweep account,
+// less the fee of the transfer, which takes the balance to zero. It returns nil if there is nothing to sweep
+func (store *SQLStore) sweepBalance(ctx context.Context, q *Queries, account Account, sweepAccountID int64) (*TransferTxResult, error) {
+	if account.HeldAmount != 0 {
+		return nil, fmt.Errorf("%w: account [%d] has %d held", ErrInvalidStatusChange, account.ID, account.HeldAmount)
+	}
+	if account.Balance < 0 {
+		return nil, fmt.Errorf("%w: account [%d] is overdrawn by %d", ErrInvalidStatusChange, account.ID, -account.Balance)
+	}
+	if account.Balance == 0 {
+		return nil, nil
+	}
+	if sweepAccountID == 0 {
+		return nil, fmt.Errorf("%w: account [%d] balance is %d, a sweep account is required", ErrInvalidStatusChange, account.ID, account.Balance)
+	}
+
+	sweep, err := q.GetAccount(ctx, sweepAccountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: sweep account [%d] not found", ErrInvalidStatusChange, sweepAccountID)
+		}
+		return nil, err
+	}
+	if sweep.ID == account.ID || sweep.Owner != account.Owner || sweep.Currency != account.Currency {
+		return nil, fmt.Errorf("%w: sweep account [%d] must be another %s account of %s", ErrInvalidStatusChange, sweep.ID, account.Currency, account.Owner)
+	}
+
+	fee, err := transferFee(ctx, q, account, sweep, account.Balance)
+	if err != nil {
+		return nil, err
+	}
+	if fee > account.Balance {
+		fee = account.Balance
+	}
+
+	result := &TransferTxResult{FromAccount: account, ToAccount: sweep}
+	if amount := account.Balance - fee; amount > 0 {
+		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+			FromAccountID: account.ID,
+			ToAccountID:   sweep.ID,
+			Amount:        amount,
+			ToAmount:      amount,
+			FxRate:        "1",
+		})
+		if err != nil {
+			return nil, err
+		}
+		err = moveMoney(ctx, q, result)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if fee > 0 {
+		err = store.chargeFee(ctx, q, result, fee)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if result.Transfer.ID == 0 {
+		return result, notifyAccountEvent(ctx, q, result.FromAccount, result.FeeEntry)
+	}
+	err = notifyAccountEvents(ctx, q, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, addTransferEvent(ctx, q, result)
+}
@@ -0,0 +1,38 @@
+package db
+
+import (
+	"context"
+)
+
+// outcomes of an audited action
+const (
+	AuditSuccess = "success"
+	AuditFailure = "failure"
+)
+
+// AuditParams describes who made a change and how, for the audit log record
+// that the store writes in the same transaction as the change
+type AuditParams struct {
+	Actor     string
+	IP        string
+	UserAgent string
+	RequestID string
+	Action    string
+}
+
+// addAuditLog records a successful action on target, it does nothing if audit is nil
+func addAuditLog(ctx context.Context, q *Queries, audit *AuditParams, target string) error {
+	if audit == nil {
+		return nil
+	}
+	_, err := q.CreateAuditLog(ctx, CreateAuditLogParams{
+		Actor:     audit.Actor,
+		Ip:        audit.IP,
+		UserAgent: audit.UserAgent,
+		RequestID: audit.RequestID,
+		Action:    audit.Action,
+		Target:    target,
+		Outcome:   AuditSuccess,
+	})
+	return err
+}
@@ -0,0 +1,195 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+)
+
+// BatchTransferLeg is one credit of a batch transfer
+type BatchTransferLeg struct {
+	ToAccountID int64 `json:"to_account_id"`
+	Amount      int64 `json:"amount"`
+	// Fraud, if set, flags the transfer of the leg for review by an analyst
+	Fraud *FraudFlagParams `json:"-"`
+}
+
+// BatchTransferTxParams contains the input parametres of the batch transfer transaction
+type BatchTransferTxParams struct {
+	FromAccountID int64              `json:"from_account_id"`
+	Legs          []BatchTransferLeg `json:"legs"`
+	Idempotency   *IdempotencyParams `json:"-"`
+	// Audit, if set, is recorded in the audit log with every transfer of the batch
+	Audit *AuditParams `json:"-"`
+}
+
+// BatchTransferTxResult is the result of the batch transfer transaction.
+// Transfers, ToAccounts, Fees and both kinds of entries are in the order of the legs
+type BatchTransferTxResult struct {
+	Transfers   []Transfer `json:"transfers"`
+	FromAccount Account    `json:"from_account"`
+	ToAccounts  []Account  `json:"to_accounts"`
+	FromEntries []Entry    `json:"from_entries"`
+	ToEntries   []Entry    `json:"to_entries"`
+	// Fees charged to the source account on top of the amount of each leg, zero for a free leg
+	Fees []int64 `json:"fees"`
+}
+
+// BatchTransferTx debits one account and credits every leg within a single database transaction,
+// so either all legs are transferred or none. Each leg gets its own transfer, entries, fee from the fee schedule,
+// transfer.completed event, audit log record and fraud flag if it has one, and its entries are sent to AccountEventsChannel.
+// The transaction is rolled back with ErrInsufficientFunds if the total with the fees would take the source account
+// below its overdraft limit, and with ErrTransferLimitExceeded if the total is over the transfer limits of its owner
+func (store *SQLStore) BatchTransferTx(ctx context.Context, arg BatchTransferTxParams) (BatchTransferTxResult, error) {
+	var result BatchTransferTxResult
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		// start over if the transaction is retried
+		result = BatchTransferTxResult{}
+
+		var total int64
+		for _, leg := range arg.Legs {
+			total += leg.Amount
+		}
+		err := store.checkTransferLimits(ctx, q, arg.FromAccountID, total)
+		if err != nil {
+			return err
+		}
+
+		amounts := map[int64]int64{}
+		for _, leg := range arg.Legs {
+			transfer, err := q.CreateTransfer(ctx, CreateTransferParams{
+				FromAccountID: arg.FromAccountID,
+				ToAccountID:   leg.ToAccountID,
+				Amount:        leg.Amount,
+				ToAmount:      leg.Amount,
+				FxRate:        "1",
+			})
+			if err != nil {
+				return err
+			}
+			result.Transfers = append(result.Transfers, transfer)
+
+			amounts[arg.FromAccountID] -= leg.Amount
+			amounts[leg.ToAccountID] += leg.Amount
+		}
+
+		accounts, err := addMoneyInOrder(ctx, q, amounts)
+		if err != nil {
+			return err
+		}
+		result.FromAccount = accounts[arg.FromAccountID]
+		for _, leg := range arg.Legs {
+			result.ToAccounts = append(result.ToAccounts, accounts[leg.ToAccountID])
+		}
+
+		// like moveMoney, the entries are created once every account is locked
+		for i, leg := range arg.Legs {
+			transfer := result.Transfers[i]
+			fromEntry, err := q.CreateEntry(ctx, CreateEntryParams{
+				AccountID:  arg.FromAccountID,
+				Amount:     -leg.Amount,
+				TransferID: sql.NullInt64{Int64: transfer.ID, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+			result.FromEntries = append(result.FromEntries, fromEntry)
+
+			toEntry, err := q.CreateEntry(ctx, CreateEntryParams{
+				AccountID:  leg.ToAccountID,
+				Amount:     leg.Amount,
+				TransferID: sql.NullInt64{Int64: transfer.ID, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+			result.ToEntries = append(result.ToEntries, toEntry)
+		}
+
+		legResults := make([]*TransferTxResult, len(arg.Legs))
+		var fees int64
+		for i, leg := range arg.Legs {
+			legResults[i] = &TransferTxResult{
+				Transfer:    result.Transfers[i],
+				FromAccount: result.FromAccount,
+				ToAccount:   result.ToAccounts[i],
+				FromEntry:   result.FromEntries[i],
+				ToEntry:     result.ToEntries[i],
+			}
+			fee, err := transferFee(ctx, q, result.FromAccount, result.ToAccounts[i], leg.Amount)
+			if err != nil {
+				return err
+			}
+			if fee > 0 {
+				err = store.chargeFee(ctx, q, legResults[i], fee)
+				if err != nil {
+					return err
+				}
+				result.FromAccount = legResults[i].FromAccount
+			}
+			result.Fees = append(result.Fees, fee)
+			fees += fee
+		}
+
+		err = checkFunds(result.FromAccount, total+fees)
+		if err != nil {
+			return err
+		}
+		for i, legResult := range legResults {
+			// every leg reports the balance of the source account after the whole batch
+			legResult.FromAccount = result.FromAccount
+			err = notifyAccountEvents(ctx, q, legResult)
+			if err != nil {
+				return err
+			}
+			err = addTransferEvent(ctx, q, legResult)
+			if err != nil {
+				return err
+			}
+			err = addAuditLog(ctx, q, arg.Audit, fmt.Sprintf("/transfers/%d", legResult.Transfer.ID))
+			if err != nil {
+				return err
+			}
+			err = addFraudFlag(ctx, q, arg.Legs[i].Fraud, legResult)
+			if err != nil {
+				return err
+			}
+		}
+
+		if arg.Idempotency != nil {
+			return saveIdempotentResponse(ctx, q, arg.Idempotency, result)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// addMoneyInOrder adds the amounts to the balances of their accounts, updating every account once.
+// Like addMoney, it goes through the accounts in ID order, so concurrent transactions lock them
+// in the same order and can't deadlock
+func addMoneyInOrder(ctx context.Context, q *Queries, amounts map[int64]int64) (map[int64]Account, error) {
+	ids := make([]int64, 0, len(amounts))
+	for id := range amounts {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	accounts := make(map[int64]Account, len(ids))
+	for _, id := range ids {
+		account, err := q.AddAccountBalance(ctx, AddAccountBalanceParams{
+			ID:     id,
+			Amount: amounts[id],
+		})
+		if err != nil {
+			return nil, err
+		}
+		err = checkStatus(account, amounts[id])
+		if err != nil {
+			return nil, err
+		}
+		accounts[id] = account
+	}
+	return accounts, nil
+}
@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// ErrTransferDenied is returned when the fraud rules deny a transfer that a worker was about to make
+var ErrTransferDenied = errors.New("transfer declined by the fraud checks")
+
+// FraudDecisionDeny is the decision of a flag whose transfer was blocked
+const FraudDecisionDeny = "deny"
+
+// FraudCheck runs the fraud rules on a transfer that a worker is about to make for username,
+// with the facts read from store. It returns the flag of the rule that matched, nil if the transfer is allowed
+type FraudCheck func(ctx context.Context, store Store, username string, arg TransferTxParams) (*FraudFlagParams, error)
+
+// FraudFlagParams names the fraud rule that flagged a transfer and its decision,
+// for the flag that the store writes in the same transaction as the transfer
+type FraudFlagParams struct {
+	Username string
+	Decision string
+	Rule     string
+}
+
+// addFraudFlag records the flag of a transfer that was made, it does nothing if flag is nil
+func addFraudFlag(ctx context.Context, q *Queries, flag *FraudFlagParams, result *TransferTxResult) error {
+	if flag == nil {
+		return nil
+	}
+	_, err := q.CreateFraudFlag(ctx, CreateFraudFlagParams{
+		Username:      flag.Username,
+		FromAccountID: result.Transfer.FromAccountID,
+		ToAccountID:   result.Transfer.ToAccountID,
+		Amount:        result.Transfer.Amount,
+		Currency:      result.FromAccount.Currency,
+		Decision:      flag.Decision,
+		Rule:          flag.Rule,
+		TransferID:    sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+	})
+	return err
+}
+
+// addPendingFraudFlag records the flag of a transfer that is only scheduled or was denied, so the flag has no transfer.
+// It does nothing if flag is nil
+func addPendingFraudFlag(ctx context.Context, q *Queries, flag *FraudFlagParams, fromAccountID, toAccountID, amount int64) error {
+	if flag == nil {
+		return nil
+	}
+	account, err := q.GetAccount(ctx, fromAccountID)
+	if err != nil {
+		return err
+	}
+	_, err = q.CreateFraudFlag(ctx, CreateFraudFlagParams{
+		Username:      flag.Username,
+		FromAccountID: fromAccountID,
+		ToAccountID:   toAccountID,
+		Amount:        amount,
+		Currency:      account.Currency,
+		Decision:      flag.Decision,
+		Rule:          flag.Rule,
+	})
+	return err
+}
+
+// checkFraud runs the fraud check of the store, if it has one, on a transfer that a worker makes for username.
+// A transfer to review gets its flag in arg.Fraud, a denied one is recorded for the analysts and fails
+// with ErrTransferDenied. Transfers of the bank, such as interest, aren't checked
+func (store *SQLStore) checkFraud(ctx context.Context, q *Queries, username string, arg *TransferTxParams) error {
+	if store.fraudCheck == nil || username == BankUsername {
+		return nil
+	}
+	flag, err := store.fraudCheck(ctx, store, username, *arg)
+	if err != nil || flag == nil {
+		return err
+	}
+	if flag.Decision == FraudDecisionDeny {
+		err = addPendingFraudFlag(ctx, q, flag, arg.FromAccountID, arg.ToAccountID, arg.Amount)
+		if err != nil {
+			return err
+		}
+		return ErrTransferDenied
+	}
+	arg.Fraud = flag
+	return nil
+}
@@ -0,0 +1,200 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// statuses of a hold
+const (
+	HoldActive   = "active"
+	HoldCaptured = "captured"
+	HoldVoided   = "voided"
+	HoldExpired  = "expired"
+)
+
+var (
+	// ErrHoldNotActive is returned when a hold that was already captured, voided or has expired
+	// is captured or voided
+	ErrHoldNotActive = errors.New("hold is not active")
+	// ErrCaptureExceedsHold is returned by CaptureHoldTx when the amount is more than was held
+	ErrCaptureExceedsHold = errors.New("capture exceeds the held amount")
+)
+
+// CreateHoldTx reserves an amount on an account. The held amount lowers the available balance
+// but not the balance, which is sent to AccountEventsChannel.
+// It fails with ErrInsufficientFunds, ErrAccountFrozen or ErrAccountClosed like a transfer would
+func (store *SQLStore) CreateHoldTx(ctx context.Context, arg CreateHoldParams) (Hold, error) {
+	var hold Hold
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		account, err := q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+			ID:     arg.AccountID,
+			Amount: arg.Amount,
+		})
+		if err != nil {
+			return err
+		}
+		err = checkStatus(account, -arg.Amount)
+		if err != nil {
+			return err
+		}
+		err = checkFunds(account, arg.Amount)
+		if err != nil {
+			return err
+		}
+		err = notifyAccountEvent(ctx, q, account, nil)
+		if err != nil {
+			return err
+		}
+
+		hold, err = q.CreateHold(ctx, arg)
+		return err
+	})
+	return hold, err
+}
+
+// CaptureHoldTxParams contains the input parametres of the capture hold transaction
+type CaptureHoldTxParams struct {
+	HoldID int64 `json:"hold_id"`
+	// Amount to transfer, the whole held amount if zero
+	Amount int64 `json:"amount"`
+	// Audit, if set, is recorded in the audit log with the transfer
+	Audit *AuditParams `json:"-"`
+	// Fraud, if set, flags the transfer for review by an analyst
+	Fraud *FraudFlagParams `json:"-"`
+}
+
+// CaptureHoldTxResult is the result of the capture hold transaction
+type CaptureHoldTxResult struct {
+	Hold Hold `json:"hold"`
+	TransferTxResult
+}
+
+// CaptureHoldTx releases an active hold and transfers up to the held amount to its destination account.
+// The transfer is made like TransferTx makes it, with its fee, transfer limits, audit record, fraud flag and transfer.completed event.
+// Whatever is not captured becomes available again
+func (store *SQLStore) CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error) {
+	var result CaptureHoldTxResult
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		// start over if the transaction is retried
+		result = CaptureHoldTxResult{}
+
+		hold, err := activeHoldForUpdate(ctx, q, arg.HoldID)
+		if err != nil {
+			return err
+		}
+
+		amount := arg.Amount
+		if amount == 0 {
+			amount = hold.Amount
+		}
+		if amount > hold.Amount {
+			return fmt.Errorf("%w: hold [%d] is %d, capture %d", ErrCaptureExceedsHold, hold.ID, hold.Amount, amount)
+		}
+
+		result.TransferTxResult, err = store.transfer(ctx, q, TransferTxParams{
+			FromAccountID: hold.AccountID,
+			ToAccountID:   hold.ToAccountID,
+			Amount:        amount,
+			Audit:         arg.Audit,
+			Fraud:         arg.Fraud,
+		}, hold.Amount)
+		if err != nil {
+			return err
+		}
+
+		result.Hold, err = q.CaptureHold(ctx, CaptureHoldParams{
+			ID:             hold.ID,
+			CapturedAmount: amount,
+			TransferID:     sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+		})
+		return err
+	})
+	return result, err
+}
+
+// VoidHoldTx releases an active hold without moving any money
+func (store *SQLStore) VoidHoldTx(ctx context.Context, holdID int64) (Hold, error) {
+	var hold Hold
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		var err error
+
+		hold, err = activeHoldForUpdate(ctx, q, holdID)
+		if err != nil {
+			return err
+		}
+
+		hold, err = releaseHold(ctx, q, hold, HoldVoided)
+		return err
+	})
+	return hold, err
+}
+
+// ExpireHolds releases up to limit active holds whose time is up.
+// The holds are locked with FOR UPDATE SKIP LOCKED, so several workers can run at the same time
+func (store *SQLStore) ExpireHolds(ctx context.Context, limit int32) ([]Hold, error) {
+	var expired []Hold
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		// start over if the transaction is retried
+		expired = nil
+
+		holds, err := q.ListExpiredHolds(ctx, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, hold := range holds {
+			hold, err = releaseHold(ctx, q, hold, HoldExpired)
+			if err != nil {
+				return err
+			}
+			expired = append(expired, hold)
+		}
+		return nil
+	})
+	return expired, err
+}
+
+// activeHoldForUpdate locks a hold and checks that it can still be captured or voided.
+// A hold past its expiry time is not active even if the worker hasn't released it yet
+func activeHoldForUpdate(ctx context.Context, q *Queries, holdID int64) (Hold, error) {
+	hold, err := q.GetHoldForUpdate(ctx, holdID)
+	if err != nil {
+		return hold, err
+	}
+	if hold.Status != HoldActive {
+		return hold, fmt.Errorf("%w: hold [%d] is %s", ErrHoldNotActive, hold.ID, hold.Status)
+	}
+	if !hold.ExpiresAt.After(time.Now()) {
+		return hold, fmt.Errorf("%w: hold [%d] expired at %s", ErrHoldNotActive, hold.ID, hold.ExpiresAt)
+	}
+	return hold, nil
+}
+
+// releaseHold gives the held amount back to the available balance, sends the new balance
+// to AccountEventsChannel and sets the final status of the hold
+func releaseHold(ctx context.Context, q *Queries, hold Hold, status string) (Hold, error) {
+	account, err := q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+		ID:     hold.AccountID,
+		Amount: -hold.Amount,
+	})
+	if err != nil {
+		return hold, err
+	}
+	err = notifyAccountEvent(ctx, q, account, nil)
+	if err != nil {
+		return hold, err
+	}
+
+	return q.UpdateHoldStatus(ctx, UpdateHoldStatusParams{
+		ID:     hold.ID,
+		Status: status,
+	})
+}
@@ -0,0 +1,145 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"simplebank/util"
+	"time"
+)
+
+// AccrueInterest records the interest every account of an interest-bearing product earned on a UTC day,
+// computed on the closing balance of the day with the day count convention of the product.
+// Accounts that already have the accrual, or whose interest for the month is posted, are skipped,
+// so running it again for the same day changes nothing. Closed accounts earn no interest.
+// It returns the number of accruals recorded
+func (store *SQLStore) AccrueInterest(ctx context.Context, day time.Time) (int64, error) {
+	day = day.UTC().Truncate(24 * time.Hour)
+	dayEnd := day.AddDate(0, 0, 1)
+
+	accounts, err := store.ListAccountsToAccrue(ctx, ListAccountsToAccrueParams{
+		DayEnd: dayEnd,
+		Day:    day,
+		Month:  startOfMonth(day),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var accrued int64
+	for _, account := range accounts {
+		balance, err := store.GetBalanceBefore(ctx, GetBalanceBeforeParams{
+			AccountID: account.ID,
+			Before:    dayEnd,
+		})
+		if err != nil {
+			return accrued, err
+		}
+		amount, err := util.DailyInterest(balance, account.AnnualRate, account.DayCount, day)
+		if err != nil {
+			return accrued, fmt.Errorf("account [%d]: %w", account.ID, err)
+		}
+
+		n, err := store.CreateInterestAccrual(ctx, CreateInterestAccrualParams{
+			AccountID:  account.ID,
+			Day:        day,
+			Balance:    balance,
+			AnnualRate: account.AnnualRate,
+			DayCount:   account.DayCount,
+			Amount:     amount,
+		})
+		if err != nil {
+			return accrued, err
+		}
+		accrued += n
+	}
+	return accrued, nil
+}
+
+// PostInterest pays the interest accrued in a month to up to limit accounts that haven't been paid for it yet.
+// The sum of the accruals is rounded and transferred through TransferTx from the interest expense account
+// of the bank in the currency of the account, with an idempotency key derived from the account and the month,
+// so a month is never paid twice. A posting is recorded even when the interest rounds to zero.
+// The interest of an account closed before it is posted is forfeited
+func (store *SQLStore) PostInterest(ctx context.Context, month time.Time, limit int32) ([]InterestPosting, error) {
+	month = startOfMonth(month)
+	var postings []InterestPosting
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		// start over if the transaction is retried
+		postings = nil
+
+		unposted, err := q.ListUnpostedInterest(ctx, ListUnpostedInterestParams{
+			Month:     month,
+			NextMonth: month.AddDate(0, 1, 0),
+			RowLimit:  limit,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, interest := range unposted {
+			posting, err := store.postInterest(ctx, q, month, interest)
+			if err == sql.ErrNoRows {
+				// posted by a concurrent run
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			postings = append(postings, posting)
+		}
+		return nil
+	})
+	return postings, err
+}
+
+// postInterest records the posting of the interest of an account for month and pays it
+func (store *SQLStore) postInterest(ctx context.Context, q *Queries, month time.Time, interest ListUnpostedInterestRow) (InterestPosting, error) {
+	amount, err := util.RoundAmount(interest.Amount)
+	if err != nil {
+		return InterestPosting{}, err
+	}
+
+	posting, err := q.CreateInterestPosting(ctx, CreateInterestPostingParams{
+		AccountID: interest.AccountID,
+		Month:     month,
+		Amount:    amount,
+	})
+	if err != nil || amount == 0 {
+		return posting, err
+	}
+
+	expense, err := store.bankAccount(ctx, ProductInterestExpense, interest.Currency)
+	if err != nil {
+		return posting, err
+	}
+
+	period := month.Format("2006-01")
+	idem := &IdempotencyParams{
+		Username:    BankUsername,
+		Key:         fmt.Sprintf("interest-%d-%s", interest.AccountID, period),
+		RequestHash: fmt.Sprintf("interest:%d:%s", interest.AccountID, period),
+	}
+	// the interest may have been paid on a previous run, but the posting wasn't recorded
+	result, err := store.transferOnce(ctx, q, TransferTxParams{
+		FromAccountID: expense.ID,
+		ToAccountID:   interest.AccountID,
+		Amount:        amount,
+		Idempotency:   idem,
+	})
+	if err != nil {
+		return posting, err
+	}
+
+	return q.SetInterestPostingTransfer(ctx, SetInterestPostingTransferParams{
+		ID:         posting.ID,
+		TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+	})
+}
+
+// startOfMonth returns midnight UTC of the first day of the month of t
+func startOfMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
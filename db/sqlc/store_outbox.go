@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// types of the events written to the outbox
+const (
+	EventTransferCompleted = "transfer.completed"
+	EventAccountCreated    = "account.created"
+	EventUserRegistered    = "user.registered"
+)
+
+// types of the aggregates the events are about. The events of one aggregate are published in order
+const (
+	AggregateTransfer = "transfer"
+	AggregateAccount  = "account"
+	AggregateUser     = "user"
+)
+
+// outboxRelayLockKey is the advisory lock taken by RelayOutboxEvents, so only one relay publishes at a time
+const outboxRelayLockKey = 7_001_001
+
+// UserRegisteredEvent is the payload of a user.registered event, the user without the password
+type UserRegisteredEvent struct {
+	Username  string    `json:"username"`
+	FullName  string    `json:"full_name"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// addOutboxEvent writes an event to the outbox, in the transaction of q,
+// so the event exists if and only if the change it is about is committed
+func addOutboxEvent(ctx context.Context, q *Queries, aggregateType string, aggregateID string, eventType string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.CreateOutboxEvent(ctx, CreateOutboxEventParams{
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		EventType:     eventType,
+		Payload:       body,
+	})
+	return err
+}
+
+// addTransferEvent writes the transfer.completed event of result to the outbox.
+// Every transaction that creates a transfer calls it, so no transfer misses its webhooks
+func addTransferEvent(ctx context.Context, q *Queries, result *TransferTxResult) error {
+	transferID := strconv.FormatInt(result.Transfer.ID, 10)
+	return addOutboxEvent(ctx, q, AggregateTransfer, transferID, EventTransferCompleted, result)
+}
+
+// CreateUserTxParams contains the input parametres of the create user transaction
+type CreateUserTxParams struct {
+	CreateUserParams
+	Audit *AuditParams `json:"-"`
+}
+
+// CreateUserTx creates a user, writes a user.registered event and, if requested, an audit log record
+func (store *SQLStore) CreateUserTx(ctx context.Context, arg CreateUserTxParams) (User, error) {
+	var user User
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		var err error
+
+		user, err = q.CreateUser(ctx, arg.CreateUserParams)
+		if err != nil {
+			return err
+		}
+
+		event := UserRegisteredEvent{
+			Username:  user.Username,
+			FullName:  user.FullName,
+			Email:     user.Email,
+			CreatedAt: user.CreatedAt,
+		}
+		err = addOutboxEvent(ctx, q, AggregateUser, user.Username, EventUserRegistered, event)
+		if err != nil {
+			return err
+		}
+		return addAuditLog(ctx, q, arg.Audit, "/users/"+user.Username)
+	})
+	return user, err
+}
+
+// RelayOutboxEvents hands up to limit pending events to publish in the order they were written
+// and marks the published ones. If an event can't be published, the later events of its aggregate
+// are left for the next run, so the events of an aggregate are never published out of order.
+// Delivery is at least once: an event is published again if it can't be marked, so consumers must
+// ignore events they have already seen by their id. Only one relay publishes at a time, the others
+// publish nothing until it is done
+func (store *SQLStore) RelayOutboxEvents(ctx context.Context, limit int32, publish func(context.Context, OutboxEvent) error) (int, error) {
+	var published int
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		// start over if the transaction is retried
+		published = 0
+
+		locked, err := q.TryAdvisoryXactLock(ctx, outboxRelayLockKey)
+		if err != nil || !locked {
+			return err
+		}
+
+		events, err := q.ListPendingOutboxEvents(ctx, limit)
+		if err != nil {
+			return err
+		}
+
+		blocked := map[string]bool{}
+		for _, event := range events {
+			aggregate := event.AggregateType + ":" + event.AggregateID
+			if blocked[aggregate] {
+				continue
+			}
+			if publish(ctx, event) != nil {
+				blocked[aggregate] = true
+				continue
+			}
+
+			err = q.MarkOutboxEventPublished(ctx, event.ID)
+			if err != nil {
+				return err
+			}
+			published++
+		}
+		return nil
+	})
+	return published, err
+}
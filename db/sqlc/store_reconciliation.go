@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ReconciliationReport lists every discrepancy between the balances, the entries and the transfers
+type ReconciliationReport struct {
+	CheckedAt time.Time `json:"checked_at"`
+	// accounts whose balance is not the sum of their entries
+	BalanceMismatches []ListBalanceMismatchesRow `json:"balance_mismatches"`
+	// transfers without exactly one debit and one credit entry of their amounts
+	TransferMismatches []ListTransferEntryMismatchesRow `json:"transfer_mismatches"`
+	// currencies whose entries don't net to zero once cross-currency transfers are accounted for
+	CurrencyImbalances []ListCurrencyImbalancesRow `json:"currency_imbalances"`
+}
+
+// Balanced reports whether no discrepancy was found
+func (report ReconciliationReport) Balanced() bool {
+	return len(report.BalanceMismatches) == 0 &&
+		len(report.TransferMismatches) == 0 &&
+		len(report.CurrencyImbalances) == 0
+}
+
+// Reconcile checks the ledger. All checks run in one read-only repeatable read transaction,
+// so they see the same snapshot and transfers made in the meantime don't show up as discrepancies
+func (store *SQLStore) Reconcile(ctx context.Context) (ReconciliationReport, error) {
+	report := ReconciliationReport{CheckedAt: time.Now()}
+
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	err := store.execTx(ctx, opts, func(q *Queries) error {
+		var err error
+
+		report.BalanceMismatches, err = q.ListBalanceMismatches(ctx)
+		if err != nil {
+			return fmt.Errorf("check balances: %w", err)
+		}
+		report.TransferMismatches, err = q.ListTransferEntryMismatches(ctx)
+		if err != nil {
+			return fmt.Errorf("check transfers: %w", err)
+		}
+		report.CurrencyImbalances, err = q.ListCurrencyImbalances(ctx)
+		if err != nil {
+			return fmt.Errorf("check currencies: %w", err)
+		}
+		return nil
+	})
+	return report, err
+}
@@ -0,0 +1,160 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// statuses of a scheduled transfer
+const (
+	ScheduledTransferPending   = "pending"
+	ScheduledTransferCompleted = "completed"
+	ScheduledTransferFailed    = "failed"
+	ScheduledTransferCanceled  = "canceled"
+)
+
+// CreateScheduledTransferTxParams contains the input parametres of the create scheduled transfer transaction
+type CreateScheduledTransferTxParams struct {
+	CreateScheduledTransferParams
+	Idempotency *IdempotencyParams `json:"-"`
+	// Fraud, if set, flags the transfer for review by an analyst
+	Fraud *FraudFlagParams `json:"-"`
+}
+
+// CreateScheduledTransferTx stores a transfer to execute later with its fraud flag, if any,
+// and, if requested, saves the response under the idempotency key
+func (store *SQLStore) CreateScheduledTransferTx(ctx context.Context, arg CreateScheduledTransferTxParams) (ScheduledTransfer, error) {
+	var scheduled ScheduledTransfer
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		var err error
+
+		scheduled, err = q.CreateScheduledTransfer(ctx, arg.CreateScheduledTransferParams)
+		if err != nil {
+			return err
+		}
+
+		err = addPendingFraudFlag(ctx, q, arg.Fraud, scheduled.FromAccountID, scheduled.ToAccountID, scheduled.Amount)
+		if err != nil {
+			return err
+		}
+
+		if arg.Idempotency != nil {
+			return saveIdempotentResponse(ctx, q, arg.Idempotency, scheduled)
+		}
+		return nil
+	})
+	return scheduled, err
+}
+
+// scheduledTransferLease is how long a claimed scheduled transfer is left to the worker that claimed it
+const scheduledTransferLease = 5 * time.Minute
+
+// ExecuteScheduledTransfers runs up to limit pending scheduled transfers that are due.
+// The rows are claimed for scheduledTransferLease in a statement of their own, so several workers
+// can run at the same time and no row lock or connection is held while the transfers are made.
+// Every transfer goes through TransferTx with an idempotency key derived from the scheduled transfer,
+// so it is never executed twice even if the status update is lost or the lease runs out
+func (store *SQLStore) ExecuteScheduledTransfers(ctx context.Context, limit int32) ([]ScheduledTransfer, error) {
+	due, err := store.ClaimDueScheduledTransfers(ctx, ClaimDueScheduledTransfersParams{
+		LeaseUntil: time.Now().Add(scheduledTransferLease),
+		Limit:      limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].ExecuteAt.Equal(due[j].ExecuteAt) {
+			return due[i].ExecuteAt.Before(due[j].ExecuteAt)
+		}
+		return due[i].ID < due[j].ID
+	})
+
+	var processed []ScheduledTransfer
+	for _, scheduled := range due {
+		scheduled, err = store.executeScheduledTransfer(ctx, scheduled)
+		if err != nil {
+			return processed, err
+		}
+		processed = append(processed, scheduled)
+	}
+	return processed, nil
+}
+
+// executeScheduledTransfer runs a claimed scheduled transfer and records the outcome on its row
+func (store *SQLStore) executeScheduledTransfer(ctx context.Context, scheduled ScheduledTransfer) (ScheduledTransfer, error) {
+	idem := &IdempotencyParams{
+		Username:    scheduled.Owner,
+		Key:         fmt.Sprintf("scheduled-transfer-%d", scheduled.ID),
+		RequestHash: fmt.Sprintf("scheduled-transfer:%d", scheduled.ID),
+	}
+
+	// the transfer may have been committed on a previous run, but its status wasn't
+	result, err := store.transferOnce(ctx, store.Queries, TransferTxParams{
+		FromAccountID: scheduled.FromAccountID,
+		ToAccountID:   scheduled.ToAccountID,
+		Amount:        scheduled.Amount,
+		Idempotency:   idem,
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return scheduled, ctx.Err()
+		}
+		return store.FailScheduledTransfer(ctx, FailScheduledTransferParams{
+			ID:            scheduled.ID,
+			FailureReason: err.Error(),
+		})
+	}
+
+	return store.CompleteScheduledTransfer(ctx, CompleteScheduledTransferParams{
+		ID:         scheduled.ID,
+		TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+	})
+}
+
+// transferOnce makes a transfer of a worker under its idempotency key, unless a previous run already made it.
+// The stored result is looked up before the transfer is attempted, so a transfer that was committed
+// is never failed by a check that no longer passes, such as the funds it took or the fraud rules.
+// The fraud rules run on the transfer as it is executed, for the user of the key.
+// A concurrent run that commits the transfer first is caught by the key
+func (store *SQLStore) transferOnce(ctx context.Context, q *Queries, arg TransferTxParams) (TransferTxResult, error) {
+	result, err := storedTransferResult(ctx, q, arg.Idempotency)
+	if err != sql.ErrNoRows {
+		return result, err
+	}
+
+	err = store.checkFraud(ctx, q, arg.Idempotency.Username, &arg)
+	if err != nil {
+		return result, err
+	}
+
+	result, err = store.TransferTx(ctx, arg)
+	if errors.Is(err, ErrDuplicateIdempotencyKey) {
+		result, err = storedTransferResult(ctx, q, arg.Idempotency)
+	}
+	return result, err
+}
+
+// storedTransferResult reads the result of a transfer saved under an idempotency key
+func storedTransferResult(ctx context.Context, q *Queries, idem *IdempotencyParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	stored, err := q.GetIdempotencyKey(ctx, GetIdempotencyKeyParams{
+		Username: idem.Username,
+		Key:      idem.Key,
+	})
+	if err != nil {
+		return result, err
+	}
+	if stored.RequestHash != idem.RequestHash {
+		return result, fmt.Errorf("idempotency key %q was used by another request", idem.Key)
+	}
+
+	err = json.Unmarshal(stored.ResponseBody, &result)
+	return result, err
+}
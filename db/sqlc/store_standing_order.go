@@ -0,0 +1,127 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"simplebank/util"
+)
+
+// statuses of a standing order
+const (
+	StandingOrderActive   = "active"
+	StandingOrderCanceled = "canceled"
+	StandingOrderFinished = "finished"
+)
+
+// statuses of a standing order run
+const (
+	StandingOrderRunCompleted = "completed"
+	StandingOrderRunFailed    = "failed"
+)
+
+// CreateStandingOrderTxParams contains the input parametres of the create standing order transaction
+type CreateStandingOrderTxParams struct {
+	CreateStandingOrderParams
+	// Fraud, if set, flags the transfers of the order for review by an analyst
+	Fraud *FraudFlagParams `json:"-"`
+}
+
+// CreateStandingOrderTx stores a standing order with its fraud flag, if any
+func (store *SQLStore) CreateStandingOrderTx(ctx context.Context, arg CreateStandingOrderTxParams) (StandingOrder, error) {
+	var order StandingOrder
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		var err error
+
+		order, err = q.CreateStandingOrder(ctx, arg.CreateStandingOrderParams)
+		if err != nil {
+			return err
+		}
+
+		return addPendingFraudFlag(ctx, q, arg.Fraud, order.FromAccountID, order.ToAccountID, order.Amount)
+	})
+	return order, err
+}
+
+// ExecuteStandingOrders makes one run of up to limit active standing orders that are due
+// and moves each of them to its next period. The orders are locked with FOR UPDATE SKIP LOCKED,
+// so several workers can run at the same time. The transfer of a run is made through TransferTx
+// with an idempotency key derived from the order and the period, so a period is never paid twice
+func (store *SQLStore) ExecuteStandingOrders(ctx context.Context, limit int32) ([]StandingOrderRun, error) {
+	var runs []StandingOrderRun
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		// start over if the transaction is retried
+		runs = nil
+
+		due, err := q.ListDueStandingOrders(ctx, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, order := range due {
+			run, err := store.executeStandingOrder(ctx, q, order)
+			if err != nil {
+				return err
+			}
+			runs = append(runs, run)
+		}
+		return nil
+	})
+	return runs, err
+}
+
+// executeStandingOrder pays the current period of a locked standing order and schedules the next one
+func (store *SQLStore) executeStandingOrder(ctx context.Context, q *Queries, order StandingOrder) (StandingOrderRun, error) {
+	period := order.NextRunAt.UTC().Format("2006-01-02T15:04:05Z")
+	idem := &IdempotencyParams{
+		Username:    order.Owner,
+		Key:         fmt.Sprintf("standing-order-%d-%s", order.ID, period),
+		RequestHash: fmt.Sprintf("standing-order:%d:%s", order.ID, period),
+	}
+
+	// the period may have been paid on a previous run, but the run wasn't recorded
+	result, err := store.transferOnce(ctx, q, TransferTxParams{
+		FromAccountID: order.FromAccountID,
+		ToAccountID:   order.ToAccountID,
+		Amount:        order.Amount,
+		Idempotency:   idem,
+	})
+
+	arg := CreateStandingOrderRunParams{
+		StandingOrderID: order.ID,
+		RunAt:           order.NextRunAt,
+		Status:          StandingOrderRunCompleted,
+		TransferID:      sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return StandingOrderRun{}, ctx.Err()
+		}
+		arg.Status = StandingOrderRunFailed
+		arg.TransferID = sql.NullInt64{}
+		arg.FailureReason = err.Error()
+	}
+
+	run, err := q.CreateStandingOrderRun(ctx, arg)
+	if err != nil {
+		return run, err
+	}
+
+	next, err := util.NextRun(order.Frequency, int(order.DayOfMonth), order.NextRunAt)
+	if err != nil {
+		return run, err
+	}
+	status := StandingOrderActive
+	if order.EndAt.Valid && next.After(order.EndAt.Time) {
+		status = StandingOrderFinished
+	}
+
+	_, err = q.UpdateStandingOrderSchedule(ctx, UpdateStandingOrderScheduleParams{
+		ID:        order.ID,
+		NextRunAt: next,
+		Status:    status,
+	})
+	return run, err
+}
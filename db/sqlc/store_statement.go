@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// statementPageSize is how many entries are read from the database at a time while exporting a statement
+const statementPageSize = 500
+
+// ExportStatementParams contains the input parametres of a statement export
+type ExportStatementParams struct {
+	Account Account
+	// From is included, To is not
+	From time.Time
+	To   time.Time
+}
+
+// StatementSummary describes a statement before its entries are written
+type StatementSummary struct {
+	Account        Account
+	From           time.Time
+	To             time.Time
+	OpeningBalance int64
+	ClosingBalance int64
+}
+
+// StatementEntry is an entry of the account with its transfer.
+// CounterpartyAccountID is zero for an entry without a transfer
+type StatementEntry struct {
+	ListStatementEntriesRow
+	// Balance after the entry
+	Balance int64
+}
+
+// StatementWriter writes a statement in some format as it is read from the database
+type StatementWriter interface {
+	Begin(summary StatementSummary) error
+	Entry(entry StatementEntry) error
+	End() error
+}
+
+// ExportStatement writes the entries of an account between From and To, oldest first.
+// Entries are read page by page within one read-only repeatable read transaction,
+// so the balances and the entries are taken from the same snapshot however long the export takes.
+// A read-only transaction never fails with a serialization failure, so execTx doesn't run it twice
+// and the statement is written once
+func (store *SQLStore) ExportStatement(ctx context.Context, arg ExportStatementParams, w StatementWriter) error {
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	return store.execTx(ctx, opts, func(q *Queries) error {
+		var err error
+
+		summary := StatementSummary{
+			Account: arg.Account,
+			From:    arg.From,
+			To:      arg.To,
+		}
+		summary.OpeningBalance, err = q.GetBalanceBefore(ctx, GetBalanceBeforeParams{
+			AccountID: arg.Account.ID,
+			Before:    arg.From,
+		})
+		if err != nil {
+			return err
+		}
+		summary.ClosingBalance, err = q.GetBalanceBefore(ctx, GetBalanceBeforeParams{
+			AccountID: arg.Account.ID,
+			Before:    arg.To,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = w.Begin(summary)
+		if err != nil {
+			return err
+		}
+
+		balance := summary.OpeningBalance
+		var afterID int64
+		for {
+			rows, err := q.ListStatementEntries(ctx, ListStatementEntriesParams{
+				AccountID: arg.Account.ID,
+				FromTime:  arg.From,
+				ToTime:    arg.To,
+				AfterID:   afterID,
+				RowLimit:  statementPageSize,
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, row := range rows {
+				balance += row.Amount
+				err = w.Entry(StatementEntry{
+					ListStatementEntriesRow: row,
+					Balance:                 balance,
+				})
+				if err != nil {
+					return err
+				}
+				afterID = row.ID
+			}
+			if len(rows) < statementPageSize {
+				break
+			}
+		}
+
+		return w.End()
+	})
+}
@@ -2,7 +2,10 @@ package db
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"simplebank/util"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -11,7 +14,7 @@ import (
 func TestTransferTx(t *testing.T) {
 	store := NewStore(testDB)
 
-	account1 := createRandomAccount(t)
+	account1 := createRandomAccountWithBalance(t, 1000)
 	account2 := createRandomAccount(t)
 
 	fmt.Println(">> before: ", account1.Balance, account2.Balance)
@@ -116,8 +119,8 @@ func TestTransferTx(t *testing.T) {
 func TestTransferTxDeadlock(t *testing.T) {
 	store := NewStore(testDB)
 
-	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createRandomAccountWithBalance(t, 1000)
 
 	fmt.Println(">> before: ", account1.Balance, account2.Balance)
 	// start n concurrenct transfer transaction
@@ -163,3 +166,198 @@ func TestTransferTxDeadlock(t *testing.T) {
 	require.Equal(t, account1.Balance, updatedAccount1.Balance)
 	require.Equal(t, account2.Balance, updatedAccount2.Balance)
 }
+
+func TestTransferTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 10)
+	account2 := createRandomAccount(t)
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        11,
+	})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrInsufficientFunds))
+
+	// the whole transaction must be rolled back
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+
+	updatedAccount2, err := store.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+	require.Equal(t, account2.Balance, updatedAccount2.Balance)
+}
+
+func TestTransferTxOverdraftLimit(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 10)
+	account2 := createRandomAccount(t)
+
+	account1, err := store.UpdateAccountOverdraftLimit(context.Background(), UpdateAccountOverdraftLimitParams{
+		ID:             account1.ID,
+		OverdraftLimit: 50,
+	})
+	require.NoError(t, err)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        60,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(-50), result.FromAccount.Balance)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+	})
+	require.True(t, errors.Is(err, ErrInsufficientFunds))
+}
+
+func TestTransferTxIdempotency(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createRandomAccount(t)
+
+	idem := &IdempotencyParams{
+		Username:    account1.Owner,
+		Key:         util.RandomString(16),
+		RequestHash: util.RandomString(64),
+	}
+	arg := TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		Idempotency:   idem,
+	}
+
+	result, err := store.TransferTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	stored, err := store.GetIdempotencyKey(context.Background(), GetIdempotencyKeyParams{
+		Username: idem.Username,
+		Key:      idem.Key,
+	})
+	require.NoError(t, err)
+	require.Equal(t, idem.RequestHash, stored.RequestHash)
+
+	var storedResult TransferTxResult
+	err = json.Unmarshal(stored.ResponseBody, &storedResult)
+	require.NoError(t, err)
+	require.Equal(t, result.Transfer.ID, storedResult.Transfer.ID)
+
+	// a second transfer with the same key must not move money again
+	_, err = store.TransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrDuplicateIdempotencyKey)
+
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-10, updatedAccount1.Balance)
+}
+
+func TestCreateAccountTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	idem := &IdempotencyParams{
+		Username:    user.Username,
+		Key:         util.RandomString(16),
+		RequestHash: util.RandomString(64),
+	}
+	account, err := store.CreateAccountTx(context.Background(), CreateAccountTxParams{
+		CreateAccountParams: CreateAccountParams{
+			Owner:    user.Username,
+			Balance:  0,
+			Currency: util.RandomCurrency(),
+			Product:  ProductCurrent,
+		},
+		Idempotency: idem,
+	})
+	require.NoError(t, err)
+	require.NotZero(t, account.ID)
+
+	stored, err := store.GetIdempotencyKey(context.Background(), GetIdempotencyKeyParams{
+		Username: idem.Username,
+		Key:      idem.Key,
+	})
+	require.NoError(t, err)
+
+	var storedAccount Account
+	err = json.Unmarshal(stored.ResponseBody, &storedAccount)
+	require.NoError(t, err)
+	require.Equal(t, account.ID, storedAccount.ID)
+}
+
+func createRandomAccountWithCurrency(t *testing.T, balance int64, currency string) Account {
+	account := createRandomAccountWithBalance(t, balance)
+	if account.Currency == currency {
+		return account
+	}
+
+	// the owner has no other account, so the currency can be swapped safely
+	_, err := testDB.Exec("UPDATE accounts SET currency = $1 WHERE id = $2", currency, account.ID)
+	require.NoError(t, err)
+	account.Currency = currency
+	return account
+}
+
+func TestTransferTxCrossCurrency(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithCurrency(t, 1000, util.USD)
+	account2 := createRandomAccountWithCurrency(t, 1000, util.EUR)
+
+	rate, err := store.CreateFxRate(context.Background(), CreateFxRateParams{
+		FromCurrency: util.USD,
+		ToCurrency:   util.EUR,
+		Rate:         "0.9",
+	})
+	require.NoError(t, err)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+		FxRateID:      rate.ID,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, int64(100), result.Transfer.Amount)
+	require.Equal(t, int64(90), result.Transfer.ToAmount)
+	require.Equal(t, rate.Rate, result.Transfer.FxRate)
+	require.Equal(t, int64(-100), result.FromEntry.Amount)
+	require.Equal(t, int64(90), result.ToEntry.Amount)
+	require.Equal(t, account1.Balance-100, result.FromAccount.Balance)
+	require.Equal(t, account2.Balance+90, result.ToAccount.Balance)
+
+	// a newer rate makes the old quote stale
+	_, err = store.CreateFxRate(context.Background(), CreateFxRateParams{
+		FromCurrency: util.USD,
+		ToCurrency:   util.EUR,
+		Rate:         "0.91",
+	})
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+		FxRateID:      rate.ID,
+	})
+	require.ErrorIs(t, err, ErrInvalidFxRate)
+
+	// the rate must match the account currencies
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account1.ID,
+		Amount:        100,
+		FxRateID:      rate.ID,
+	})
+	require.ErrorIs(t, err, ErrInvalidFxRate)
+}
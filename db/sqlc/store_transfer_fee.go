@@ -0,0 +1,192 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"simplebank/util"
+)
+
+// transferFee returns the fee for a transfer of amount between two accounts, by the most specific rule
+// of the fee schedule: a rule for the currency of the source account wins over a rule for any currency,
+// then a rule for own or other accounts wins over a rule for any transfer.
+// Transfers without a matching rule and transfers out of the accounts of the bank are free
+func transferFee(ctx context.Context, q *Queries, from, to Account, amount int64) (int64, error) {
+	if from.Owner == BankUsername {
+		return 0, nil
+	}
+
+	scope := util.FeeScopeOther
+	if from.Owner == to.Owner {
+		scope = util.FeeScopeOwn
+	}
+	rule, err := q.GetTransferFee(ctx, GetTransferFeeParams{
+		Currency: from.Currency,
+		Scope:    scope,
+	})
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return util.TransferFee(amount, rule.FlatFee, rule.Rate, rule.MinFee, rule.MaxFee)
+}
+
+// chargeFee moves the fee of result.Transfer from the source account to the fee income account of the bank
+// in the currency of the source account. The fee is a transfer of its own, so every transfer keeps exactly two entries,
+// and it is linked to result.Transfer for a reversal to refund it. A fee without a transfer, when closing an account
+// takes its whole balance as the fee, isn't linked
+func (store *SQLStore) chargeFee(ctx context.Context, q *Queries, result *TransferTxResult, fee int64) error {
+	income, err := store.bankAccount(ctx, ProductFeeIncome, result.FromAccount.Currency)
+	if err != nil {
+		return err
+	}
+
+	feeTransfer, err := q.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID: result.FromAccount.ID,
+		ToAccountID:   income.ID,
+		Amount:        fee,
+		ToAmount:      fee,
+		FxRate:        "1",
+	})
+	if err != nil {
+		return err
+	}
+
+	// the source account is already locked by the transfer and the fee income account is always locked last,
+	// so charging fees can't deadlock
+	result.FromAccount, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
+		ID:     feeTransfer.FromAccountID,
+		Amount: -fee,
+	})
+	if err != nil {
+		return err
+	}
+	err = checkStatus(result.FromAccount, -fee)
+	if err != nil {
+		return err
+	}
+	_, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
+		ID:     feeTransfer.ToAccountID,
+		Amount: fee,
+	})
+	if err != nil {
+		return err
+	}
+
+	// like moveMoney, the entries are created once both accounts are locked
+	feeEntry, err := q.CreateEntry(ctx, CreateEntryParams{
+		AccountID:  feeTransfer.FromAccountID,
+		Amount:     -fee,
+		TransferID: sql.NullInt64{Int64: feeTransfer.ID, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID:  feeTransfer.ToAccountID,
+		Amount:     fee,
+		TransferID: sql.NullInt64{Int64: feeTransfer.ID, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if result.Transfer.ID != 0 {
+		_, err = q.CreateTransferFeeCharge(ctx, CreateTransferFeeChargeParams{
+			TransferID:    result.Transfer.ID,
+			FeeTransferID: feeTransfer.ID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	result.Fee = fee
+	result.FeeTransfer = &feeTransfer
+	result.FeeEntry = &feeEntry
+	return nil
+}
+
+// refundFee gives back to the sender of original the share of its fee that a reversal takes back:
+// the fee times the part of the amount credited by original that is reversed, counting earlier reversals.
+// Like the amount credited back, the running total is rounded down, and reversing what is left
+// of a transfer refunds what is left of its fee. The refund is recorded as a reversal of the fee transfer
+func refundFee(ctx context.Context, q *Queries, result *ReverseTransferTxResult, original Transfer, reversed, amount int64, reversedBy string) error {
+	charge, err := q.GetTransferFeeCharge(ctx, original.ID)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	feeTransfer, err := q.GetTransferForUpdate(ctx, charge.FeeTransferID)
+	if err != nil {
+		return err
+	}
+	refunded, err := q.GetReversedAmounts(ctx, feeTransfer.ID)
+	if err != nil {
+		return err
+	}
+
+	refund := util.ProRata(feeTransfer.Amount, reversed+amount, original.ToAmount) - refunded.Amount
+	if refund <= 0 {
+		return nil
+	}
+
+	refundTransfer, err := q.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID: feeTransfer.ToAccountID,
+		ToAccountID:   feeTransfer.FromAccountID,
+		Amount:        refund,
+		ToAmount:      refund,
+		FxRate:        "1",
+	})
+	if err != nil {
+		return err
+	}
+	_, err = q.CreateTransferReversal(ctx, CreateTransferReversalParams{
+		ReversalID: refundTransfer.ID,
+		TransferID: feeTransfer.ID,
+		ReversedBy: reversedBy,
+	})
+	if err != nil {
+		return err
+	}
+
+	// the sender is already locked by the reversal and the fee income account is locked last, like chargeFee
+	result.ToAccount, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
+		ID:     refundTransfer.ToAccountID,
+		Amount: refund,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
+		ID:     refundTransfer.FromAccountID,
+		Amount: -refund,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID:  refundTransfer.FromAccountID,
+		Amount:     -refund,
+		TransferID: sql.NullInt64{Int64: refundTransfer.ID, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	refundEntry, err := q.CreateEntry(ctx, CreateEntryParams{
+		AccountID:  refundTransfer.ToAccountID,
+		Amount:     refund,
+		TransferID: sql.NullInt64{Int64: refundTransfer.ID, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+
+	result.FeeRefund = refund
+	result.FeeRefundTransfer = &refundTransfer
+	result.FeeRefundEntry = &refundEntry
+	return nil
+}
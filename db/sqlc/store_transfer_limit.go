@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTransferLimitExceeded is returned when a transfer would take the outgoing transfers of a user
+// in one currency over the daily or monthly limit
+var ErrTransferLimitExceeded = errors.New("transfer limit exceeded")
+
+// TransferLimits caps the sum of the outgoing transfers of a user in one currency.
+// Zero means no limit
+type TransferLimits struct {
+	Daily   int64
+	Monthly int64
+}
+
+// checkTransferLimits fails with ErrTransferLimitExceeded if amount, taken from an account, doesn't fit
+// into what is left of the daily or monthly limit of its owner. The limits of the store apply unless the owner
+// has an override in transfer_limits, and the accounts of the bank have none. Windows are calendar days and months in UTC.
+// The user row is locked first, so concurrent transfers of the same user are counted one after another
+func (store *SQLStore) checkTransferLimits(ctx context.Context, q *Queries, accountID int64, amount int64) error {
+	// the owner and the currency of an account never change, so it doesn't need to be locked yet
+	account, err := q.GetAccount(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	if account.Owner == BankUsername {
+		return nil
+	}
+
+	_, err = q.GetUserForUpdate(ctx, account.Owner)
+	if err != nil {
+		return err
+	}
+
+	limits := store.limits
+	override, err := q.GetTransferLimit(ctx, GetTransferLimitParams{
+		Username: account.Owner,
+		Currency: account.Currency,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if override.DailyLimit.Valid {
+		limits.Daily = override.DailyLimit.Int64
+	}
+	if override.MonthlyLimit.Valid {
+		limits.Monthly = override.MonthlyLimit.Int64
+	}
+
+	now := time.Now().UTC()
+	windows := []struct {
+		name  string
+		limit int64
+		since time.Time
+	}{
+		{"daily", limits.Daily, time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)},
+		{"monthly", limits.Monthly, time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, window := range windows {
+		if window.limit == 0 {
+			continue
+		}
+
+		total, err := q.GetOutgoingTransferTotal(ctx, GetOutgoingTransferTotalParams{
+			Owner:     account.Owner,
+			Currency:  account.Currency,
+			CreatedAt: window.since,
+		})
+		if err != nil {
+			return err
+		}
+		if total+amount > window.limit {
+			remaining := window.limit - total
+			if remaining < 0 {
+				remaining = 0
+			}
+			return fmt.Errorf("%w: %s limit is %d %s, %d remaining, amount %d",
+				ErrTransferLimitExceeded, window.name, window.limit, account.Currency, remaining, amount)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"simplebank/util"
+)
+
+// ReverseTransferTxParams contains the input parametres of the reverse transfer transaction
+type ReverseTransferTxParams struct {
+	TransferID int64 `json:"transfer_id"`
+	// Amount to take back from the recipient, in its currency. Zero reverses what is left of the transfer
+	Amount     int64  `json:"amount"`
+	ReversedBy string `json:"reversed_by"`
+}
+
+// ReverseTransferTxResult is the result of the reverse transfer transaction
+type ReverseTransferTxResult struct {
+	TransferTxResult
+	ReversalOf int64 `json:"reversal_of"`
+	// FeeRefund is the share of the fee of the transfer given back to its sender, in the currency of the sender
+	FeeRefund         int64     `json:"fee_refund"`
+	FeeRefundTransfer *Transfer `json:"fee_refund_transfer,omitempty"`
+	FeeRefundEntry    *Entry    `json:"fee_refund_entry,omitempty"`
+}
+
+// ReverseTransferTx moves money back from the recipient of a transfer to its sender
+// with a compensating transfer linked to the original one, and writes its transfer.completed event.
+// A transfer can be reversed in parts, but never beyond its amount, and a reversal can't be reversed.
+// The sender is credited in proportion to the original transfer, so a cross-currency transfer
+// is reversed at its original rate, and gets back the same share of the fee it was charged.
+// A reversal itself is free
+func (store *SQLStore) ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error) {
+	result := ReverseTransferTxResult{ReversalOf: arg.TransferID}
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		// start over if the transaction is retried
+		result = ReverseTransferTxResult{ReversalOf: arg.TransferID}
+
+		// locking the original transfer serializes concurrent reversals of it
+		original, err := q.GetTransferForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.GetTransferReversal(ctx, original.ID)
+		if err == nil {
+			return fmt.Errorf("%w: transfer [%d] is a reversal", ErrInvalidReversal, original.ID)
+		}
+		if err != sql.ErrNoRows {
+			return err
+		}
+
+		reversed, err := q.GetReversedAmounts(ctx, original.ID)
+		if err != nil {
+			return err
+		}
+		left := original.ToAmount - reversed.Amount
+		amount := arg.Amount
+		if amount == 0 {
+			amount = left
+		}
+		if amount <= 0 || amount > left {
+			return fmt.Errorf("%w: transfer [%d] has %d left to reverse, requested %d", ErrInvalidReversal, original.ID, left, amount)
+		}
+
+		// rounding down the running total never credits the sender more than it paid.
+		// Both amounts of the original are in minor units of their own currency, so the
+		// pro rata share needs no rescaling, unlike a conversion with the per major unit rate
+		toAmount := util.ProRata(original.Amount, reversed.Amount+amount, original.ToAmount) - reversed.ToAmount
+		fxRate, err := util.InverseFxRate(original.FxRate)
+		if err != nil {
+			return err
+		}
+
+		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+			FromAccountID: original.ToAccountID,
+			ToAccountID:   original.FromAccountID,
+			Amount:        amount,
+			ToAmount:      toAmount,
+			FxRate:        fxRate,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateTransferReversal(ctx, CreateTransferReversalParams{
+			ReversalID: result.Transfer.ID,
+			TransferID: original.ID,
+			ReversedBy: arg.ReversedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = moveMoney(ctx, q, &result.TransferTxResult)
+		if err != nil {
+			return err
+		}
+		err = checkFunds(result.FromAccount, amount)
+		if err != nil {
+			return err
+		}
+		err = refundFee(ctx, q, &result, original, reversed.Amount, amount, arg.ReversedBy)
+		if err != nil {
+			return err
+		}
+		err = notifyAccountEvents(ctx, q, &result.TransferTxResult)
+		if err != nil {
+			return err
+		}
+		if result.FeeRefundEntry != nil {
+			err = notifyAccountEvent(ctx, q, result.ToAccount, result.FeeRefundEntry)
+			if err != nil {
+				return err
+			}
+		}
+		return addTransferEvent(ctx, q, &result.TransferTxResult)
+	})
+	return result, err
+}
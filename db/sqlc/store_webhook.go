@@ -0,0 +1,56 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// statuses of a webhook delivery
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// RecordWebhookAttemptTxParams is the outcome of an attempt to deliver a webhook
+type RecordWebhookAttemptTxParams struct {
+	DeliveryID int64
+	// StatusCode of the response, 0 if there was none
+	StatusCode int32
+	Error      string
+	// Status of the delivery after the attempt, pending if it is retried at NextAttemptAt
+	Status        string
+	NextAttemptAt time.Time
+}
+
+// RecordWebhookAttemptTx stores an attempt to deliver a webhook and updates the delivery with its outcome
+func (store *SQLStore) RecordWebhookAttemptTx(ctx context.Context, arg RecordWebhookAttemptTxParams) (WebhookDelivery, error) {
+	var delivery WebhookDelivery
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		_, err := q.CreateWebhookAttempt(ctx, CreateWebhookAttemptParams{
+			DeliveryID: arg.DeliveryID,
+			StatusCode: arg.StatusCode,
+			Error:      arg.Error,
+		})
+		if err != nil {
+			return err
+		}
+
+		var deliveredAt sql.NullTime
+		if arg.Status == WebhookDeliveryDelivered {
+			deliveredAt = sql.NullTime{Time: time.Now(), Valid: true}
+		}
+		delivery, err = q.UpdateWebhookDeliveryAttempt(ctx, UpdateWebhookDeliveryAttemptParams{
+			ID:             arg.DeliveryID,
+			Status:         arg.Status,
+			NextAttemptAt:  arg.NextAttemptAt,
+			LastStatusCode: arg.StatusCode,
+			LastError:      arg.Error,
+			DeliveredAt:    deliveredAt,
+		})
+		return err
+	})
+	return delivery, err
+}
@@ -0,0 +1,264 @@
+package db
+
+import (
+	"context"
+	"simplebank/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// addTransferFee adds a rule to the fee schedule for the duration of the test
+func addTransferFee(t *testing.T, arg SetTransferFeeParams) TransferFee {
+	fee, err := testQueries.SetTransferFee(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Currency, fee.Currency)
+	require.Equal(t, arg.Scope, fee.Scope)
+	require.Equal(t, arg.FlatFee, fee.FlatFee)
+	require.Equal(t, arg.MinFee, fee.MinFee)
+	require.Equal(t, arg.MaxFee, fee.MaxFee)
+
+	t.Cleanup(func() {
+		_, err := testQueries.DeleteTransferFee(context.Background(), DeleteTransferFeeParams{
+			Currency: arg.Currency,
+			Scope:    arg.Scope,
+		})
+		require.NoError(t, err)
+	})
+	return fee
+}
+
+func TestTransferFeeRules(t *testing.T) {
+	addTransferFee(t, SetTransferFeeParams{Currency: "", Scope: util.FeeScopeAny, FlatFee: 1, Rate: "0"})
+	addTransferFee(t, SetTransferFeeParams{Currency: util.EUR, Scope: util.FeeScopeAny, FlatFee: 2, Rate: "0"})
+	addTransferFee(t, SetTransferFeeParams{Currency: util.EUR, Scope: util.FeeScopeOwn, FlatFee: 3, Rate: "0"})
+
+	testCases := []struct {
+		name string
+		from Account
+		to   Account
+		fee  int64
+	}{
+		{
+			name: "CurrencyAndScope",
+			from: Account{Owner: "alice", Currency: util.EUR},
+			to:   Account{Owner: "alice", Currency: util.EUR},
+			fee:  3,
+		},
+		{
+			name: "Currency",
+			from: Account{Owner: "alice", Currency: util.EUR},
+			to:   Account{Owner: "bob", Currency: util.EUR},
+			fee:  2,
+		},
+		{
+			name: "AnyCurrency",
+			from: Account{Owner: "alice", Currency: util.USD},
+			to:   Account{Owner: "alice", Currency: util.USD},
+			fee:  1,
+		},
+		{
+			name: "Bank",
+			from: Account{Owner: BankUsername, Currency: util.EUR},
+			to:   Account{Owner: "bob", Currency: util.EUR},
+			fee:  0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fee, err := transferFee(context.Background(), testQueries, tc.from, tc.to, 1000)
+			require.NoError(t, err)
+			require.Equal(t, tc.fee, fee)
+		})
+	}
+}
+
+func TestTransferTxFee(t *testing.T) {
+	store := NewStore(testDB)
+	addTransferFee(t, SetTransferFeeParams{Currency: util.USD, Scope: util.FeeScopeOther, FlatFee: 10, Rate: "0.01"})
+
+	account1 := createRandomAccountWithCurrency(t, 1000, util.USD)
+	account2 := createRandomAccountWithCurrency(t, 1000, util.USD)
+
+	incomeBalance := func() int64 {
+		income, err := testQueries.GetAccountByProduct(context.Background(), GetAccountByProductParams{
+			Owner:    BankUsername,
+			Currency: util.USD,
+			Product:  ProductFeeIncome,
+		})
+		if err != nil {
+			return 0
+		}
+		return income.Balance
+	}
+	before := incomeBalance()
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        500,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, int64(15), result.Fee)
+	require.Equal(t, int64(1000-500-15), result.FromAccount.Balance)
+	require.Equal(t, int64(1000+500), result.ToAccount.Balance)
+	require.Equal(t, int64(-500), result.FromEntry.Amount)
+
+	require.NotNil(t, result.FeeTransfer)
+	require.Equal(t, account1.ID, result.FeeTransfer.FromAccountID)
+	require.Equal(t, int64(15), result.FeeTransfer.Amount)
+	require.NotNil(t, result.FeeEntry)
+	require.Equal(t, account1.ID, result.FeeEntry.AccountID)
+	require.Equal(t, int64(-15), result.FeeEntry.Amount)
+	require.Equal(t, result.FeeTransfer.ID, result.FeeEntry.TransferID.Int64)
+
+	income, err := testQueries.GetAccount(context.Background(), result.FeeTransfer.ToAccountID)
+	require.NoError(t, err)
+	require.Equal(t, BankUsername, income.Owner)
+	require.Equal(t, ProductFeeIncome, income.Product)
+	require.Equal(t, util.USD, income.Currency)
+	require.Equal(t, before+15, incomeBalance())
+
+	// the fee counts against the funds, but not against the transfer limits
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        480,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	total, err := testQueries.GetOutgoingTransferTotal(context.Background(), GetOutgoingTransferTotalParams{
+		Owner:     account1.Owner,
+		Currency:  util.USD,
+		CreatedAt: result.Transfer.CreatedAt.Add(-time.Second),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(500), total)
+}
+
+func TestBatchTransferTxFee(t *testing.T) {
+	store := NewStore(testDB)
+	addTransferFee(t, SetTransferFeeParams{Currency: util.USD, Scope: util.FeeScopeOther, FlatFee: 10, Rate: "0.01"})
+
+	account1 := createRandomAccountWithCurrency(t, 1000, util.USD)
+	account2 := createRandomAccountWithCurrency(t, 0, util.USD)
+	account3 := createRandomAccountWithCurrency(t, 0, util.USD)
+
+	result, err := store.BatchTransferTx(context.Background(), BatchTransferTxParams{
+		FromAccountID: account1.ID,
+		Legs: []BatchTransferLeg{
+			{ToAccountID: account2.ID, Amount: 100},
+			{ToAccountID: account3.ID, Amount: 300},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int64{11, 13}, result.Fees)
+	require.Equal(t, int64(1000-400-24), result.FromAccount.Balance)
+
+	for i, transfer := range result.Transfers {
+		charge, err := testQueries.GetTransferFeeCharge(context.Background(), transfer.ID)
+		require.NoError(t, err)
+		feeTransfer, err := testQueries.GetTransfer(context.Background(), charge.FeeTransferID)
+		require.NoError(t, err)
+		require.Equal(t, account1.ID, feeTransfer.FromAccountID)
+		require.Equal(t, result.Fees[i], feeTransfer.Amount)
+	}
+
+	// the fees count against the funds
+	_, err = store.BatchTransferTx(context.Background(), BatchTransferTxParams{
+		FromAccountID: account1.ID,
+		Legs: []BatchTransferLeg{
+			{ToAccountID: account2.ID, Amount: 280},
+			{ToAccountID: account3.ID, Amount: 280},
+		},
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestCloseAccountSweepFee(t *testing.T) {
+	store := NewStore(testDB)
+	addTransferFee(t, SetTransferFeeParams{Currency: util.USD, Scope: util.FeeScopeOwn, FlatFee: 5, Rate: "0"})
+
+	account := createRandomAccountWithCurrency(t, 100, util.USD)
+	second := createSecondAccount(t, account)
+
+	result, err := store.ChangeAccountStatusTx(context.Background(), ChangeAccountStatusTxParams{
+		AccountID:      account.ID,
+		Status:         util.AccountClosed,
+		SweepAccountID: second.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.AccountClosed, result.Account.Status)
+	require.Zero(t, result.Account.Balance)
+	require.NotNil(t, result.Sweep)
+	require.Equal(t, int64(95), result.Sweep.Transfer.Amount)
+	require.Equal(t, int64(95), result.Sweep.ToAccount.Balance)
+	require.Equal(t, int64(5), result.Sweep.Fee)
+	require.NotNil(t, result.Sweep.FeeTransfer)
+
+	// a balance that doesn't cover the fee is taken as the fee
+	account = createRandomAccountWithCurrency(t, 3, util.USD)
+	second = createSecondAccount(t, account)
+
+	result, err = store.ChangeAccountStatusTx(context.Background(), ChangeAccountStatusTxParams{
+		AccountID:      account.ID,
+		Status:         util.AccountClosed,
+		SweepAccountID: second.ID,
+	})
+	require.NoError(t, err)
+	require.Zero(t, result.Account.Balance)
+	require.NotNil(t, result.Sweep)
+	require.Zero(t, result.Sweep.Transfer.ID)
+	require.Equal(t, int64(3), result.Sweep.Fee)
+
+	updated, err := store.GetAccount(context.Background(), second.ID)
+	require.NoError(t, err)
+	require.Zero(t, updated.Balance)
+}
+
+func TestReverseTransferTxRefundsFee(t *testing.T) {
+	store := NewStore(testDB)
+	addTransferFee(t, SetTransferFeeParams{Currency: util.USD, Scope: util.FeeScopeOther, FlatFee: 0, Rate: "0.05"})
+
+	account1 := createRandomAccountWithCurrency(t, 1000, util.USD)
+	account2 := createRandomAccountWithCurrency(t, 1000, util.USD)
+
+	transfer, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        300,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(15), transfer.Fee)
+
+	// a third of the transfer gets a third of the fee back
+	result, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		Amount:     100,
+		ReversedBy: account2.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(5), result.FeeRefund)
+	require.NotNil(t, result.FeeRefundTransfer)
+	require.Equal(t, account1.ID, result.FeeRefundTransfer.ToAccountID)
+	require.NotNil(t, result.FeeRefundEntry)
+	require.Equal(t, int64(5), result.FeeRefundEntry.Amount)
+	require.Equal(t, int64(1000-300-15+100+5), result.ToAccount.Balance)
+
+	// the rest gets the rest of the fee back, and the sender is where it started
+	result, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		ReversedBy: account2.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(10), result.FeeRefund)
+	require.Equal(t, account1.Balance, result.ToAccount.Balance)
+	require.Equal(t, account2.Balance, result.FromAccount.Balance)
+
+	refunded, err := testQueries.GetReversedAmounts(context.Background(), transfer.FeeTransfer.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(15), refunded.Amount)
+}
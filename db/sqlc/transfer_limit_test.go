@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetTransferLimit(t *testing.T) {
+	user := createRandomUser(t)
+
+	arg := SetTransferLimitParams{
+		Username:   user.Username,
+		Currency:   "USD",
+		DailyLimit: sql.NullInt64{Int64: 100, Valid: true},
+	}
+	limit, err := testQueries.SetTransferLimit(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.DailyLimit, limit.DailyLimit)
+	require.False(t, limit.MonthlyLimit.Valid)
+
+	// setting it again replaces the override
+	arg.DailyLimit = sql.NullInt64{}
+	arg.MonthlyLimit = sql.NullInt64{Int64: 1000, Valid: true}
+	_, err = testQueries.SetTransferLimit(context.Background(), arg)
+	require.NoError(t, err)
+
+	limit, err = testQueries.GetTransferLimit(context.Background(), GetTransferLimitParams{
+		Username: user.Username,
+		Currency: "USD",
+	})
+	require.NoError(t, err)
+	require.False(t, limit.DailyLimit.Valid)
+	require.Equal(t, arg.MonthlyLimit, limit.MonthlyLimit)
+}
+
+func TestTransferTxLimits(t *testing.T) {
+	store := NewStoreWithConfig(testDB, StoreConfig{
+		TxRetry:        DefaultTxRetryConfig,
+		TransferLimits: TransferLimits{Daily: 100, Monthly: 1000},
+	})
+
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createRandomAccount(t)
+
+	transfer := func(amount int64) error {
+		_, err := store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        amount,
+		})
+		return err
+	}
+
+	require.NoError(t, transfer(60))
+
+	err := transfer(50)
+	require.ErrorIs(t, err, ErrTransferLimitExceeded)
+	require.Contains(t, err.Error(), "40 remaining")
+
+	require.NoError(t, transfer(40))
+
+	// an override of the user takes precedence over the default
+	_, err = store.SetTransferLimit(context.Background(), SetTransferLimitParams{
+		Username:   account1.Owner,
+		Currency:   account1.Currency,
+		DailyLimit: sql.NullInt64{Int64: 150, Valid: true},
+	})
+	require.NoError(t, err)
+	require.NoError(t, transfer(50))
+	require.ErrorIs(t, transfer(1), ErrTransferLimitExceeded)
+
+	// capturing a hold is a transfer too
+	hold := createRandomHold(t, store, account1, account2, 1, time.Now().Add(time.Hour))
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID})
+	require.ErrorIs(t, err, ErrTransferLimitExceeded)
+	_, err = store.VoidHoldTx(context.Background(), hold.ID)
+	require.NoError(t, err)
+
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-150, updatedAccount1.Balance)
+}
+
+func TestTransferTxLimitsConcurrent(t *testing.T) {
+	store := NewStoreWithConfig(testDB, StoreConfig{
+		TxRetry:        DefaultTxRetryConfig,
+		TransferLimits: TransferLimits{Daily: 55},
+	})
+
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createRandomAccount(t)
+
+	n := 10
+	amount := int64(10)
+	errs := make(chan error)
+
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := store.TransferTx(context.Background(), TransferTxParams{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        amount,
+			})
+			errs <- err
+		}()
+	}
+
+	// only five transfers fit into the limit, however they interleave
+	failed := 0
+	for i := 0; i < n; i++ {
+		err := <-errs
+		if err != nil {
+			require.ErrorIs(t, err, ErrTransferLimitExceeded)
+			failed++
+		}
+	}
+	require.Equal(t, n-5, failed)
+}
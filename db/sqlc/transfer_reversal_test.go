@@ -0,0 +1,224 @@
+package db
+
+import (
+	"context"
+	"simplebank/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReverseTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createRandomAccountWithBalance(t, 1000)
+
+	transfer, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+	})
+	require.NoError(t, err)
+
+	// partial reversal
+	result, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		Amount:     40,
+		ReversedBy: account2.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, transfer.Transfer.ID, result.ReversalOf)
+	require.Equal(t, account2.ID, result.Transfer.FromAccountID)
+	require.Equal(t, account1.ID, result.Transfer.ToAccountID)
+	require.Equal(t, int64(40), result.Transfer.Amount)
+	require.Equal(t, int64(40), result.Transfer.ToAmount)
+	require.Equal(t, int64(-40), result.FromEntry.Amount)
+	require.Equal(t, int64(40), result.ToEntry.Amount)
+	require.Equal(t, account1.Balance-60, result.ToAccount.Balance)
+	require.Equal(t, account2.Balance+60, result.FromAccount.Balance)
+
+	reversal, err := store.GetTransferReversal(context.Background(), result.Transfer.ID)
+	require.NoError(t, err)
+	require.Equal(t, transfer.Transfer.ID, reversal.TransferID)
+	require.Equal(t, account2.Owner, reversal.ReversedBy)
+
+	// more than is left
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		Amount:     61,
+		ReversedBy: account2.Owner,
+	})
+	require.ErrorIs(t, err, ErrInvalidReversal)
+
+	// a reversal can't be reversed
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: result.Transfer.ID,
+		ReversedBy: account1.Owner,
+	})
+	require.ErrorIs(t, err, ErrInvalidReversal)
+
+	// the rest
+	result, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		ReversedBy: account2.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(60), result.Transfer.Amount)
+	require.Equal(t, account1.Balance, result.ToAccount.Balance)
+	require.Equal(t, account2.Balance, result.FromAccount.Balance)
+
+	// nothing is left to reverse twice
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		ReversedBy: account2.Owner,
+	})
+	require.ErrorIs(t, err, ErrInvalidReversal)
+}
+
+func TestReverseTransferTxConcurrent(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createRandomAccountWithBalance(t, 1000)
+
+	transfer, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+	})
+	require.NoError(t, err)
+
+	n := 5
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+				TransferID: transfer.Transfer.ID,
+				ReversedBy: account2.Owner,
+			})
+			errs <- err
+		}()
+	}
+
+	succeeded := 0
+	for i := 0; i < n; i++ {
+		err := <-errs
+		if err == nil {
+			succeeded++
+			continue
+		}
+		require.ErrorIs(t, err, ErrInvalidReversal)
+	}
+	require.Equal(t, 1, succeeded)
+
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+}
+
+func TestReverseTransferTxCrossCurrency(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccountWithCurrency(t, 1000, util.USD)
+	account2 := createRandomAccountWithCurrency(t, 1000, util.EUR)
+
+	rate, err := store.CreateFxRate(context.Background(), CreateFxRateParams{
+		FromCurrency: util.USD,
+		ToCurrency:   util.EUR,
+		Rate:         "0.9",
+	})
+	require.NoError(t, err)
+
+	transfer, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+		FxRateID:      rate.ID,
+	})
+	require.NoError(t, err)
+
+	// 45 EUR of the 90 EUR received are worth 50 USD at the original rate
+	result, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		Amount:     45,
+		ReversedBy: account2.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(45), result.Transfer.Amount)
+	require.Equal(t, int64(50), result.Transfer.ToAmount)
+
+	result, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		ReversedBy: account2.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(45), result.Transfer.Amount)
+	require.Equal(t, int64(50), result.Transfer.ToAmount)
+	require.Equal(t, account1.Balance, result.ToAccount.Balance)
+	require.Equal(t, account2.Balance, result.FromAccount.Balance)
+}
+
+func TestReverseTransferTxMinorUnits(t *testing.T) {
+	util.SetCurrencies([]util.Currency{
+		{Code: util.USD, MinorUnits: 2, Enabled: true},
+		{Code: util.EUR, MinorUnits: 2, Enabled: true},
+		{Code: "JPY", MinorUnits: 0, Enabled: true},
+		{Code: "KWD", MinorUnits: 3, Enabled: true},
+	})
+	defer util.SetCurrencies([]util.Currency{
+		{Code: util.USD, MinorUnits: 2, Enabled: true},
+		{Code: util.EUR, MinorUnits: 2, Enabled: true},
+	})
+
+	testCases := []struct {
+		name     string
+		from     string
+		to       string
+		rate     string
+		amount   int64
+		toAmount int64
+	}{
+		// 1.00 USD at 150 is 150 JPY
+		{name: "USDToJPY", from: util.USD, to: "JPY", rate: "150", amount: 100, toAmount: 150},
+		// 1.000 KWD at 3.25 is 3.25 USD
+		{name: "KWDToUSD", from: "KWD", to: util.USD, rate: "3.25", amount: 1000, toAmount: 325},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := NewStore(testDB)
+
+			account1 := createRandomAccountWithCurrency(t, 10000, tc.from)
+			account2 := createRandomAccountWithCurrency(t, 10000, tc.to)
+
+			rate, err := store.CreateFxRate(context.Background(), CreateFxRateParams{
+				FromCurrency: tc.from,
+				ToCurrency:   tc.to,
+				Rate:         tc.rate,
+			})
+			require.NoError(t, err)
+
+			transfer, err := store.TransferTx(context.Background(), TransferTxParams{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        tc.amount,
+				FxRateID:      rate.ID,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.amount, transfer.Transfer.Amount)
+			require.Equal(t, tc.toAmount, transfer.Transfer.ToAmount)
+			require.Equal(t, account2.Balance+tc.toAmount, transfer.ToAccount.Balance)
+
+			result, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+				TransferID: transfer.Transfer.ID,
+				ReversedBy: account2.Owner,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.toAmount, result.Transfer.Amount)
+			require.Equal(t, tc.amount, result.Transfer.ToAmount)
+			require.Equal(t, account1.Balance, result.ToAccount.Balance)
+			require.Equal(t, account2.Balance, result.FromAccount.Balance)
+		})
+	}
+}
@@ -10,10 +10,13 @@ import (
 )
 
 func createRandomTransfer(t *testing.T, account1, account2 Account) Transfer {
+	amount := util.RandomMoney()
 	arg := CreateTransferParams{
 		FromAccountID: account1.ID,
 		ToAccountID:   account2.ID,
-		Amount:        util.RandomMoney(),
+		Amount:        amount,
+		ToAmount:      amount,
+		FxRate:        "1",
 	}
 
 	transfer, err := testQueries.CreateTransfer(context.Background(), arg)
@@ -23,6 +26,8 @@ func createRandomTransfer(t *testing.T, account1, account2 Account) Transfer {
 	require.Equal(t, transfer.FromAccountID, account1.ID)
 	require.Equal(t, transfer.ToAccountID, account2.ID)
 	require.Equal(t, transfer.Amount, arg.Amount)
+	require.Equal(t, transfer.ToAmount, arg.ToAmount)
+	require.Equal(t, transfer.FxRate, arg.FxRate)
 
 	require.NotZero(t, transfer.ID)
 	require.NotZero(t, transfer.CreatedAt)
@@ -52,28 +57,76 @@ func TestGetTransfer(t *testing.T) {
 	require.WithinDuration(t, transfer1.CreatedAt, transfer2.CreatedAt, time.Second)
 }
 
+func TestGetTransferWithOwners(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	transfer1 := createRandomTransfer(t, account1, account2)
+	transfer2, err := testQueries.GetTransferWithOwners(context.Background(), transfer1.ID)
+	require.NoError(t, err)
+
+	require.Equal(t, transfer1.ID, transfer2.ID)
+	require.Equal(t, transfer1.Amount, transfer2.Amount)
+	require.Equal(t, account1.Owner, transfer2.FromOwner)
+	require.Equal(t, account2.Owner, transfer2.ToOwner)
+}
+
 func TestListTransfers(t *testing.T) {
 	account1 := createRandomAccount(t)
 	account2 := createRandomAccount(t)
+	account3 := createRandomAccount(t)
 
-	for i := 0; i < 10; i++ {
+	for i := 0; i < 3; i++ {
 		createRandomTransfer(t, account1, account2)
 	}
+	for i := 0; i < 2; i++ {
+		createRandomTransfer(t, account2, account1)
+	}
+	createRandomTransfer(t, account2, account3)
+
+	list := func(direction string) []ListTransfersRow {
+		arg := ListTransfersParams{
+			Direction:       direction,
+			AccountID:       account1.ID,
+			ToTime:          time.Now().Add(time.Hour),
+			CursorCreatedAt: time.Now().Add(time.Hour),
+			RowLimit:        2,
+		}
+
+		var transfers []ListTransfersRow
+		for {
+			page, err := testQueries.ListTransfers(context.Background(), arg)
+			require.NoError(t, err)
+			transfers = append(transfers, page...)
+			if len(page) < int(arg.RowLimit) {
+				return transfers
+			}
+			last := page[len(page)-1]
+			arg.CursorCreatedAt = last.CreatedAt
+			arg.CursorID = last.ID
+		}
+	}
 
-	arg := ListTransfersParams{
-		FromAccountID: account1.ID,
-		ToAccountID:   account2.ID,
-		Limit:         5,
-		Offset:        5,
+	all := list("all")
+	require.Len(t, all, 5)
+	for i := 1; i < len(all); i++ {
+		// newest first
+		require.False(t, all[i].CreatedAt.After(all[i-1].CreatedAt))
+		require.Less(t, all[i].ID, all[i-1].ID)
 	}
 
-	transfers, err := testQueries.ListTransfers(context.Background(), arg)
-	require.NotEmpty(t, transfers)
-	require.NoError(t, err)
+	out := list("out")
+	require.Len(t, out, 3)
+	for _, transfer := range out {
+		require.Equal(t, account1.ID, transfer.FromAccountID)
+		require.Equal(t, account1.Owner, transfer.FromOwner)
+		require.Equal(t, account2.Owner, transfer.ToOwner)
+	}
 
-	for _, transfer := range transfers {
-		require.NotEmpty(t, transfer)
-		require.Equal(t, transfer.FromAccountID, account1.ID)
-		require.Equal(t, transfer.ToAccountID, account2.ID)
+	in := list("in")
+	require.Len(t, in, 2)
+	for _, transfer := range in {
+		require.Equal(t, account1.ID, transfer.ToAccountID)
+		require.Equal(t, account2.Owner, transfer.FromOwner)
 	}
 }
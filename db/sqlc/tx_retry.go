@@ -0,0 +1,80 @@
+package db
+
+import (
+	"errors"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// TxRetryConfig sets how often execTx retries a transaction that failed
+// with a serialization failure or a deadlock
+type TxRetryConfig struct {
+	// MaxRetries is the number of retries after the first attempt, 0 disables retries
+	MaxRetries int
+	// BaseDelay is the backoff before the first retry, doubled for every next one
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff
+	MaxDelay time.Duration
+}
+
+// DefaultTxRetryConfig is used by NewStore
+var DefaultTxRetryConfig = TxRetryConfig{
+	MaxRetries: 3,
+	BaseDelay:  10 * time.Millisecond,
+	MaxDelay:   200 * time.Millisecond,
+}
+
+// TxRetryStats counts the retried transactions since the store was created
+type TxRetryStats struct {
+	// Retries is the number of times a transaction was run again
+	Retries int64 `json:"retries"`
+	// Exhausted is the number of transactions that still failed after the last retry
+	Exhausted int64 `json:"exhausted"`
+}
+
+// txRetryCounters are updated by concurrent transactions
+type txRetryCounters struct {
+	retries   int64
+	exhausted int64
+}
+
+func (c *txRetryCounters) stats() TxRetryStats {
+	return TxRetryStats{
+		Retries:   atomic.LoadInt64(&c.retries),
+		Exhausted: atomic.LoadInt64(&c.exhausted),
+	}
+}
+
+// isRetryableTxError returns true for a serialization failure or a deadlock, after which
+// Postgres has rolled back the transaction and running it again may succeed
+func isRetryableTxError(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	switch pqErr.Code.Name() {
+	case "serialization_failure", "deadlock_detected":
+		return true
+	}
+	return false
+}
+
+// backoff returns the delay before the retry with the given number, starting at 0.
+// The delay doubles with every retry up to MaxDelay, and a random half of it is jitter
+// so that transactions that conflicted don't conflict again on their retries
+func (config TxRetryConfig) backoff(retry int) time.Duration {
+	delay := config.BaseDelay
+	for i := 0; i < retry && delay < config.MaxDelay; i++ {
+		delay *= 2
+	}
+	if config.MaxDelay > 0 && delay > config.MaxDelay {
+		delay = config.MaxDelay
+	}
+	if delay <= 1 {
+		return delay
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)))
+}
@@ -0,0 +1,93 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRetryableTxError(t *testing.T) {
+	require.True(t, isRetryableTxError(&pq.Error{Code: "40001"}))
+	require.True(t, isRetryableTxError(&pq.Error{Code: "40P01"}))
+	require.True(t, isRetryableTxError(fmt.Errorf("check balances: %w", &pq.Error{Code: "40001"})))
+	require.False(t, isRetryableTxError(&pq.Error{Code: "23505"}))
+	require.False(t, isRetryableTxError(sql.ErrNoRows))
+	require.False(t, isRetryableTxError(ErrInsufficientFunds))
+}
+
+func TestTxRetryBackoff(t *testing.T) {
+	config := TxRetryConfig{BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+
+	for i := 0; i < 100; i++ {
+		delay := config.backoff(0)
+		require.GreaterOrEqual(t, delay, 5*time.Millisecond)
+		require.Less(t, delay, 10*time.Millisecond)
+
+		delay = config.backoff(1)
+		require.GreaterOrEqual(t, delay, 10*time.Millisecond)
+		require.Less(t, delay, 20*time.Millisecond)
+
+		delay = config.backoff(10)
+		require.GreaterOrEqual(t, delay, 25*time.Millisecond)
+		require.Less(t, delay, 50*time.Millisecond)
+	}
+
+	require.Zero(t, TxRetryConfig{}.backoff(3))
+}
+
+func TestExecTxRetry(t *testing.T) {
+	store := NewStoreWithConfig(testDB, StoreConfig{
+		TxRetry: TxRetryConfig{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond},
+	}).(*SQLStore)
+	serializationFailure := &pq.Error{Code: "40001"}
+
+	attempts := 0
+	err := store.execTx(context.Background(), nil, func(q *Queries) error {
+		attempts++
+		if attempts < 3 {
+			return serializationFailure
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+	require.Equal(t, TxRetryStats{Retries: 2}, store.TxRetryStats())
+
+	attempts = 0
+	err = store.execTx(context.Background(), nil, func(q *Queries) error {
+		attempts++
+		return serializationFailure
+	})
+	require.ErrorIs(t, err, serializationFailure)
+	require.Equal(t, 3, attempts)
+	require.Equal(t, TxRetryStats{Retries: 4, Exhausted: 1}, store.TxRetryStats())
+
+	attempts = 0
+	err = store.execTx(context.Background(), nil, func(q *Queries) error {
+		attempts++
+		return ErrInsufficientFunds
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+	require.Equal(t, 1, attempts)
+}
+
+func TestExecTxReadOnly(t *testing.T) {
+	store := NewStore(testDB).(*SQLStore)
+	account := createRandomAccount(t)
+
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	err := store.execTx(context.Background(), opts, func(q *Queries) error {
+		_, err := q.AddAccountBalance(context.Background(), AddAccountBalanceParams{ID: account.ID, Amount: 1})
+		return err
+	})
+	require.Error(t, err)
+
+	got, err := testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.Balance, got.Balance)
+}
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"simplebank/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomWebhookSubscription(t *testing.T, owner string, eventTypes ...string) WebhookSubscription {
+	arg := CreateWebhookSubscriptionParams{
+		Owner:      owner,
+		Url:        "https://partner.example.com/" + util.RandomString(6),
+		EventTypes: eventTypes,
+		Secret:     util.RandomString(64),
+	}
+
+	subscription, err := testQueries.CreateWebhookSubscription(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, subscription.ID)
+	require.Equal(t, arg.Owner, subscription.Owner)
+	require.Equal(t, arg.Url, subscription.Url)
+	require.Equal(t, arg.EventTypes, subscription.EventTypes)
+	require.Equal(t, arg.Secret, subscription.Secret)
+	require.NotZero(t, subscription.CreatedAt)
+
+	return subscription
+}
+
+func createRandomOutboxEvent(t *testing.T) OutboxEvent {
+	event, err := testQueries.CreateOutboxEvent(context.Background(), CreateOutboxEventParams{
+		AggregateType: AggregateAccount,
+		AggregateID:   util.RandomString(6),
+		EventType:     EventAccountCreated,
+		Payload:       json.RawMessage(`{}`),
+	})
+	require.NoError(t, err)
+	return event
+}
+
+func TestCreateWebhookDeliveries(t *testing.T) {
+	user := createRandomUser(t)
+	received := createRandomWebhookSubscription(t, user.Username, util.WebhookTransferReceived)
+	createRandomWebhookSubscription(t, user.Username, util.WebhookTransferSent)
+	event := createRandomOutboxEvent(t)
+
+	arg := CreateWebhookDeliveriesParams{
+		EventID:   event.ID,
+		EventType: util.WebhookTransferReceived,
+		Payload:   json.RawMessage(`{"event_type": "transfer.received"}`),
+		Owner:     user.Username,
+	}
+	n, err := testQueries.CreateWebhookDeliveries(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	// an event relayed again doesn't create another delivery
+	n, err = testQueries.CreateWebhookDeliveries(context.Background(), arg)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	deliveries, err := testQueries.ListWebhookDeliveries(context.Background(), ListWebhookDeliveriesParams{
+		SubscriptionID: received.ID,
+		Limit:          5,
+	})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, event.ID, deliveries[0].EventID)
+	require.Equal(t, WebhookDeliveryPending, deliveries[0].Status)
+	require.Zero(t, deliveries[0].Attempts)
+	require.JSONEq(t, string(arg.Payload), string(deliveries[0].Payload))
+}
+
+func TestRecordWebhookAttemptTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	subscription := createRandomWebhookSubscription(t, user.Username, util.WebhookAccountCreated)
+	event := createRandomOutboxEvent(t)
+
+	_, err := testQueries.CreateWebhookDeliveries(context.Background(), CreateWebhookDeliveriesParams{
+		EventID:   event.ID,
+		EventType: util.WebhookAccountCreated,
+		Payload:   json.RawMessage(`{}`),
+		Owner:     user.Username,
+	})
+	require.NoError(t, err)
+	deliveries, err := testQueries.ListWebhookDeliveries(context.Background(), ListWebhookDeliveriesParams{
+		SubscriptionID: subscription.ID,
+		Limit:          5,
+	})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	delivery := deliveries[0]
+
+	nextAttemptAt := time.Now().Add(time.Minute)
+	failed, err := store.RecordWebhookAttemptTx(context.Background(), RecordWebhookAttemptTxParams{
+		DeliveryID:    delivery.ID,
+		StatusCode:    503,
+		Error:         "unexpected response status 503",
+		Status:        WebhookDeliveryPending,
+		NextAttemptAt: nextAttemptAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, WebhookDeliveryPending, failed.Status)
+	require.Equal(t, int32(1), failed.Attempts)
+	require.Equal(t, int32(503), failed.LastStatusCode)
+	require.WithinDuration(t, nextAttemptAt, failed.NextAttemptAt, time.Second)
+	require.False(t, failed.DeliveredAt.Valid)
+
+	delivered, err := store.RecordWebhookAttemptTx(context.Background(), RecordWebhookAttemptTxParams{
+		DeliveryID:    delivery.ID,
+		StatusCode:    200,
+		Status:        WebhookDeliveryDelivered,
+		NextAttemptAt: nextAttemptAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, WebhookDeliveryDelivered, delivered.Status)
+	require.Equal(t, int32(2), delivered.Attempts)
+	require.Empty(t, delivered.LastError)
+	require.True(t, delivered.DeliveredAt.Valid)
+
+	attempts, err := testQueries.ListWebhookAttempts(context.Background(), delivery.ID)
+	require.NoError(t, err)
+	require.Len(t, attempts, 2)
+	require.Equal(t, int32(503), attempts[0].StatusCode)
+	require.Equal(t, int32(200), attempts[1].StatusCode)
+
+	replayed, err := testQueries.ReplayWebhookDelivery(context.Background(), delivery.ID)
+	require.NoError(t, err)
+	require.Equal(t, WebhookDeliveryPending, replayed.Status)
+	require.Zero(t, replayed.Attempts)
+	require.False(t, replayed.DeliveredAt.Valid)
+
+	// the claimed delivery is leased until the worker records the attempt
+	leaseUntil := time.Now().Add(time.Hour)
+	claimed, err := testQueries.ClaimDueWebhookDeliveries(context.Background(), ClaimDueWebhookDeliveriesParams{
+		LeaseUntil: leaseUntil,
+		Limit:      1000,
+	})
+	require.NoError(t, err)
+	var found bool
+	for _, c := range claimed {
+		if c.ID == delivery.ID {
+			found = true
+			require.WithinDuration(t, leaseUntil, c.NextAttemptAt, time.Second)
+		}
+	}
+	require.True(t, found)
+}
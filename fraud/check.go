@@ -0,0 +1,34 @@
+package fraud
+
+import (
+	"context"
+	db "simplebank/db/sqlc"
+)
+
+// CheckTransfer runs the rules on a transfer that a worker makes for a user, with the facts read from store.
+// It is the db.FraudCheck of the store, and returns the flag of the rule that matched, nil if the transfer is allowed
+func (engine *Engine) CheckTransfer(ctx context.Context, store db.Store, username string, arg db.TransferTxParams) (*db.FraudFlagParams, error) {
+	fromAccount, err := store.GetAccount(ctx, arg.FromAccountID)
+	if err != nil {
+		return nil, err
+	}
+	toAccount, err := store.GetAccount(ctx, arg.ToAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := engine.Evaluate(ctx, NewTransferFacts(store, Transfer{
+		Username:    username,
+		Amount:      arg.Amount,
+		FromAccount: fromAccount,
+		ToAccount:   toAccount,
+	}))
+	if err != nil || result.Decision == Allow {
+		return nil, err
+	}
+	return &db.FraudFlagParams{
+		Username: username,
+		Decision: result.Decision,
+		Rule:     result.Rule,
+	}, nil
+}
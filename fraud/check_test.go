@@ -0,0 +1,53 @@
+package fraud
+
+import (
+	"context"
+	mockdb "simplebank/db/mock"
+	db "simplebank/db/sqlc"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTransfer(t *testing.T) {
+	list, err := ParseRules([]byte(`[
+		{"name": "large", "decision": "deny", "conditions": [{"fact": "transfer.amount", "op": ">=", "value": 1000}]},
+		{"name": "medium", "decision": "review", "conditions": [{"fact": "transfer.amount", "op": ">=", "value": 500}]}
+	]`))
+	require.NoError(t, err)
+	engine := NewEngine()
+	engine.SetRules(list)
+
+	testCases := []struct {
+		name   string
+		amount int64
+		flag   *db.FraudFlagParams
+	}{
+		{name: "Allow", amount: 100},
+		{name: "Review", amount: 500, flag: &db.FraudFlagParams{Username: "alice", Decision: Review, Rule: "medium"}},
+		{name: "Deny", amount: 1000, flag: &db.FraudFlagParams{Username: "alice", Decision: Deny, Rule: "large"}},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(int64(1))).Times(1).Return(db.Account{ID: 1, Owner: "alice"}, nil)
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(int64(2))).Times(1).Return(db.Account{ID: 2, Owner: "bob"}, nil)
+
+			flag, err := engine.CheckTransfer(context.Background(), store, "alice", db.TransferTxParams{
+				FromAccountID: 1,
+				ToAccountID:   2,
+				Amount:        tc.amount,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.flag, flag)
+		})
+	}
+
+	// the deny decision of the store is the one of the rules
+	require.Equal(t, Deny, db.FraudDecisionDeny)
+}
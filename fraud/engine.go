@@ -0,0 +1,90 @@
+package fraud
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Facts gives the value of a fact about a transfer, over the window for the window facts
+type Facts interface {
+	Fact(ctx context.Context, name string, window time.Duration) (int64, error)
+}
+
+// Result is the decision on a transfer and the rule that made it, no rule if none matched
+type Result struct {
+	Decision string
+	Rule     string
+}
+
+// Engine decides on transfers with its rules. It has no rules until SetRules or LoadRules
+// gives it some, so every transfer is allowed. The rules can be replaced while transfers are evaluated
+type Engine struct {
+	mu    sync.RWMutex
+	rules []Rule
+}
+
+// NewEngine creates an engine without rules
+func NewEngine() *Engine {
+	return &Engine{}
+}
+
+// SetRules replaces the rules of the engine
+func (engine *Engine) SetRules(list []Rule) {
+	engine.mu.Lock()
+	defer engine.mu.Unlock()
+	engine.rules = list
+}
+
+// Rules returns the rules of the engine in the order they are evaluated
+func (engine *Engine) Rules() []Rule {
+	engine.mu.RLock()
+	defer engine.mu.RUnlock()
+	return engine.rules
+}
+
+// LoadRules reads a JSON array of rules from a file and replaces the rules of the engine.
+// The rules are left as they were if the file can't be read or has an invalid rule
+func (engine *Engine) LoadRules(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	list, err := ParseRules(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	engine.SetRules(list)
+	return nil
+}
+
+// Evaluate runs the rules in order and returns the decision of the first rule whose conditions all hold,
+// so an allow rule placed first exempts the transfers it matches from the rules after it.
+// A transfer that matches no rule is allowed
+func (engine *Engine) Evaluate(ctx context.Context, facts Facts) (Result, error) {
+	for _, rule := range engine.Rules() {
+		matched, err := rule.matches(ctx, facts)
+		if err != nil {
+			return Result{}, err
+		}
+		if matched {
+			return Result{Decision: rule.Decision, Rule: rule.Name}, nil
+		}
+	}
+	return Result{Decision: Allow}, nil
+}
+
+func (rule Rule) matches(ctx context.Context, facts Facts) (bool, error) {
+	for _, cond := range rule.Conditions {
+		value, err := facts.Fact(ctx, cond.Fact, cond.window)
+		if err != nil {
+			return false, err
+		}
+		if !cond.holds(value) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,104 @@
+package fraud
+
+import (
+	"context"
+	"fmt"
+	db "simplebank/db/sqlc"
+	"time"
+)
+
+// Transfer is a transfer about to be made
+type Transfer struct {
+	Username    string
+	Amount      int64
+	FromAccount db.Account
+	ToAccount   db.Account
+}
+
+// TransferFacts gives the facts about a transfer. The facts that need the history of the user
+// are read from the store when a condition first asks for them
+type TransferFacts struct {
+	store    db.Store
+	transfer Transfer
+	now      time.Time
+	values   map[string]int64
+}
+
+// NewTransferFacts creates the facts about a transfer made now
+func NewTransferFacts(store db.Store, transfer Transfer) *TransferFacts {
+	return &TransferFacts{
+		store:    store,
+		transfer: transfer,
+		now:      time.Now(),
+		values:   map[string]int64{},
+	}
+}
+
+// Fact returns the value of a fact, the same value every time it is asked for
+func (facts *TransferFacts) Fact(ctx context.Context, name string, window time.Duration) (int64, error) {
+	key := name
+	if window > 0 {
+		key = fmt.Sprintf("%s/%s", name, window)
+	}
+	if value, ok := facts.values[key]; ok {
+		return value, nil
+	}
+
+	value, err := facts.fact(ctx, name, window)
+	if err != nil {
+		return 0, fmt.Errorf("cannot get fraud fact %s: %w", key, err)
+	}
+	facts.values[key] = value
+	return value, nil
+}
+
+func (facts *TransferFacts) fact(ctx context.Context, name string, window time.Duration) (int64, error) {
+	transfer := facts.transfer
+	switch name {
+	case FactAmount:
+		return transfer.Amount, nil
+	case FactBalancePercent:
+		balance := transfer.FromAccount.AvailableBalance
+		if balance <= 0 {
+			return 100, nil
+		}
+		return transfer.Amount * 100 / balance, nil
+	case FactAccountBalance:
+		return transfer.FromAccount.AvailableBalance, nil
+	case FactAccountAgeHours:
+		return ageHours(facts.now, transfer.FromAccount.CreatedAt), nil
+	case FactUserAgeHours:
+		user, err := facts.store.GetUser(ctx, transfer.Username)
+		if err != nil {
+			return 0, err
+		}
+		return ageHours(facts.now, user.CreatedAt), nil
+	case FactPayeeTransfers:
+		return facts.store.CountPayeeTransfers(ctx, db.CountPayeeTransfersParams{
+			Owner:       transfer.Username,
+			ToAccountID: transfer.ToAccount.ID,
+		})
+	case FactPayeeOwn:
+		if transfer.ToAccount.Owner == transfer.Username {
+			return 1, nil
+		}
+		return 0, nil
+	case FactWindowCount, FactWindowTotal:
+		stats, err := facts.store.GetOutgoingTransferStats(ctx, db.GetOutgoingTransferStatsParams{
+			FromAccountID: transfer.FromAccount.ID,
+			CreatedAt:     facts.now.Add(-window),
+		})
+		if err != nil {
+			return 0, err
+		}
+		if name == FactWindowCount {
+			return stats.Count, nil
+		}
+		return stats.Total, nil
+	}
+	return 0, fmt.Errorf("unknown fact %q", name)
+}
+
+func ageHours(now time.Time, createdAt time.Time) int64 {
+	return int64(now.Sub(createdAt) / time.Hour)
+}
@@ -0,0 +1,87 @@
+package fraud
+
+import (
+	"context"
+	"database/sql"
+	mockdb "simplebank/db/mock"
+	db "simplebank/db/sqlc"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferFacts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	now := time.Now()
+	transfer := Transfer{
+		Username: "alice",
+		Amount:   900,
+		FromAccount: db.Account{
+			ID:               1,
+			Owner:            "alice",
+			AvailableBalance: 1000,
+			CreatedAt:        now.Add(-50 * time.Hour),
+		},
+		ToAccount: db.Account{ID: 2, Owner: "bob"},
+	}
+
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq("alice")).Times(1).
+		Return(db.User{Username: "alice", CreatedAt: now.Add(-5 * time.Hour)}, nil)
+	store.EXPECT().CountPayeeTransfers(gomock.Any(), gomock.Eq(db.CountPayeeTransfersParams{
+		Owner:       "alice",
+		ToAccountID: 2,
+	})).Times(1).Return(int64(0), nil)
+	store.EXPECT().GetOutgoingTransferStats(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.GetOutgoingTransferStatsParams) (db.GetOutgoingTransferStatsRow, error) {
+			require.Equal(t, int64(1), arg.FromAccountID)
+			require.WithinDuration(t, now.Add(-10*time.Minute), arg.CreatedAt, time.Second)
+			return db.GetOutgoingTransferStatsRow{Count: 3, Total: 1500}, nil
+		})
+
+	facts := NewTransferFacts(store, transfer)
+	want := []struct {
+		name   string
+		window time.Duration
+		value  int64
+	}{
+		{FactAmount, 0, 900},
+		{FactBalancePercent, 0, 90},
+		{FactAccountBalance, 0, 1000},
+		{FactAccountAgeHours, 0, 50},
+		{FactUserAgeHours, 0, 5},
+		{FactPayeeTransfers, 0, 0},
+		{FactPayeeOwn, 0, 0},
+		{FactWindowCount, 10 * time.Minute, 3},
+		// asked again, the value isn't read from the store twice
+		{FactUserAgeHours, 0, 5},
+	}
+	for _, w := range want {
+		value, err := facts.Fact(context.Background(), w.name, w.window)
+		require.NoError(t, err)
+		require.Equal(t, w.value, value, w.name)
+	}
+}
+
+func TestTransferFactsStoreError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, sql.ErrConnDone)
+
+	facts := NewTransferFacts(store, Transfer{Username: "alice"})
+	_, err := facts.Fact(context.Background(), FactUserAgeHours, 0)
+	require.ErrorIs(t, err, sql.ErrConnDone)
+}
+
+func TestBalancePercentOfEmptyAccount(t *testing.T) {
+	facts := NewTransferFacts(nil, Transfer{Amount: 10})
+	value, err := facts.Fact(context.Background(), FactBalancePercent, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), value)
+}
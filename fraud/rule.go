@@ -0,0 +1,137 @@
+package fraud
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// decisions of a rule
+const (
+	Allow  = "allow"
+	Review = "review"
+	Deny   = "deny"
+)
+
+// facts a condition can compare, see TransferFacts
+const (
+	// FactAmount is the amount of the transfer in minor units of the source account
+	FactAmount = "transfer.amount"
+	// FactBalancePercent is the amount as a percentage of the available balance of the source account
+	FactBalancePercent = "transfer.balance_percent"
+	// FactAccountBalance is the available balance of the source account
+	FactAccountBalance = "account.balance"
+	// FactAccountAgeHours is how long ago the source account was opened
+	FactAccountAgeHours = "account.age_hours"
+	// FactUserAgeHours is how long ago the user signed up
+	FactUserAgeHours = "user.age_hours"
+	// FactPayeeTransfers is the number of earlier transfers of the user to the destination account
+	FactPayeeTransfers = "payee.transfers"
+	// FactPayeeOwn is 1 if the destination account belongs to the user, 0 otherwise
+	FactPayeeOwn = "payee.own"
+	// FactWindowCount is the number of transfers from the source account in the window
+	FactWindowCount = "window.transfer_count"
+	// FactWindowTotal is the amount sent from the source account in the window
+	FactWindowTotal = "window.transfer_total"
+)
+
+// Condition compares a fact about the transfer with a value
+type Condition struct {
+	Fact string `json:"fact"`
+	// Op is one of <, <=, >, >=, == and !=
+	Op    string `json:"op"`
+	Value int64  `json:"value"`
+	// Window is the period of the window facts ending now, like "10m" or "24h"
+	Window string `json:"window,omitempty"`
+
+	window time.Duration
+}
+
+// Rule decides on a transfer that meets all its conditions
+type Rule struct {
+	Name       string      `json:"name"`
+	Decision   string      `json:"decision"`
+	Conditions []Condition `json:"conditions"`
+}
+
+// ParseRules parses and validates a JSON array of rules
+func ParseRules(data []byte) ([]Rule, error) {
+	var list []Rule
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	for i := range list {
+		rule := &list[i]
+		if len(rule.Name) == 0 {
+			return nil, fmt.Errorf("rule %d has no name", i)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("duplicate rule %q", rule.Name)
+		}
+		names[rule.Name] = true
+
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+	}
+	return list, nil
+}
+
+func (rule *Rule) validate() error {
+	switch rule.Decision {
+	case Allow, Review, Deny:
+	default:
+		return fmt.Errorf("unknown decision %q", rule.Decision)
+	}
+	if len(rule.Conditions) == 0 {
+		return errors.New("no conditions")
+	}
+
+	for i := range rule.Conditions {
+		cond := &rule.Conditions[i]
+		switch cond.Fact {
+		case FactAmount, FactBalancePercent, FactAccountBalance, FactAccountAgeHours,
+			FactUserAgeHours, FactPayeeTransfers, FactPayeeOwn:
+			if len(cond.Window) > 0 {
+				return fmt.Errorf("fact %q has no window", cond.Fact)
+			}
+		case FactWindowCount, FactWindowTotal:
+			window, err := time.ParseDuration(cond.Window)
+			if err != nil || window <= 0 {
+				return fmt.Errorf("fact %q needs a positive window, got %q", cond.Fact, cond.Window)
+			}
+			cond.window = window
+		default:
+			return fmt.Errorf("unknown fact %q", cond.Fact)
+		}
+
+		switch cond.Op {
+		case "<", "<=", ">", ">=", "==", "!=":
+		default:
+			return fmt.Errorf("unknown operator %q", cond.Op)
+		}
+	}
+	return nil
+}
+
+// holds reports whether the value of the fact meets the condition
+func (cond Condition) holds(value int64) bool {
+	switch cond.Op {
+	case "<":
+		return value < cond.Value
+	case "<=":
+		return value <= cond.Value
+	case ">":
+		return value > cond.Value
+	case ">=":
+		return value >= cond.Value
+	case "==":
+		return value == cond.Value
+	case "!=":
+		return value != cond.Value
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+package fraud
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// stubFacts gives fixed values, keyed by fact and window
+type stubFacts map[string]int64
+
+func (facts stubFacts) Fact(ctx context.Context, name string, window time.Duration) (int64, error) {
+	if window > 0 {
+		name += "/" + window.String()
+	}
+	value, ok := facts[name]
+	if !ok {
+		return 0, errors.New("no fact " + name)
+	}
+	return value, nil
+}
+
+func TestParseRules(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{
+			name: "OK",
+			data: `[{"name": "burst", "decision": "review", "conditions": [{"fact": "window.transfer_count", "op": ">=", "value": 5, "window": "10m"}]}]`,
+		},
+		{
+			name: "InvalidJSON",
+			data: `{`,
+			err:  "unexpected end of JSON input",
+		},
+		{
+			name: "NoName",
+			data: `[{"decision": "deny", "conditions": [{"fact": "transfer.amount", "op": ">", "value": 1}]}]`,
+			err:  "rule 0 has no name",
+		},
+		{
+			name: "DuplicateName",
+			data: `[{"name": "a", "decision": "deny", "conditions": [{"fact": "transfer.amount", "op": ">", "value": 1}]},
+				{"name": "a", "decision": "review", "conditions": [{"fact": "transfer.amount", "op": ">", "value": 1}]}]`,
+			err: `duplicate rule "a"`,
+		},
+		{
+			name: "UnknownDecision",
+			data: `[{"name": "a", "decision": "block", "conditions": [{"fact": "transfer.amount", "op": ">", "value": 1}]}]`,
+			err:  `rule "a": unknown decision "block"`,
+		},
+		{
+			name: "NoConditions",
+			data: `[{"name": "a", "decision": "deny", "conditions": []}]`,
+			err:  `rule "a": no conditions`,
+		},
+		{
+			name: "UnknownFact",
+			data: `[{"name": "a", "decision": "deny", "conditions": [{"fact": "user.country", "op": "==", "value": 1}]}]`,
+			err:  `rule "a": unknown fact "user.country"`,
+		},
+		{
+			name: "UnknownOperator",
+			data: `[{"name": "a", "decision": "deny", "conditions": [{"fact": "transfer.amount", "op": "=>", "value": 1}]}]`,
+			err:  `rule "a": unknown operator "=>"`,
+		},
+		{
+			name: "MissingWindow",
+			data: `[{"name": "a", "decision": "deny", "conditions": [{"fact": "window.transfer_total", "op": ">", "value": 1}]}]`,
+			err:  `rule "a": fact "window.transfer_total" needs a positive window, got ""`,
+		},
+		{
+			name: "UnexpectedWindow",
+			data: `[{"name": "a", "decision": "deny", "conditions": [{"fact": "transfer.amount", "op": ">", "value": 1, "window": "1h"}]}]`,
+			err:  `rule "a": fact "transfer.amount" has no window`,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			list, err := ParseRules([]byte(tc.data))
+			if len(tc.err) > 0 {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			require.Equal(t, 10*time.Minute, list[0].Conditions[0].window)
+		})
+	}
+}
+
+func TestExampleRules(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "fraud_rules.json"))
+	require.NoError(t, err)
+
+	list, err := ParseRules(data)
+	require.NoError(t, err)
+	require.NotEmpty(t, list)
+}
+
+func TestEvaluate(t *testing.T) {
+	list, err := ParseRules([]byte(`[
+		{"name": "own", "decision": "allow", "conditions": [{"fact": "payee.own", "op": "==", "value": 1}]},
+		{"name": "empties_balance", "decision": "deny", "conditions": [
+			{"fact": "user.age_hours", "op": "<", "value": 24},
+			{"fact": "transfer.balance_percent", "op": ">=", "value": 90}
+		]},
+		{"name": "burst", "decision": "review", "conditions": [{"fact": "window.transfer_count", "op": ">=", "value": 10, "window": "10m"}]}
+	]`))
+	require.NoError(t, err)
+	engine := NewEngine()
+	engine.SetRules(list)
+
+	testCases := []struct {
+		name   string
+		facts  stubFacts
+		result Result
+		err    bool
+	}{
+		{
+			name:   "NoRuleMatches",
+			facts:  stubFacts{"payee.own": 0, "user.age_hours": 1000, "window.transfer_count/10m0s": 2},
+			result: Result{Decision: Allow},
+		},
+		{
+			name:   "FirstRuleDecides",
+			facts:  stubFacts{"payee.own": 1},
+			result: Result{Decision: Allow, Rule: "own"},
+		},
+		{
+			name:   "AllConditions",
+			facts:  stubFacts{"payee.own": 0, "user.age_hours": 3, "transfer.balance_percent": 95},
+			result: Result{Decision: Deny, Rule: "empties_balance"},
+		},
+		{
+			name:   "Review",
+			facts:  stubFacts{"payee.own": 0, "user.age_hours": 3, "transfer.balance_percent": 50, "window.transfer_count/10m0s": 10},
+			result: Result{Decision: Review, Rule: "burst"},
+		},
+		{
+			name:  "FactError",
+			facts: stubFacts{"payee.own": 0},
+			err:   true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := engine.Evaluate(context.Background(), tc.facts)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.result, result)
+		})
+	}
+}
+
+func TestLoadRulesKeepsRulesOnError(t *testing.T) {
+	engine := NewEngine()
+	path := filepath.Join(t.TempDir(), "rules.json")
+
+	err := os.WriteFile(path, []byte(`[{"name": "big", "decision": "deny", "conditions": [{"fact": "transfer.amount", "op": ">", "value": 100}]}]`), 0600)
+	require.NoError(t, err)
+	require.NoError(t, engine.LoadRules(path))
+	require.Len(t, engine.Rules(), 1)
+
+	err = os.WriteFile(path, []byte(`[{"name": "big"}]`), 0600)
+	require.NoError(t, err)
+	require.Error(t, engine.LoadRules(path))
+	require.Equal(t, "big", engine.Rules()[0].Name)
+	require.Equal(t, Deny, engine.Rules()[0].Decision)
+}
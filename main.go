@@ -1,11 +1,17 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
+	"os"
 	"simplebank/api"
 	db "simplebank/db/sqlc"
+	"simplebank/fraud"
+	"simplebank/notify"
 	"simplebank/util"
+	"simplebank/worker"
+	"strconv"
 
 	_ "github.com/lib/pq"
 	_ "simplebank/docs"
@@ -32,8 +38,76 @@ func main() {
 		log.Fatal("cannot connect to db:", err)
 	}
 
-	store := db.NewStore(conn)
-	server, err := api.NewServer(config, store)
+	// the rules are loaded below, the store checks the transfers of its workers with them
+	engine := fraud.NewEngine()
+	store := db.NewStoreWithConfig(conn, db.StoreConfig{
+		TxRetry: db.TxRetryConfig{
+			MaxRetries: config.TxMaxRetries,
+			BaseDelay:  config.TxRetryBaseDelay,
+			MaxDelay:   config.TxRetryMaxDelay,
+		},
+		TransferLimits: db.TransferLimits{
+			Daily:   config.DailyTransferLimit,
+			Monthly: config.MonthlyTransferLimit,
+		},
+		FraudCheck: engine.CheckTransfer,
+	})
+
+	err = worker.LoadCurrencies(context.Background(), store)
+	if err != nil {
+		log.Fatal("cannot load currencies:", err)
+	}
+
+	if len(os.Args) > 1 {
+		runCommand(store, os.Args[1:])
+		return
+	}
+
+	if len(config.FraudRulesFile) > 0 {
+		err = engine.LoadRules(config.FraudRulesFile)
+		if err != nil {
+			log.Fatal("cannot load fraud rules:", err)
+		}
+		if config.FraudRulesRefreshInterval > 0 {
+			go worker.NewFraudRuleWorker(engine, config.FraudRulesFile, config.FraudRulesRefreshInterval).Run(context.Background())
+		}
+	}
+
+	if config.CurrencyRefreshInterval > 0 {
+		go worker.NewCurrencyWorker(store, config.CurrencyRefreshInterval).Run(context.Background())
+	}
+	if config.ScheduledTransferInterval > 0 {
+		go worker.NewScheduledTransferWorker(store, config.ScheduledTransferInterval).Run(context.Background())
+	}
+	if config.StandingOrderInterval > 0 {
+		go worker.NewStandingOrderWorker(store, config.StandingOrderInterval).Run(context.Background())
+	}
+	if config.HoldExpiryInterval > 0 {
+		go worker.NewHoldExpiryWorker(store, config.HoldExpiryInterval).Run(context.Background())
+	}
+	if config.BalanceSnapshotInterval > 0 {
+		go worker.NewBalanceSnapshotWorker(store, config.BalanceSnapshotInterval).Run(context.Background())
+	}
+	if config.InterestInterval > 0 {
+		go worker.NewInterestWorker(store, config.InterestInterval).Run(context.Background())
+	}
+	if config.OutboxRelayInterval > 0 {
+		publisher := worker.Publishers{worker.LogPublisher{}, worker.NewWebhookPublisher(store)}
+		go worker.NewOutboxRelay(store, publisher, config.OutboxRelayInterval).Run(context.Background())
+	}
+	if config.WebhookInterval > 0 {
+		client := worker.NewWebhookClient(config.WebhookTimeout)
+		go worker.NewWebhookWorker(store, client, config.WebhookInterval, config.WebhookMaxAttempts, config.WebhookRetryDelay).Run(context.Background())
+	}
+
+	listener, err := notify.Listen(config.DBSource)
+	if err != nil {
+		log.Fatal("cannot listen to account events:", err)
+	}
+	hub := notify.NewHub()
+	go hub.Run(context.Background(), listener)
+
+	server, err := api.NewServer(config, store, hub, engine)
 	if err != nil {
 		log.Fatal("cannot create server:", err)
 	}
@@ -43,3 +117,263 @@ func main() {
 		log.Fatal("cannot run server:", err)
 	}
 }
+
+// runCommand runs a one-off maintenance command instead of the server
+func runCommand(store db.Store, args []string) {
+	switch args[0] {
+	case "import-fx-rates":
+		if len(args) != 2 {
+			log.Fatal("usage: main import-fx-rates <file>")
+		}
+		importFxRates(store, args[1])
+	case "set-role":
+		if len(args) != 3 {
+			log.Fatal("usage: main set-role <username> <role>")
+		}
+		setRole(store, args[1], args[2])
+	case "set-transfer-limit":
+		if len(args) != 5 {
+			log.Fatal("usage: main set-transfer-limit <username> <currency> <daily|default> <monthly|default>")
+		}
+		setTransferLimit(store, args[1], args[2], args[3], args[4])
+	case "set-product":
+		if len(args) != 5 {
+			log.Fatal("usage: main set-product <code> <name> <annual_rate> <ACT/365|30/360>")
+		}
+		setProduct(store, args[1], args[2], args[3], args[4])
+	case "set-fee":
+		if len(args) != 7 {
+			log.Fatal("usage: main set-fee <currency|any> <any|own|other> <flat_fee> <rate> <min_fee> <max_fee|0>")
+		}
+		setTransferFee(store, args[1], args[2], args[3], args[4], args[5], args[6])
+	case "delete-fee":
+		if len(args) != 3 {
+			log.Fatal("usage: main delete-fee <currency|any> <any|own|other>")
+		}
+		deleteTransferFee(store, args[1], args[2])
+	case "set-currency":
+		if len(args) != 4 {
+			log.Fatal("usage: main set-currency <code> <minor_units> <enabled|disabled>")
+		}
+		setCurrency(store, args[1], args[2], args[3])
+	case "reconcile":
+		reconcile(store)
+	default:
+		log.Fatalf("unknown command %q", args[0])
+	}
+}
+
+// importFxRates loads fx rates from a "from_currency,to_currency,rate" csv file
+func importFxRates(store db.Store, path string) {
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatal("cannot open fx rates file:", err)
+	}
+	defer file.Close()
+
+	rates, err := util.ReadFxRates(file)
+	if err != nil {
+		log.Fatal("cannot read fx rates:", err)
+	}
+
+	arg := make([]db.CreateFxRateParams, len(rates))
+	for i, rate := range rates {
+		arg[i] = db.CreateFxRateParams{
+			FromCurrency: rate.FromCurrency,
+			ToCurrency:   rate.ToCurrency,
+			Rate:         rate.Rate,
+		}
+	}
+
+	created, err := store.CreateFxRatesTx(context.Background(), arg)
+	if err != nil {
+		log.Fatal("cannot import fx rates:", err)
+	}
+	log.Printf("imported %d fx rates", len(created))
+}
+
+// setRole changes the role of a user, e.g. to make the first admin
+func setRole(store db.Store, username string, role string) {
+	if !util.IsRoleSupport(role) {
+		log.Fatalf("unknown role %q", role)
+	}
+
+	user, err := store.UpdateUserRole(context.Background(), db.UpdateUserRoleParams{
+		Username: username,
+		Role:     role,
+	})
+	if err != nil {
+		log.Fatal("cannot set role:", err)
+	}
+	log.Printf("user %s is now %s", user.Username, user.Role)
+}
+
+// setTransferLimit overrides the default transfer limits of a user in one currency.
+// "default" keeps the default from the config, 0 removes the limit
+func setTransferLimit(store db.Store, username string, currency string, daily string, monthly string) {
+	if !util.IsCurrencySupport(currency) {
+		log.Fatalf("unknown currency %q", currency)
+	}
+
+	arg := db.SetTransferLimitParams{
+		Username:     username,
+		Currency:     currency,
+		DailyLimit:   parseLimit(daily),
+		MonthlyLimit: parseLimit(monthly),
+	}
+	limit, err := store.SetTransferLimit(context.Background(), arg)
+	if err != nil {
+		log.Fatal("cannot set transfer limit:", err)
+	}
+	log.Printf("transfer limits of %s in %s are set", limit.Username, limit.Currency)
+}
+
+func parseLimit(value string) sql.NullInt64 {
+	if value == "default" {
+		return sql.NullInt64{}
+	}
+	limit, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || limit < 0 {
+		log.Fatalf("invalid limit %q", value)
+	}
+	return sql.NullInt64{Int64: limit, Valid: true}
+}
+
+// setProduct creates or changes an account product. Products of the bank's own accounts cannot be changed
+func setProduct(store db.Store, code string, name string, annualRate string, dayCount string) {
+	_, err := util.ParseInterestRate(annualRate)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !util.IsDayCountSupport(dayCount) {
+		log.Fatalf("unknown day count convention %q", dayCount)
+	}
+
+	product, err := store.SetAccountProduct(context.Background(), db.SetAccountProductParams{
+		Code:       code,
+		Name:       name,
+		AnnualRate: annualRate,
+		DayCount:   dayCount,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Fatalf("product %q is internal", code)
+		}
+		log.Fatal("cannot set product:", err)
+	}
+	log.Printf("product %s pays %s a year, %s", product.Code, product.AnnualRate, product.DayCount)
+}
+
+// setTransferFee creates or changes a rule of the fee schedule
+func setTransferFee(store db.Store, currency string, scope string, flatFee string, rate string, minFee string, maxFee string) {
+	arg := db.SetTransferFeeParams{
+		Currency: feeCurrency(currency),
+		Scope:    feeScope(scope),
+		FlatFee:  parseFee(flatFee),
+		Rate:     rate,
+		MinFee:   parseFee(minFee),
+		MaxFee:   parseFee(maxFee),
+	}
+	_, err := util.ParseFeeRate(rate)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if arg.MaxFee > 0 && arg.MaxFee < arg.MinFee {
+		log.Fatalf("max fee %d is below min fee %d", arg.MaxFee, arg.MinFee)
+	}
+
+	_, err = store.SetTransferFee(context.Background(), arg)
+	if err != nil {
+		log.Fatal("cannot set fee:", err)
+	}
+	log.Printf("fee for %s transfers in %s is set", scope, currency)
+}
+
+// deleteTransferFee removes a rule of the fee schedule, so a less specific one applies
+func deleteTransferFee(store db.Store, currency string, scope string) {
+	n, err := store.DeleteTransferFee(context.Background(), db.DeleteTransferFeeParams{
+		Currency: feeCurrency(currency),
+		Scope:    feeScope(scope),
+	})
+	if err != nil {
+		log.Fatal("cannot delete fee:", err)
+	}
+	if n == 0 {
+		log.Fatalf("no fee for %s transfers in %s", scope, currency)
+	}
+	log.Printf("fee for %s transfers in %s is deleted", scope, currency)
+}
+
+// feeCurrency returns the currency of a fee rule, empty for any currency
+func feeCurrency(currency string) string {
+	if currency == "any" {
+		return ""
+	}
+	if !util.IsCurrencySupport(currency) {
+		log.Fatalf("unknown currency %q", currency)
+	}
+	return currency
+}
+
+func feeScope(scope string) string {
+	if !util.IsFeeScopeSupport(scope) {
+		log.Fatalf("unknown fee scope %q", scope)
+	}
+	return scope
+}
+
+func parseFee(value string) int64 {
+	fee, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || fee < 0 {
+		log.Fatalf("invalid fee %q", value)
+	}
+	return fee
+}
+
+// setCurrency adds a currency or enables or disables one. The minor units of a currency can't change,
+// as they give the meaning of every amount in it. Running servers pick the change up on their next refresh
+func setCurrency(store db.Store, code string, minorUnits string, enabled string) {
+	units, err := strconv.ParseInt(minorUnits, 10, 32)
+	if err != nil || units < 0 || units > 4 {
+		log.Fatalf("invalid minor units %q", minorUnits)
+	}
+	if enabled != "enabled" && enabled != "disabled" {
+		log.Fatalf("invalid state %q, expected enabled or disabled", enabled)
+	}
+
+	currency, err := store.SetCurrency(context.Background(), db.SetCurrencyParams{
+		Code:       code,
+		MinorUnits: int32(units),
+		Enabled:    enabled == "enabled",
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Fatalf("currency %s has other minor units", code)
+		}
+		log.Fatal("cannot set currency:", err)
+	}
+	log.Printf("currency %s with %d minor units is %s", currency.Code, currency.MinorUnits, enabled)
+}
+
+// reconcile prints every discrepancy of the ledger and exits with a non-zero status if there is any
+func reconcile(store db.Store) {
+	report, err := store.Reconcile(context.Background())
+	if err != nil {
+		log.Fatal("cannot reconcile:", err)
+	}
+
+	for _, m := range report.BalanceMismatches {
+		log.Printf("account %d: balance %d %s, entries sum to %d", m.AccountID, m.Balance, m.Currency, m.EntriesTotal)
+	}
+	for _, m := range report.TransferMismatches {
+		log.Printf("transfer %d: %d entries, %d matching debits, %d matching credits", m.TransferID, m.Entries, m.FromEntries, m.ToEntries)
+	}
+	for _, m := range report.CurrencyImbalances {
+		log.Printf("currency %s: entries net to %d", m.Currency, m.Net)
+	}
+
+	if !report.Balanced() {
+		log.Fatalf("found %d discrepancies", len(report.BalanceMismatches)+len(report.TransferMismatches)+len(report.CurrencyImbalances))
+	}
+	log.Print("ledger is balanced")
+}
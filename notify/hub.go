@@ -0,0 +1,166 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	db "simplebank/db/sqlc"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// subscriptionBuffer is the number of events a subscriber can fall behind before it misses some
+const subscriptionBuffer = 64
+
+// pingInterval is how long Run waits for a notification before it checks the connection
+const pingInterval = 90 * time.Second
+
+// Hub fans the account events received from Postgres out to the subscribers of each account
+type Hub struct {
+	mu            sync.Mutex
+	subscriptions map[int64]map[*Subscription]struct{}
+}
+
+// NewHub creates a hub without subscribers
+func NewHub() *Hub {
+	return &Hub{
+		subscriptions: make(map[int64]map[*Subscription]struct{}),
+	}
+}
+
+// Subscription receives the events of one account until it is closed
+type Subscription struct {
+	hub       *Hub
+	accountID int64
+	events    chan db.AccountEvent
+	missed    chan struct{}
+}
+
+// Events returns the channel of the account events
+func (sub *Subscription) Events() <-chan db.AccountEvent {
+	return sub.events
+}
+
+// Missed returns a channel that is signalled when events may have been lost, because the subscriber
+// fell behind or the connection to Postgres was re-established. The subscriber should then
+// read the entries it missed from the database
+func (sub *Subscription) Missed() <-chan struct{} {
+	return sub.missed
+}
+
+// Close stops the subscription
+func (sub *Subscription) Close() {
+	sub.hub.mu.Lock()
+	defer sub.hub.mu.Unlock()
+
+	subscriptions := sub.hub.subscriptions[sub.accountID]
+	delete(subscriptions, sub)
+	if len(subscriptions) == 0 {
+		delete(sub.hub.subscriptions, sub.accountID)
+	}
+}
+
+// Subscribe starts receiving the events of an account
+func (hub *Hub) Subscribe(accountID int64) *Subscription {
+	sub := &Subscription{
+		hub:       hub,
+		accountID: accountID,
+		events:    make(chan db.AccountEvent, subscriptionBuffer),
+		missed:    make(chan struct{}, 1),
+	}
+
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	if hub.subscriptions[accountID] == nil {
+		hub.subscriptions[accountID] = make(map[*Subscription]struct{})
+	}
+	hub.subscriptions[accountID][sub] = struct{}{}
+	return sub
+}
+
+// Publish sends an event to the subscribers of its account without blocking.
+// A subscriber with a full buffer doesn't get the event and is signalled that it missed some
+func (hub *Hub) Publish(event db.AccountEvent) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	for sub := range hub.subscriptions[event.AccountID] {
+		select {
+		case sub.events <- event:
+		default:
+			sub.signalMissed()
+		}
+	}
+}
+
+// Reset signals every subscriber that it may have missed events
+func (hub *Hub) Reset() {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	for _, subscriptions := range hub.subscriptions {
+		for sub := range subscriptions {
+			sub.signalMissed()
+		}
+	}
+}
+
+func (sub *Subscription) signalMissed() {
+	select {
+	case sub.missed <- struct{}{}:
+	default:
+	}
+}
+
+// Dispatch publishes an AccountEvent received as the payload of a notification
+func (hub *Hub) Dispatch(payload string) error {
+	var event db.AccountEvent
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		return err
+	}
+	hub.Publish(event)
+	return nil
+}
+
+// Listen connects to Postgres and listens on db.AccountEventsChannel.
+// The listener reconnects by itself if the connection is lost
+func Listen(dataSource string) (*pq.Listener, error) {
+	listener := pq.NewListener(dataSource, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("account events listener: %v", err)
+		}
+	})
+	if err := listener.Listen(db.AccountEventsChannel); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
+
+// Run dispatches the notifications of listener until ctx is done
+func (hub *Hub) Run(ctx context.Context, listener *pq.Listener) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notification := <-listener.Notify:
+			if notification == nil {
+				// the connection was re-established, notifications sent in between are lost
+				hub.Reset()
+				continue
+			}
+			if err := hub.Dispatch(notification.Extra); err != nil {
+				log.Printf("account events hub: cannot dispatch %q: %v", notification.Extra, err)
+			}
+		case <-time.After(pingInterval):
+			go func() {
+				if err := listener.Ping(); err != nil {
+					log.Printf("account events hub: ping failed: %v", err)
+				}
+			}()
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package notify
+
+import (
+	"encoding/json"
+	db "simplebank/db/sqlc"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHubPublish(t *testing.T) {
+	hub := NewHub()
+	sub1 := hub.Subscribe(1)
+	defer sub1.Close()
+	sub2 := hub.Subscribe(2)
+	defer sub2.Close()
+
+	event := db.AccountEvent{AccountID: 1, Entry: &db.Entry{ID: 10, AccountID: 1, Amount: 5}, Balance: 105}
+	hub.Publish(event)
+
+	select {
+	case received := <-sub1.Events():
+		require.Equal(t, event, received)
+	case <-time.After(time.Second):
+		t.Fatal("event wasn't received")
+	}
+	require.Empty(t, sub2.Events())
+	require.Empty(t, sub1.Missed())
+}
+
+func TestHubDispatch(t *testing.T) {
+	hub := NewHub()
+	sub := hub.Subscribe(1)
+	defer sub.Close()
+
+	event := db.AccountEvent{AccountID: 1, Entry: &db.Entry{ID: 10, AccountID: 1, Amount: -5}, Balance: 95, AvailableBalance: 90}
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	require.NoError(t, hub.Dispatch(string(payload)))
+	require.Equal(t, event.Entry.ID, (<-sub.Events()).Entry.ID)
+
+	require.Error(t, hub.Dispatch("not json"))
+}
+
+func TestHubSlowSubscriber(t *testing.T) {
+	hub := NewHub()
+	sub := hub.Subscribe(1)
+	defer sub.Close()
+
+	for i := 0; i <= subscriptionBuffer; i++ {
+		hub.Publish(db.AccountEvent{AccountID: 1, Entry: &db.Entry{ID: int64(i + 1)}})
+	}
+	require.Len(t, sub.Events(), subscriptionBuffer)
+	require.Len(t, sub.Missed(), 1)
+}
+
+func TestHubReset(t *testing.T) {
+	hub := NewHub()
+	sub1 := hub.Subscribe(1)
+	defer sub1.Close()
+	sub2 := hub.Subscribe(2)
+	defer sub2.Close()
+
+	hub.Reset()
+	hub.Reset()
+	require.Len(t, sub1.Missed(), 1)
+	require.Len(t, sub2.Missed(), 1)
+}
+
+func TestSubscriptionClose(t *testing.T) {
+	hub := NewHub()
+	sub := hub.Subscribe(1)
+	other := hub.Subscribe(1)
+
+	sub.Close()
+	hub.Publish(db.AccountEvent{AccountID: 1})
+	require.Empty(t, sub.Events())
+	require.Len(t, other.Events(), 1)
+
+	other.Close()
+	require.Empty(t, hub.subscriptions)
+}
@@ -0,0 +1,200 @@
+package statement
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	db "simplebank/db/sqlc"
+	"strconv"
+	"time"
+)
+
+// camt053Namespace is the namespace of the ISO 20022 bank to customer statement, version 2
+const camt053Namespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"
+
+type camtGroupHeader struct {
+	MsgID   string `xml:"MsgId"`
+	CreDtTm string `xml:"CreDtTm"`
+}
+
+type camtPeriod struct {
+	FrDtTm string `xml:"FrDtTm"`
+	ToDtTm string `xml:"ToDtTm"`
+}
+
+type camtAccountID struct {
+	Othr struct {
+		ID string `xml:"Id"`
+	} `xml:"Othr"`
+}
+
+type camtAccount struct {
+	ID   camtAccountID `xml:"Id"`
+	Ccy  string        `xml:"Ccy,omitempty"`
+	Ownr *camtParty    `xml:"Ownr,omitempty"`
+}
+
+type camtParty struct {
+	Nm string `xml:"Nm"`
+}
+
+type camtAmount struct {
+	Ccy   string `xml:"Ccy,attr"`
+	Value string `xml:",chardata"`
+}
+
+type camtDate struct {
+	DtTm string `xml:"DtTm"`
+}
+
+type camtBalance struct {
+	Tp struct {
+		CdOrPrtry struct {
+			Cd string `xml:"Cd"`
+		} `xml:"CdOrPrtry"`
+	} `xml:"Tp"`
+	Amt       camtAmount `xml:"Amt"`
+	CdtDbtInd string     `xml:"CdtDbtInd"`
+	Dt        camtDate   `xml:"Dt"`
+}
+
+type camtBankTransactionCode struct {
+	Domn struct {
+		Cd   string `xml:"Cd"`
+		Fmly struct {
+			Cd        string `xml:"Cd"`
+			SubFmlyCd string `xml:"SubFmlyCd"`
+		} `xml:"Fmly"`
+	} `xml:"Domn"`
+}
+
+type camtRelatedParties struct {
+	DbtrAcct *camtAccount `xml:"DbtrAcct,omitempty"`
+	CdtrAcct *camtAccount `xml:"CdtrAcct,omitempty"`
+}
+
+type camtTransactionDetails struct {
+	Refs struct {
+		AcctSvcrRef string `xml:"AcctSvcrRef"`
+	} `xml:"Refs"`
+	RltdPties camtRelatedParties `xml:"RltdPties"`
+}
+
+type camtEntry struct {
+	Amt         camtAmount              `xml:"Amt"`
+	CdtDbtInd   string                  `xml:"CdtDbtInd"`
+	Sts         string                  `xml:"Sts"`
+	BookgDt     camtDate                `xml:"BookgDt"`
+	ValDt       camtDate                `xml:"ValDt"`
+	AcctSvcrRef string                  `xml:"AcctSvcrRef"`
+	BkTxCd      camtBankTransactionCode `xml:"BkTxCd"`
+	NtryDtls    *camtEntryDetails       `xml:"NtryDtls,omitempty"`
+}
+
+type camtEntryDetails struct {
+	TxDtls camtTransactionDetails `xml:"TxDtls"`
+}
+
+// camt053Writer writes an ISO 20022 camt.053.001.02 statement with one Ntry per entry
+type camt053Writer struct {
+	enc     *xml.Encoder
+	w       io.Writer
+	summary db.StatementSummary
+}
+
+func newCAMT053Writer(w io.Writer) *camt053Writer {
+	return &camt053Writer{
+		w:   w,
+		enc: xml.NewEncoder(w),
+	}
+}
+
+func (writer *camt053Writer) Begin(summary db.StatementSummary) error {
+	writer.summary = summary
+	_, err := io.WriteString(writer.w, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	id := fmt.Sprintf("STMT-%d-%s-%s", summary.Account.ID, summary.From.UTC().Format("20060102"), summary.To.UTC().Format("20060102"))
+	now := formatTime(time.Now())
+	account := camtAccountWithID(summary.Account.ID)
+	account.Ccy = summary.Account.Currency
+	account.Ownr = &camtParty{Nm: summary.Account.Owner}
+
+	return encodeParts(writer.enc,
+		xml.StartElement{Name: xml.Name{Space: camt053Namespace, Local: "Document"}},
+		start("BkToCstmrStmt"),
+		element{"GrpHdr", camtGroupHeader{MsgID: id, CreDtTm: now}},
+		start("Stmt"),
+		element{"Id", id},
+		element{"CreDtTm", now},
+		element{"FrToDt", camtPeriod{FrDtTm: formatTime(summary.From), ToDtTm: formatTime(summary.To)}},
+		element{"Acct", account},
+		element{"Bal", writer.balance("OPBD", summary.OpeningBalance, summary.From)},
+		element{"Bal", writer.balance("CLBD", summary.ClosingBalance, summary.To)},
+	)
+}
+
+func (writer *camt053Writer) Entry(entry db.StatementEntry) error {
+	amount, indicator := camtAmountOf(writer.summary.Account.Currency, entry.Amount)
+	ntry := camtEntry{
+		Amt:         amount,
+		CdtDbtInd:   indicator,
+		Sts:         "BOOK",
+		BookgDt:     camtDate{DtTm: formatTime(entry.CreatedAt)},
+		ValDt:       camtDate{DtTm: formatTime(entry.CreatedAt)},
+		AcctSvcrRef: strconv.FormatInt(entry.ID, 10),
+	}
+	// internal book transfers, issued or received
+	ntry.BkTxCd.Domn.Cd = "PMNT"
+	ntry.BkTxCd.Domn.Fmly.Cd = "RCDT"
+	if indicator == "DBIT" {
+		ntry.BkTxCd.Domn.Fmly.Cd = "ICDT"
+	}
+	ntry.BkTxCd.Domn.Fmly.SubFmlyCd = "BOOK"
+
+	if entry.TransferID.Valid {
+		var details camtTransactionDetails
+		details.Refs.AcctSvcrRef = strconv.FormatInt(entry.TransferID.Int64, 10)
+		counterparty := camtAccountWithID(entry.CounterpartyAccountID)
+		if indicator == "DBIT" {
+			details.RltdPties.CdtrAcct = &counterparty
+		} else {
+			details.RltdPties.DbtrAcct = &counterparty
+		}
+		ntry.NtryDtls = &camtEntryDetails{TxDtls: details}
+	}
+
+	return encodeParts(writer.enc, element{"Ntry", ntry})
+}
+
+func (writer *camt053Writer) End() error {
+	return encodeParts(writer.enc,
+		end("Stmt"),
+		end("BkToCstmrStmt"),
+		xml.EndElement{Name: xml.Name{Space: camt053Namespace, Local: "Document"}},
+	)
+}
+
+func (writer *camt053Writer) balance(code string, balance int64, at time.Time) camtBalance {
+	var bal camtBalance
+	bal.Tp.CdOrPrtry.Cd = code
+	bal.Amt, bal.CdtDbtInd = camtAmountOf(writer.summary.Account.Currency, balance)
+	bal.Dt.DtTm = formatTime(at)
+	return bal
+}
+
+func camtAccountWithID(id int64) camtAccount {
+	var account camtAccount
+	account.ID.Othr.ID = strconv.FormatInt(id, 10)
+	return account
+}
+
+// camtAmountOf splits a signed amount into the non-negative amount and the credit/debit indicator camt expects
+func camtAmountOf(currency string, amount int64) (camtAmount, string) {
+	if amount < 0 {
+		return camtAmount{Ccy: currency, Value: formatAmount(-amount, currency)}, "DBIT"
+	}
+	return camtAmount{Ccy: currency, Value: formatAmount(amount, currency)}, "CRDT"
+}
@@ -0,0 +1,53 @@
+package statement
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCAMT053Writer(t *testing.T) {
+	data := writeTestStatement(t, CAMT053)
+	doc := parseXML(t, data, camt053Namespace, "Document")
+
+	stmt := find(doc, "BkToCstmrStmt/Stmt")[0]
+	require.Equal(t, "7", find(stmt, "Acct/Id/Othr/Id")[0].Text)
+	require.Equal(t, "USD", find(stmt, "Acct/Ccy")[0].Text)
+	require.Equal(t, "alice", find(stmt, "Acct/Ownr/Nm")[0].Text)
+
+	balances := find(stmt, "Bal")
+	require.Len(t, balances, 2)
+	require.Equal(t, "USD", attr(find(balances[0], "Amt")[0], "Ccy"))
+	require.Equal(t, "OPBD", find(balances[0], "Tp/CdOrPrtry/Cd")[0].Text)
+	require.Equal(t, "1.00", find(balances[0], "Amt")[0].Text)
+	require.Equal(t, "CRDT", find(balances[0], "CdtDbtInd")[0].Text)
+	require.Equal(t, "CLBD", find(balances[1], "Tp/CdOrPrtry/Cd")[0].Text)
+	require.Equal(t, "0.35", find(balances[1], "Amt")[0].Text)
+	require.Equal(t, "DBIT", find(balances[1], "CdtDbtInd")[0].Text)
+
+	entries := find(stmt, "Ntry")
+	require.Len(t, entries, len(testEntries()))
+
+	debit := entries[0]
+	require.Equal(t, "USD", attr(find(debit, "Amt")[0], "Ccy"))
+	require.Equal(t, "BOOK", find(debit, "Sts")[0].Text)
+	require.Equal(t, "PMNT", find(debit, "BkTxCd/Domn/Cd")[0].Text)
+	require.Equal(t, "ICDT", find(debit, "BkTxCd/Domn/Fmly/Cd")[0].Text)
+	require.Equal(t, "1.50", find(debit, "Amt")[0].Text)
+	require.Equal(t, "DBIT", find(debit, "CdtDbtInd")[0].Text)
+	require.Equal(t, "1", find(debit, "AcctSvcrRef")[0].Text)
+	require.Equal(t, "9", find(debit, "NtryDtls/TxDtls/Refs/AcctSvcrRef")[0].Text)
+	require.Equal(t, "8", find(debit, "NtryDtls/TxDtls/RltdPties/CdtrAcct/Id/Othr/Id")[0].Text)
+
+	credit := entries[1]
+	require.Equal(t, "CRDT", find(credit, "CdtDbtInd")[0].Text)
+	require.Equal(t, "RCDT", find(credit, "BkTxCd/Domn/Fmly/Cd")[0].Text)
+	require.Equal(t, "3", find(credit, "NtryDtls/TxDtls/RltdPties/DbtrAcct/Id/Othr/Id")[0].Text)
+
+	// an entry without a transfer has no details
+	require.Empty(t, find(entries[2], "NtryDtls"))
+
+	t.Run("Schema", func(t *testing.T) {
+		require.NoError(t, validateXML(t, data, camt053Schema))
+	})
+}
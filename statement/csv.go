@@ -0,0 +1,67 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	db "simplebank/db/sqlc"
+	"strconv"
+	"time"
+)
+
+// csvWriter writes a row per entry between an opening and a closing balance row
+type csvWriter struct {
+	w       *csv.Writer
+	summary db.StatementSummary
+}
+
+var csvHeader = []string{"date", "type", "entry_id", "transfer_id", "counterparty_account_id", "amount", "balance", "currency"}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (writer *csvWriter) Begin(summary db.StatementSummary) error {
+	writer.summary = summary
+	err := writer.w.Write(csvHeader)
+	if err != nil {
+		return err
+	}
+	return writer.balance(summary.From, "opening_balance", summary.OpeningBalance)
+}
+
+func (writer *csvWriter) Entry(entry db.StatementEntry) error {
+	var transferID, counterparty string
+	if entry.TransferID.Valid {
+		transferID = strconv.FormatInt(entry.TransferID.Int64, 10)
+		counterparty = strconv.FormatInt(entry.CounterpartyAccountID, 10)
+	}
+	return writer.w.Write([]string{
+		formatTime(entry.CreatedAt),
+		"entry",
+		strconv.FormatInt(entry.ID, 10),
+		transferID,
+		counterparty,
+		formatAmount(entry.Amount, writer.summary.Account.Currency),
+		formatAmount(entry.Balance, writer.summary.Account.Currency),
+		writer.summary.Account.Currency,
+	})
+}
+
+func (writer *csvWriter) End() error {
+	err := writer.balance(writer.summary.To, "closing_balance", writer.summary.ClosingBalance)
+	if err != nil {
+		return err
+	}
+	writer.w.Flush()
+	return writer.w.Error()
+}
+
+func (writer *csvWriter) balance(date time.Time, kind string, balance int64) error {
+	return writer.w.Write([]string{
+		formatTime(date),
+		kind,
+		"", "", "", "",
+		formatAmount(balance, writer.summary.Account.Currency),
+		writer.summary.Account.Currency,
+	})
+}
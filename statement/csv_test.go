@@ -0,0 +1,55 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"simplebank/util"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestCSVWriter checks the statement against the rules of RFC 4180 as implemented by encoding/csv
+// and that the balances add up
+func TestCSVWriter(t *testing.T) {
+	data := writeTestStatement(t, CSV)
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = len(csvHeader)
+	records, err := r.ReadAll()
+	require.NoError(t, err)
+
+	entries := testEntries()
+	require.Len(t, records, len(entries)+3)
+	require.Equal(t, csvHeader, records[0])
+
+	opening := records[1]
+	require.Equal(t, "opening_balance", opening[1])
+	require.Equal(t, "1.00", opening[6])
+	closing := records[len(records)-1]
+	require.Equal(t, "closing_balance", closing[1])
+	require.Equal(t, "-0.35", closing[6])
+
+	balance, err := util.ParseAmount(opening[6], 2)
+	require.NoError(t, err)
+	for i, record := range records[2 : len(records)-1] {
+		require.Equal(t, "entry", record[1])
+		require.Equal(t, "USD", record[7])
+		_, err := time.Parse(time.RFC3339, record[0])
+		require.NoError(t, err)
+
+		amount, err := util.ParseAmount(record[5], 2)
+		require.NoError(t, err)
+		balance += amount
+		require.Equal(t, util.FormatAmount(balance, 2), record[6])
+		require.Equal(t, strconv.FormatInt(entries[i].ID, 10), record[2])
+	}
+	require.Equal(t, closing[6], util.FormatAmount(balance, 2))
+
+	// an entry without a transfer has no counterparty
+	last := records[len(records)-2]
+	require.Empty(t, last[3])
+	require.Empty(t, last[4])
+}
@@ -0,0 +1,152 @@
+package statement
+
+import (
+	"encoding/xml"
+	"io"
+	db "simplebank/db/sqlc"
+	"strconv"
+	"time"
+)
+
+// ofxHeader is the header of an OFX 2.2 document, which is XML
+const ofxHeader = xml.Header + `<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>` + "\n"
+
+type ofxStatus struct {
+	Code     int    `xml:"CODE"`
+	Severity string `xml:"SEVERITY"`
+}
+
+type ofxSignon struct {
+	Status   ofxStatus `xml:"STATUS"`
+	DTServer string    `xml:"DTSERVER"`
+	Language string    `xml:"LANGUAGE"`
+}
+
+type ofxBankAccount struct {
+	BankID   string `xml:"BANKID"`
+	AcctID   string `xml:"ACCTID"`
+	AcctType string `xml:"ACCTTYPE"`
+}
+
+type ofxTransaction struct {
+	TrnType    string          `xml:"TRNTYPE"`
+	DTPosted   string          `xml:"DTPOSTED"`
+	TrnAmt     string          `xml:"TRNAMT"`
+	FitID      string          `xml:"FITID"`
+	RefNum     string          `xml:"REFNUM,omitempty"`
+	BankAcctTo *ofxBankAccount `xml:"BANKACCTTO,omitempty"`
+}
+
+type ofxLedgerBalance struct {
+	BalAmt string `xml:"BALAMT"`
+	DTAsOf string `xml:"DTASOF"`
+}
+
+type ofxBalance struct {
+	Name    string `xml:"NAME"`
+	Desc    string `xml:"DESC"`
+	BalType string `xml:"BALTYPE"`
+	Value   string `xml:"VALUE"`
+	DTAsOf  string `xml:"DTASOF"`
+}
+
+// ofxWriter writes an OFX 2.2 bank statement response. The closing balance is the ledger balance,
+// the opening balance goes to the balance list
+type ofxWriter struct {
+	w       io.Writer
+	enc     *xml.Encoder
+	summary db.StatementSummary
+}
+
+func newOFXWriter(w io.Writer) *ofxWriter {
+	return &ofxWriter{
+		w:   w,
+		enc: xml.NewEncoder(w),
+	}
+}
+
+func (writer *ofxWriter) Begin(summary db.StatementSummary) error {
+	writer.summary = summary
+	_, err := io.WriteString(writer.w, ofxHeader)
+	if err != nil {
+		return err
+	}
+
+	status := ofxStatus{Code: 0, Severity: "INFO"}
+	return writer.encode(
+		start("OFX"),
+		start("SIGNONMSGSRSV1"),
+		element{"SONRS", ofxSignon{Status: status, DTServer: ofxTime(time.Now()), Language: "ENG"}},
+		end("SIGNONMSGSRSV1"),
+		start("BANKMSGSRSV1"),
+		start("STMTTRNRS"),
+		element{"TRNUID", "0"},
+		element{"STATUS", status},
+		start("STMTRS"),
+		element{"CURDEF", summary.Account.Currency},
+		element{"BANKACCTFROM", ofxAccount(summary.Account.ID)},
+		start("BANKTRANLIST"),
+		element{"DTSTART", ofxTime(summary.From)},
+		element{"DTEND", ofxTime(summary.To)},
+	)
+}
+
+func (writer *ofxWriter) Entry(entry db.StatementEntry) error {
+	transaction := ofxTransaction{
+		TrnType:  "XFER",
+		DTPosted: ofxTime(entry.CreatedAt),
+		TrnAmt:   formatAmount(entry.Amount, writer.summary.Account.Currency),
+		FitID:    strconv.FormatInt(entry.ID, 10),
+	}
+	if entry.TransferID.Valid {
+		account := ofxAccount(entry.CounterpartyAccountID)
+		transaction.RefNum = strconv.FormatInt(entry.TransferID.Int64, 10)
+		transaction.BankAcctTo = &account
+	} else if entry.Amount < 0 {
+		transaction.TrnType = "DEBIT"
+	} else {
+		transaction.TrnType = "CREDIT"
+	}
+	return writer.encode(element{"STMTTRN", transaction})
+}
+
+func (writer *ofxWriter) End() error {
+	summary := writer.summary
+	return writer.encode(
+		end("BANKTRANLIST"),
+		element{"LEDGERBAL", ofxLedgerBalance{
+			BalAmt: formatAmount(summary.ClosingBalance, summary.Account.Currency),
+			DTAsOf: ofxTime(summary.To),
+		}},
+		start("BALLIST"),
+		element{"BAL", ofxBalance{
+			Name:    "Opening balance",
+			Desc:    "Balance at the start of the statement",
+			BalType: "DOLLAR",
+			Value:   formatAmount(summary.OpeningBalance, summary.Account.Currency),
+			DTAsOf:  ofxTime(summary.From),
+		}},
+		end("BALLIST"),
+		end("STMTRS"),
+		end("STMTTRNRS"),
+		end("BANKMSGSRSV1"),
+		end("OFX"),
+	)
+}
+
+func (writer *ofxWriter) encode(parts ...interface{}) error {
+	return encodeParts(writer.enc, parts...)
+}
+
+func ofxAccount(id int64) ofxBankAccount {
+	return ofxBankAccount{
+		BankID:   bankID,
+		AcctID:   strconv.FormatInt(id, 10),
+		AcctType: "CHECKING",
+	}
+}
+
+// ofxTime formats t as an OFX datetime in GMT
+func ofxTime(t time.Time) string {
+	return t.UTC().Format("20060102150405.000") + "[0:GMT]"
+}
@@ -0,0 +1,58 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOFXWriter(t *testing.T) {
+	data := writeTestStatement(t, OFX)
+
+	// the OFX processing instruction comes before the root element
+	var header *xml.ProcInst
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for header == nil {
+		token, err := dec.Token()
+		require.NoError(t, err)
+		_, isStart := token.(xml.StartElement)
+		require.False(t, isStart, "no OFX header")
+		if inst, ok := token.(xml.ProcInst); ok && inst.Target == "OFX" {
+			header = &inst
+		}
+	}
+	require.Contains(t, string(header.Inst), `OFXHEADER="200"`)
+	require.Contains(t, string(header.Inst), `VERSION="220"`)
+
+	doc := parseXML(t, data, "", "OFX")
+
+	require.Equal(t, "0", find(doc, "BANKMSGSRSV1/STMTTRNRS/STATUS/CODE")[0].Text)
+
+	stmt := "BANKMSGSRSV1/STMTTRNRS/STMTRS/"
+	require.Equal(t, "USD", find(doc, stmt+"CURDEF")[0].Text)
+	require.Equal(t, "7", find(doc, stmt+"BANKACCTFROM/ACCTID")[0].Text)
+	require.Equal(t, "-0.35", find(doc, stmt+"LEDGERBAL/BALAMT")[0].Text)
+	require.Equal(t, "1.00", find(doc, stmt+"BALLIST/BAL/VALUE")[0].Text)
+	require.True(t, strings.HasPrefix(find(doc, stmt+"BANKTRANLIST/DTSTART")[0].Text, "20220301000000"))
+	require.True(t, strings.HasPrefix(find(doc, stmt+"BANKTRANLIST/DTEND")[0].Text, "20220304000000"))
+
+	transactions := find(doc, stmt+"BANKTRANLIST/STMTTRN")
+	entries := testEntries()
+	require.Len(t, transactions, len(entries))
+
+	require.Equal(t, "XFER", find(transactions[0], "TRNTYPE")[0].Text)
+	require.Equal(t, "-1.50", find(transactions[0], "TRNAMT")[0].Text)
+	require.Equal(t, "1", find(transactions[0], "FITID")[0].Text)
+	require.Equal(t, "9", find(transactions[0], "REFNUM")[0].Text)
+	require.Equal(t, "8", find(transactions[0], "BANKACCTTO/ACCTID")[0].Text)
+
+	require.Equal(t, "CREDIT", find(transactions[2], "TRNTYPE")[0].Text)
+	require.Empty(t, find(transactions[2], "BANKACCTTO"))
+
+	t.Run("Schema", func(t *testing.T) {
+		require.NoError(t, validateXML(t, data, ofxSchema))
+	})
+}
@@ -0,0 +1,71 @@
+// Package statement writes account statements in the formats accounting tools import
+package statement
+
+import (
+	"fmt"
+	"io"
+	db "simplebank/db/sqlc"
+	"simplebank/util"
+	"time"
+)
+
+// supported statement formats
+const (
+	CSV     = "csv"
+	OFX     = "ofx"
+	CAMT053 = "camt053"
+)
+
+// bankID identifies the bank in the formats that require it
+const bankID = "SIMPLBANK"
+
+func IsFormatSupport(format string) bool {
+	switch format {
+	case CSV, OFX, CAMT053:
+		return true
+	}
+	return false
+}
+
+// NewWriter returns a writer of the statement in format to w
+func NewWriter(format string, w io.Writer) (db.StatementWriter, error) {
+	switch format {
+	case CSV:
+		return newCSVWriter(w), nil
+	case OFX:
+		return newOFXWriter(w), nil
+	case CAMT053:
+		return newCAMT053Writer(w), nil
+	}
+	return nil, fmt.Errorf("unsupported statement format %q", format)
+}
+
+// ContentType returns the media type of a statement in format
+func ContentType(format string) string {
+	switch format {
+	case CSV:
+		return "text/csv"
+	case OFX:
+		return "application/x-ofx"
+	}
+	return "application/xml"
+}
+
+// FileName returns the name to save the statement of an account under
+func FileName(format string, summary db.StatementSummary) string {
+	ext := format
+	if format == CAMT053 {
+		ext = "xml"
+	}
+	return fmt.Sprintf("statement-%d-%s-%s.%s", summary.Account.ID,
+		summary.From.UTC().Format("20060102"), summary.To.UTC().Format("20060102"), ext)
+}
+
+// formatAmount formats an amount in minor units as a decimal in the currency, like "1.50" USD or "150" JPY
+func formatAmount(amount int64, currency string) string {
+	return util.Money{Amount: amount, Currency: currency}.String()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,110 @@
+package statement
+
+import (
+	"bytes"
+	"database/sql"
+	db "simplebank/db/sqlc"
+	"simplebank/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testFrom = time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func testSummary() db.StatementSummary {
+	return db.StatementSummary{
+		Account: db.Account{
+			ID:       7,
+			Owner:    "alice",
+			Currency: "USD",
+		},
+		From:           testFrom,
+		To:             testFrom.AddDate(0, 0, 3),
+		OpeningBalance: 100,
+		ClosingBalance: -35,
+	}
+}
+
+func testEntries() []db.StatementEntry {
+	return []db.StatementEntry{
+		{
+			ListStatementEntriesRow: db.ListStatementEntriesRow{
+				ID:                    1,
+				Amount:                -150,
+				CreatedAt:             testFrom.Add(time.Hour),
+				TransferID:            sql.NullInt64{Int64: 9, Valid: true},
+				CounterpartyAccountID: 8,
+			},
+			Balance: -50,
+		},
+		{
+			ListStatementEntriesRow: db.ListStatementEntriesRow{
+				ID:                    2,
+				Amount:                10,
+				CreatedAt:             testFrom.Add(30 * time.Hour),
+				TransferID:            sql.NullInt64{Int64: 10, Valid: true},
+				CounterpartyAccountID: 3,
+			},
+			Balance: -40,
+		},
+		{
+			ListStatementEntriesRow: db.ListStatementEntriesRow{
+				ID:        3,
+				Amount:    5,
+				CreatedAt: testFrom.Add(50 * time.Hour),
+			},
+			Balance: -35,
+		},
+	}
+}
+
+// writeTestStatement writes the test statement in format
+func writeTestStatement(t *testing.T, format string) []byte {
+	var buf bytes.Buffer
+	w, err := NewWriter(format, &buf)
+	require.NoError(t, err)
+
+	require.NoError(t, w.Begin(testSummary()))
+	for _, entry := range testEntries() {
+		require.NoError(t, w.Entry(entry))
+	}
+	require.NoError(t, w.End())
+	return buf.Bytes()
+}
+
+func TestNewWriter(t *testing.T) {
+	for _, format := range []string{CSV, OFX, CAMT053} {
+		require.True(t, IsFormatSupport(format))
+		_, err := NewWriter(format, &bytes.Buffer{})
+		require.NoError(t, err)
+	}
+
+	require.False(t, IsFormatSupport("pdf"))
+	_, err := NewWriter("pdf", &bytes.Buffer{})
+	require.Error(t, err)
+}
+
+func TestFileName(t *testing.T) {
+	require.Equal(t, "statement-7-20220301-20220304.csv", FileName(CSV, testSummary()))
+	require.Equal(t, "statement-7-20220301-20220304.ofx", FileName(OFX, testSummary()))
+	require.Equal(t, "statement-7-20220301-20220304.xml", FileName(CAMT053, testSummary()))
+}
+
+func TestFormatAmount(t *testing.T) {
+	util.SetCurrencies([]util.Currency{
+		{Code: util.USD, MinorUnits: 2, Enabled: true},
+		{Code: "JPY", MinorUnits: 0, Enabled: true},
+		{Code: "KWD", MinorUnits: 3, Enabled: true},
+	})
+	defer util.SetCurrencies([]util.Currency{
+		{Code: util.USD, MinorUnits: 2, Enabled: true},
+		{Code: util.EUR, MinorUnits: 2, Enabled: true},
+	})
+
+	require.Equal(t, "1.50", formatAmount(150, util.USD))
+	require.Equal(t, "-0.05", formatAmount(-5, util.USD))
+	require.Equal(t, "150", formatAmount(150, "JPY"))
+	require.Equal(t, "0.150", formatAmount(150, "KWD"))
+}
@@ -0,0 +1,37 @@
+package statement
+
+import "encoding/xml"
+
+// element is a complete element of a streamed XML document
+type element struct {
+	name  string
+	value interface{}
+}
+
+func start(name string) xml.StartElement {
+	return xml.StartElement{Name: xml.Name{Local: name}}
+}
+
+func end(name string) xml.EndElement {
+	return xml.EndElement{Name: xml.Name{Local: name}}
+}
+
+// encodeParts writes start and end tags and elements in order and flushes them,
+// so a document can be written while its entries are read
+func encodeParts(enc *xml.Encoder, parts ...interface{}) error {
+	for _, part := range parts {
+		var err error
+		switch part := part.(type) {
+		case element:
+			err = enc.EncodeElement(part.value, start(part.name))
+		case xml.StartElement:
+			err = enc.EncodeToken(part)
+		case xml.EndElement:
+			err = enc.EncodeToken(part)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return enc.Flush()
+}
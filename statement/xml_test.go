@@ -0,0 +1,101 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// xmlNode is any element of a parsed document
+type xmlNode struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Nodes   []xmlNode  `xml:",any"`
+	Text    string     `xml:",chardata"`
+}
+
+// parseXML parses data and checks the root element and its namespace
+func parseXML(t *testing.T, data []byte, namespace, root string) xmlNode {
+	var doc xmlNode
+	require.NoError(t, xml.NewDecoder(bytes.NewReader(data)).Decode(&doc))
+	require.Equal(t, xml.Name{Space: namespace, Local: root}, doc.XMLName)
+	return doc
+}
+
+// find returns the descendants of node at path
+func find(node xmlNode, path string) []xmlNode {
+	nodes := []xmlNode{node}
+	for _, name := range strings.Split(path, "/") {
+		var children []xmlNode
+		for _, n := range nodes {
+			for _, child := range n.Nodes {
+				if child.XMLName.Local == name {
+					children = append(children, child)
+				}
+			}
+		}
+		nodes = children
+	}
+	return nodes
+}
+
+// attr returns the value of the attribute of node
+func attr(node xmlNode, name string) string {
+	for _, a := range node.Attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// camt053Schema and ofxSchema are the schemas the statements are validated against
+var (
+	camt053Schema = filepath.Join("testdata", "camt.053.001.02.xsd")
+	ofxSchema     = filepath.Join("testdata", "ofx-2.2-bank-statement.xsd")
+)
+
+// validateXML validates data against the XSD schema with xmllint. There is no schema validator
+// in the standard library, so the check is skipped where xmllint isn't installed
+func validateXML(t *testing.T, data []byte, schema string) error {
+	path, err := exec.LookPath("xmllint")
+	if err != nil {
+		t.Skip("xmllint is not installed")
+	}
+	cmd := exec.Command(path, "--noout", "--schema", schema, "-")
+	cmd.Stdin = bytes.NewReader(data)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, out)
+	}
+	return nil
+}
+
+func TestValidateXMLRejectsInvalidStatements(t *testing.T) {
+	camt := string(writeTestStatement(t, CAMT053))
+	ofx := string(writeTestStatement(t, OFX))
+
+	invalid := []struct {
+		name   string
+		schema string
+		data   string
+	}{
+		// camt.053 amounts are never negative, the sign is in CdtDbtInd
+		{"NegativeAmount", camt053Schema, strings.Replace(camt, `<Amt Ccy="USD">1.50`, `<Amt Ccy="USD">-1.50`, 1)},
+		{"MissingStatus", camt053Schema, strings.Replace(camt, "<Sts>BOOK</Sts>", "", 1)},
+		{"BalanceType", camt053Schema, strings.Replace(camt, "<Cd>OPBD</Cd>", "<Cd>OPEN</Cd>", 1)},
+		{"DecimalComma", ofxSchema, strings.Replace(ofx, "<TRNAMT>-1.50", "<TRNAMT>-1,50", 1)},
+		{"MissingLedgerBalance", ofxSchema, ofx[:strings.Index(ofx, "<LEDGERBAL>")] + ofx[strings.Index(ofx, "</LEDGERBAL>")+len("</LEDGERBAL>"):]},
+	}
+	for _, tc := range invalid {
+		require.NotEqual(t, camt, tc.data, tc.name)
+		require.NotEqual(t, ofx, tc.data, tc.name)
+		require.Error(t, validateXML(t, []byte(tc.data), tc.schema), tc.name)
+	}
+}
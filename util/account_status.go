@@ -0,0 +1,30 @@
+package util
+
+// statuses of an account
+const (
+	AccountActive = "active"
+	// a frozen account may receive money, but not pay
+	AccountFrozen = "frozen"
+	// a closed account can't move money, and can't be reopened
+	AccountClosed = "closed"
+)
+
+func IsAccountStatusSupport(status string) bool {
+	switch status {
+	case AccountActive, AccountFrozen, AccountClosed:
+		return true
+	}
+	return false
+}
+
+// CanChangeAccountStatus returns true if an account can go from one status to the other.
+// Active and frozen accounts swap freely, only an active account can be closed
+func CanChangeAccountStatus(from, to string) bool {
+	switch from {
+	case AccountActive:
+		return to == AccountFrozen || to == AccountClosed
+	case AccountFrozen:
+		return to == AccountActive
+	}
+	return false
+}
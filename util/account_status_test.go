@@ -0,0 +1,29 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanChangeAccountStatus(t *testing.T) {
+	testCases := []struct {
+		from    string
+		to      string
+		allowed bool
+	}{
+		{from: AccountActive, to: AccountFrozen, allowed: true},
+		{from: AccountActive, to: AccountClosed, allowed: true},
+		{from: AccountActive, to: AccountActive, allowed: false},
+		{from: AccountFrozen, to: AccountActive, allowed: true},
+		{from: AccountFrozen, to: AccountClosed, allowed: false},
+		{from: AccountFrozen, to: AccountFrozen, allowed: false},
+		{from: AccountClosed, to: AccountActive, allowed: false},
+		{from: AccountClosed, to: AccountFrozen, allowed: false},
+		{from: AccountActive, to: "deleted", allowed: false},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.allowed, CanChangeAccountStatus(tc.from, tc.to), "%s -> %s", tc.from, tc.to)
+	}
+}
@@ -7,11 +7,31 @@ import (
 )
 
 type Config struct {
-	DBDriver            string        `mapstructure:"DB_DRIVER"`
-	DBSource            string        `mapstructure:"DB_SOURCE"`
-	ServerAddress       string        `mapstructure:"SERVER_ADDRESS"`
-	TokenSymmetricKey   string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
-	AccessTokenDuration time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	DBDriver                  string        `mapstructure:"DB_DRIVER"`
+	DBSource                  string        `mapstructure:"DB_SOURCE"`
+	ServerAddress             string        `mapstructure:"SERVER_ADDRESS"`
+	TokenSymmetricKey         string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	AccessTokenDuration       time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	ScheduledTransferInterval time.Duration `mapstructure:"SCHEDULED_TRANSFER_INTERVAL"`
+	StandingOrderInterval     time.Duration `mapstructure:"STANDING_ORDER_INTERVAL"`
+	HoldDuration              time.Duration `mapstructure:"HOLD_DURATION"`
+	HoldExpiryInterval        time.Duration `mapstructure:"HOLD_EXPIRY_INTERVAL"`
+	DailyTransferLimit        int64         `mapstructure:"DAILY_TRANSFER_LIMIT"`
+	MonthlyTransferLimit      int64         `mapstructure:"MONTHLY_TRANSFER_LIMIT"`
+	BalanceSnapshotInterval   time.Duration `mapstructure:"BALANCE_SNAPSHOT_INTERVAL"`
+	InterestInterval          time.Duration `mapstructure:"INTEREST_INTERVAL"`
+	CurrencyRefreshInterval   time.Duration `mapstructure:"CURRENCY_REFRESH_INTERVAL"`
+	TxMaxRetries              int           `mapstructure:"TX_MAX_RETRIES"`
+	TxRetryBaseDelay          time.Duration `mapstructure:"TX_RETRY_BASE_DELAY"`
+	TxRetryMaxDelay           time.Duration `mapstructure:"TX_RETRY_MAX_DELAY"`
+	OutboxRelayInterval       time.Duration `mapstructure:"OUTBOX_RELAY_INTERVAL"`
+	WebhookInterval           time.Duration `mapstructure:"WEBHOOK_INTERVAL"`
+	WebhookTimeout            time.Duration `mapstructure:"WEBHOOK_TIMEOUT"`
+	WebhookMaxAttempts        int32         `mapstructure:"WEBHOOK_MAX_ATTEMPTS"`
+	WebhookRetryDelay         time.Duration `mapstructure:"WEBHOOK_RETRY_DELAY"`
+	AccountEventsHeartbeat    time.Duration `mapstructure:"ACCOUNT_EVENTS_HEARTBEAT"`
+	FraudRulesFile            string        `mapstructure:"FRAUD_RULES_FILE"`
+	FraudRulesRefreshInterval time.Duration `mapstructure:"FRAUD_RULES_REFRESH_INTERVAL"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -1,14 +1,55 @@
 package util
 
+import "sync"
+
 const (
 	USD = "USD"
 	EUR = "EUR"
 )
 
-func IsCurrencySupport(currency string) bool {
-	switch currency {
-	case USD, EUR:
-		return true
+// Currency is an entry of the currency registry
+type Currency struct {
+	Code string
+	// MinorUnits is the number of decimals of an amount, 2 for USD, 0 for JPY
+	MinorUnits int32
+	// Enabled currencies are accepted for new accounts, transfers and rates
+	Enabled bool
+}
+
+// currencies is the registry of known currencies. It starts with USD and EUR
+// until SetCurrencies loads the currencies table
+var currencies = struct {
+	sync.RWMutex
+	byCode map[string]Currency
+}{
+	byCode: map[string]Currency{
+		USD: {Code: USD, MinorUnits: 2, Enabled: true},
+		EUR: {Code: EUR, MinorUnits: 2, Enabled: true},
+	},
+}
+
+// SetCurrencies replaces the currency registry
+func SetCurrencies(list []Currency) {
+	byCode := make(map[string]Currency, len(list))
+	for _, currency := range list {
+		byCode[currency.Code] = currency
 	}
-	return false
+
+	currencies.Lock()
+	defer currencies.Unlock()
+	currencies.byCode = byCode
+}
+
+// LookupCurrency returns a currency of the registry, enabled or not
+func LookupCurrency(code string) (Currency, bool) {
+	currencies.RLock()
+	defer currencies.RUnlock()
+	currency, ok := currencies.byCode[code]
+	return currency, ok
+}
+
+// IsCurrencySupport returns true if the currency is in the registry and enabled
+func IsCurrencySupport(currency string) bool {
+	c, ok := LookupCurrency(currency)
+	return ok && c.Enabled
 }
@@ -0,0 +1,41 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetCurrencies(t *testing.T) {
+	require.True(t, IsCurrencySupport(USD))
+	require.True(t, IsCurrencySupport(EUR))
+	require.False(t, IsCurrencySupport("JPY"))
+
+	SetCurrencies([]Currency{
+		{Code: USD, MinorUnits: 2, Enabled: true},
+		{Code: EUR, MinorUnits: 2, Enabled: false},
+		{Code: "JPY", MinorUnits: 0, Enabled: true},
+		{Code: "KWD", MinorUnits: 3, Enabled: true},
+	})
+	defer SetCurrencies([]Currency{
+		{Code: USD, MinorUnits: 2, Enabled: true},
+		{Code: EUR, MinorUnits: 2, Enabled: true},
+	})
+
+	require.True(t, IsCurrencySupport(USD))
+	require.False(t, IsCurrencySupport(EUR))
+	require.True(t, IsCurrencySupport("JPY"))
+	require.False(t, IsCurrencySupport("GBP"))
+
+	// a disabled currency still formats the amounts of its accounts
+	eur, ok := LookupCurrency(EUR)
+	require.True(t, ok)
+	require.Equal(t, int32(2), eur.MinorUnits)
+
+	kwd, ok := LookupCurrency("KWD")
+	require.True(t, ok)
+	require.Equal(t, int32(3), kwd.MinorUnits)
+
+	_, ok = LookupCurrency("GBP")
+	require.False(t, ok)
+}
@@ -0,0 +1,56 @@
+package util
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// scopes of a fee rule: every transfer, transfers between accounts of the same user, or to someone else
+const (
+	FeeScopeAny   = "any"
+	FeeScopeOwn   = "own"
+	FeeScopeOther = "other"
+)
+
+// IsFeeScopeSupport returns true if the fee scope is supported
+func IsFeeScopeSupport(scope string) bool {
+	switch scope {
+	case FeeScopeAny, FeeScopeOwn, FeeScopeOther:
+		return true
+	}
+	return false
+}
+
+// ParseFeeRate parses a decimal share of the amount charged as a fee, which must not be negative
+func ParseFeeRate(rate string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok {
+		return nil, fmt.Errorf("invalid fee rate %q", rate)
+	}
+	if r.Sign() < 0 {
+		return nil, fmt.Errorf("fee rate must not be negative: %s", rate)
+	}
+	return r, nil
+}
+
+// TransferFee returns the flat fee plus rate times amount, rounded half away from zero,
+// then raised to minFee and capped at maxFee. A maxFee of 0 means no cap
+func TransferFee(amount int64, flatFee int64, rate string, minFee int64, maxFee int64) (int64, error) {
+	r, err := ParseFeeRate(rate)
+	if err != nil {
+		return 0, err
+	}
+
+	x := new(big.Rat).Mul(new(big.Rat).SetInt64(amount), r)
+	fee, err := roundRat(x.Add(x, new(big.Rat).SetInt64(flatFee)))
+	if err != nil {
+		return 0, err
+	}
+	if fee < minFee {
+		fee = minFee
+	}
+	if maxFee > 0 && fee > maxFee {
+		fee = maxFee
+	}
+	return fee, nil
+}
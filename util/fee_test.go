@@ -0,0 +1,50 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferFee(t *testing.T) {
+	testCases := []struct {
+		name    string
+		amount  int64
+		flatFee int64
+		rate    string
+		minFee  int64
+		maxFee  int64
+		fee     int64
+	}{
+		{name: "Free", amount: 1000, rate: "0", fee: 0},
+		{name: "Flat", amount: 1000, flatFee: 25, rate: "0", fee: 25},
+		{name: "Rate", amount: 1000, rate: "0.015", fee: 15},
+		{name: "RoundHalfUp", amount: 150, rate: "0.01", fee: 2},
+		{name: "RoundDown", amount: 149, rate: "0.01", fee: 1},
+		{name: "FlatAndRate", amount: 1000, flatFee: 10, rate: "0.01", fee: 20},
+		{name: "Min", amount: 100, rate: "0.01", minFee: 5, fee: 5},
+		{name: "Max", amount: 100000, rate: "0.01", maxFee: 300, fee: 300},
+		{name: "NoMax", amount: 100000, rate: "0.01", fee: 1000},
+		{name: "MinAboveRate", amount: 1000, flatFee: 1, rate: "0.001", minFee: 3, maxFee: 10, fee: 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fee, err := TransferFee(tc.amount, tc.flatFee, tc.rate, tc.minFee, tc.maxFee)
+			require.NoError(t, err)
+			require.Equal(t, tc.fee, fee)
+		})
+	}
+
+	_, err := TransferFee(1000, 0, "-0.01", 0, 0)
+	require.Error(t, err)
+	_, err = TransferFee(1000, 0, "one percent", 0, 0)
+	require.Error(t, err)
+}
+
+func TestIsFeeScopeSupport(t *testing.T) {
+	require.True(t, IsFeeScopeSupport(FeeScopeAny))
+	require.True(t, IsFeeScopeSupport(FeeScopeOwn))
+	require.True(t, IsFeeScopeSupport(FeeScopeOther))
+	require.False(t, IsFeeScopeSupport("all"))
+}
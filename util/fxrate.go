@@ -0,0 +1,125 @@
+package util
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+// FxRate is an exchange rate read from an import file
+type FxRate struct {
+	FromCurrency string
+	ToCurrency   string
+	Rate         string
+}
+
+// ParseFxRate parses a decimal exchange rate, which must be positive
+func ParseFxRate(rate string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok {
+		return nil, fmt.Errorf("invalid fx rate %q", rate)
+	}
+	if r.Sign() <= 0 {
+		return nil, fmt.Errorf("fx rate must be positive: %s", rate)
+	}
+	return r, nil
+}
+
+// ConvertAmount converts amount, in minor units of a currency with fromMinorUnits decimals,
+// to minor units of a currency with toMinorUnits decimals. The rate is quoted per major unit,
+// so 1.00 USD at 150 is 150 JPY. The result is rounded half away from zero
+func ConvertAmount(amount int64, rate string, fromMinorUnits, toMinorUnits int32) (int64, error) {
+	r, err := ParseFxRate(rate)
+	if err != nil {
+		return 0, err
+	}
+
+	x := new(big.Rat).Mul(new(big.Rat).SetInt64(amount), r)
+	switch {
+	case toMinorUnits > fromMinorUnits:
+		x.Mul(x, pow10(toMinorUnits-fromMinorUnits))
+	case toMinorUnits < fromMinorUnits:
+		x.Quo(x, pow10(fromMinorUnits-toMinorUnits))
+	}
+	return roundRat(x)
+}
+
+// pow10 returns 10^n for n >= 0
+func pow10(n int32) *big.Rat {
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil))
+}
+
+// roundRat rounds x to an amount, half away from zero
+func roundRat(x *big.Rat) (int64, error) {
+	q, m := new(big.Int).QuoRem(x.Num(), x.Denom(), new(big.Int))
+	// round half away from zero: |remainder| * 2 >= denominator
+	if m.Abs(m).Lsh(m, 1).Cmp(x.Denom()) >= 0 {
+		if x.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	if !q.IsInt64() {
+		return 0, errors.New("amount is out of range")
+	}
+	return q.Int64(), nil
+}
+
+// InverseFxRate returns 1/rate as a decimal with up to 10 fractional digits
+func InverseFxRate(rate string) (string, error) {
+	r, err := ParseFxRate(rate)
+	if err != nil {
+		return "", err
+	}
+	inv := new(big.Rat).Inv(r).FloatString(10)
+	inv = strings.TrimRight(strings.TrimRight(inv, "0"), ".")
+	return inv, nil
+}
+
+// ProRata returns amount * part / whole rounded down, computed without overflow
+func ProRata(amount, part, whole int64) int64 {
+	x := new(big.Int).Mul(big.NewInt(amount), big.NewInt(part))
+	return x.Div(x, big.NewInt(whole)).Int64()
+}
+
+// ReadFxRates reads "from_currency,to_currency,rate" records, one per line.
+// Empty lines and lines starting with # are skipped
+func ReadFxRates(r io.Reader) ([]FxRate, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+
+	var rates []FxRate
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rate := FxRate{
+			FromCurrency: strings.ToUpper(record[0]),
+			ToCurrency:   strings.ToUpper(record[1]),
+			Rate:         record[2],
+		}
+		line, _ := reader.FieldPos(0)
+		if !IsCurrencySupport(rate.FromCurrency) || !IsCurrencySupport(rate.ToCurrency) {
+			return nil, fmt.Errorf("line %d: unsupported currency pair %s/%s", line, rate.FromCurrency, rate.ToCurrency)
+		}
+		if rate.FromCurrency == rate.ToCurrency {
+			return nil, fmt.Errorf("line %d: currencies must differ", line)
+		}
+		if _, err := ParseFxRate(rate.Rate); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
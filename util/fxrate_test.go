@@ -0,0 +1,105 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertAmount(t *testing.T) {
+	testCases := []struct {
+		name   string
+		amount int64
+		rate   string
+		from   int32
+		to     int32
+		want   int64
+	}{
+		{name: "Same", amount: 100, rate: "1", from: 2, to: 2, want: 100},
+		{name: "RoundDown", amount: 1000, rate: "0.9231", from: 2, to: 2, want: 923},
+		{name: "RoundUp", amount: 1000, rate: "1.0835", from: 2, to: 2, want: 1084},
+		{name: "Half", amount: 1, rate: "0.5", from: 2, to: 2, want: 1},
+		{name: "BelowHalf", amount: 1, rate: "0.49", from: 2, to: 2, want: 0},
+		{name: "Negative", amount: -1000, rate: "1.0835", from: 2, to: 2, want: -1084},
+		// 1.00 USD at 150 JPY per USD is 150 JPY
+		{name: "USDToJPY", amount: 100, rate: "150", from: 2, to: 0, want: 150},
+		// 12.34 USD at 149.87 is 1849.3958 JPY
+		{name: "USDToJPYRounded", amount: 1234, rate: "149.87", from: 2, to: 0, want: 1849},
+		// 150 JPY at 0.0066 USD per JPY is 0.99 USD
+		{name: "JPYToUSD", amount: 150, rate: "0.0066", from: 0, to: 2, want: 99},
+		// 1.000 KWD at 3.25 USD per KWD is 3.25 USD
+		{name: "KWDToUSD", amount: 1000, rate: "3.25", from: 3, to: 2, want: 325},
+		// 0.155 KWD at 3.2547 is 0.5044785 USD
+		{name: "KWDToUSDRounded", amount: 155, rate: "3.2547", from: 3, to: 2, want: 50},
+		// 1.00 USD at 0.3077 KWD per USD is 0.3077 KWD
+		{name: "USDToKWD", amount: 100, rate: "0.3077", from: 2, to: 3, want: 308},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ConvertAmount(tc.amount, tc.rate, tc.from, tc.to)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got, "%d * %s", tc.amount, tc.rate)
+		})
+	}
+
+	_, err := ConvertAmount(100, "abc", 2, 2)
+	require.Error(t, err)
+
+	_, err = ConvertAmount(100, "-1.2", 2, 2)
+	require.Error(t, err)
+}
+
+func TestReadFxRates(t *testing.T) {
+	input := `# from,to,rate
+USD,EUR,0.9231
+eur, usd, 1.0833
+`
+	rates, err := ReadFxRates(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Equal(t, []FxRate{
+		{FromCurrency: USD, ToCurrency: EUR, Rate: "0.9231"},
+		{FromCurrency: EUR, ToCurrency: USD, Rate: "1.0833"},
+	}, rates)
+
+	_, err = ReadFxRates(strings.NewReader("USD,GBP,0.8\n"))
+	require.Error(t, err)
+
+	_, err = ReadFxRates(strings.NewReader("USD,USD,1\n"))
+	require.Error(t, err)
+
+	_, err = ReadFxRates(strings.NewReader("USD,EUR,0\n"))
+	require.Error(t, err)
+
+	_, err = ReadFxRates(strings.NewReader("USD,EUR\n"))
+	require.Error(t, err)
+}
+
+func TestInverseFxRate(t *testing.T) {
+	testCases := []struct {
+		rate string
+		want string
+	}{
+		{rate: "1", want: "1"},
+		{rate: "0.5", want: "2"},
+		{rate: "0.9231", want: "1.0833062507"},
+		{rate: "4", want: "0.25"},
+	}
+
+	for _, tc := range testCases {
+		got, err := InverseFxRate(tc.rate)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, got, tc.rate)
+	}
+
+	_, err := InverseFxRate("0")
+	require.Error(t, err)
+}
+
+func TestProRata(t *testing.T) {
+	require.Equal(t, int64(50), ProRata(100, 1, 2))
+	require.Equal(t, int64(33), ProRata(100, 1, 3))
+	require.Equal(t, int64(100), ProRata(100, 3, 3))
+	require.Equal(t, int64(1e18), ProRata(1e18, 1e18, 1e18))
+}
@@ -0,0 +1,91 @@
+package util
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// day count conventions of an interest-bearing product
+const (
+	DayCountACT365 = "ACT/365"
+	DayCount30360  = "30/360"
+)
+
+// interestDigits is the number of fractional digits an accrued amount is kept with
+const interestDigits = 10
+
+func IsDayCountSupport(convention string) bool {
+	switch convention {
+	case DayCountACT365, DayCount30360:
+		return true
+	}
+	return false
+}
+
+// ParseInterestRate parses a decimal annual interest rate, which must not be negative
+func ParseInterestRate(rate string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok {
+		return nil, fmt.Errorf("invalid interest rate %q", rate)
+	}
+	if r.Sign() < 0 {
+		return nil, fmt.Errorf("interest rate must not be negative: %s", rate)
+	}
+	return r, nil
+}
+
+// DayCountFraction returns the fraction of a year a day accrues interest for.
+// ACT/365 counts every day as 1/365. 30/360 counts every month as 30 days of a 360 day year:
+// the 31st accrues nothing and the last day of February also accrues the days up to the 30th
+func DayCountFraction(convention string, day time.Time) (*big.Rat, error) {
+	switch convention {
+	case DayCountACT365:
+		return big.NewRat(1, 365), nil
+	case DayCount30360:
+		return big.NewRat(days360(day, day.AddDate(0, 0, 1)), 360), nil
+	}
+	return nil, fmt.Errorf("unsupported day count convention %q", convention)
+}
+
+// days360 counts the days between two dates with the 30/360 bond basis
+func days360(from, to time.Time) int64 {
+	y1, m1, d1 := from.Date()
+	y2, m2, d2 := to.Date()
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 && d1 == 30 {
+		d2 = 30
+	}
+	return int64(360*(y2-y1) + 30*(int(m2)-int(m1)) + (d2 - d1))
+}
+
+// DailyInterest returns the interest the closing balance of a day earns, as a decimal
+// with interestDigits fractional digits. A negative balance earns nothing
+func DailyInterest(balance int64, annualRate, convention string, day time.Time) (string, error) {
+	rate, err := ParseInterestRate(annualRate)
+	if err != nil {
+		return "", err
+	}
+	fraction, err := DayCountFraction(convention, day)
+	if err != nil {
+		return "", err
+	}
+	if balance < 0 {
+		balance = 0
+	}
+
+	x := new(big.Rat).SetInt64(balance)
+	x.Mul(x, rate).Mul(x, fraction)
+	return x.FloatString(interestDigits), nil
+}
+
+// RoundAmount rounds a decimal amount half away from zero
+func RoundAmount(amount string) (int64, error) {
+	x, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return 0, fmt.Errorf("invalid amount %q", amount)
+	}
+	return roundRat(x)
+}
@@ -0,0 +1,96 @@
+package util
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDayCountFraction(t *testing.T) {
+	testCases := []struct {
+		convention string
+		day        time.Time
+		days       int64
+		year       int64
+	}{
+		{convention: DayCountACT365, day: date(2022, 3, 15), days: 1, year: 365},
+		{convention: DayCountACT365, day: date(2024, 2, 29), days: 1, year: 365},
+		{convention: DayCount30360, day: date(2022, 3, 15), days: 1, year: 360},
+		{convention: DayCount30360, day: date(2022, 3, 30), days: 0, year: 360},
+		{convention: DayCount30360, day: date(2022, 3, 31), days: 1, year: 360},
+		{convention: DayCount30360, day: date(2022, 4, 30), days: 1, year: 360},
+		{convention: DayCount30360, day: date(2022, 2, 28), days: 3, year: 360},
+		{convention: DayCount30360, day: date(2024, 2, 28), days: 1, year: 360},
+		{convention: DayCount30360, day: date(2024, 2, 29), days: 2, year: 360},
+		{convention: DayCount30360, day: date(2022, 12, 31), days: 1, year: 360},
+	}
+
+	for _, tc := range testCases {
+		fraction, err := DayCountFraction(tc.convention, tc.day)
+		require.NoError(t, err)
+		require.Equal(t, big.NewRat(tc.days, tc.year).String(), fraction.String(), "%s %s", tc.convention, tc.day)
+	}
+
+	_, err := DayCountFraction("ACT/ACT", date(2022, 3, 15))
+	require.Error(t, err)
+}
+
+func TestDayCount30360Month(t *testing.T) {
+	// every month accrues 30 days whatever its length
+	for month := time.January; month <= time.December; month++ {
+		total := new(big.Rat)
+		for day := date(2022, month, 1); day.Month() == month; day = day.AddDate(0, 0, 1) {
+			fraction, err := DayCountFraction(DayCount30360, day)
+			require.NoError(t, err)
+			total.Add(total, fraction)
+		}
+		require.Equal(t, big.NewRat(30, 360).String(), total.String(), month.String())
+	}
+}
+
+func TestDailyInterest(t *testing.T) {
+	amount, err := DailyInterest(365000, "0.05", DayCountACT365, date(2022, 3, 15))
+	require.NoError(t, err)
+	require.Equal(t, "50.0000000000", amount)
+
+	amount, err = DailyInterest(1000, "0.05", DayCountACT365, date(2022, 3, 15))
+	require.NoError(t, err)
+	require.Equal(t, "0.1369863014", amount)
+
+	amount, err = DailyInterest(360000, "0.05", DayCount30360, date(2022, 3, 31))
+	require.NoError(t, err)
+	require.Equal(t, "50.0000000000", amount)
+
+	amount, err = DailyInterest(-1000, "0.05", DayCountACT365, date(2022, 3, 15))
+	require.NoError(t, err)
+	require.Equal(t, "0.0000000000", amount)
+
+	_, err = DailyInterest(1000, "-0.05", DayCountACT365, date(2022, 3, 15))
+	require.Error(t, err)
+	_, err = DailyInterest(1000, "abc", DayCountACT365, date(2022, 3, 15))
+	require.Error(t, err)
+}
+
+func TestRoundAmount(t *testing.T) {
+	testCases := []struct {
+		amount string
+		want   int64
+	}{
+		{amount: "0", want: 0},
+		{amount: "4.1095890410", want: 4},
+		{amount: "4.5", want: 5},
+		{amount: "4.4999999999", want: 4},
+		{amount: "-4.5", want: -5},
+	}
+
+	for _, tc := range testCases {
+		got, err := RoundAmount(tc.amount)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, got, tc.amount)
+	}
+
+	_, err := RoundAmount("abc")
+	require.Error(t, err)
+}
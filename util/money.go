@@ -0,0 +1,100 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in the minor units of a currency, 1234 USD is 12.34 dollars
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// ParseMoney parses a decimal amount like "12.34" in a currency of the registry.
+// It rejects an amount with more decimals than the currency has minor units
+func ParseMoney(s string, currency string) (Money, error) {
+	c, ok := LookupCurrency(currency)
+	if !ok {
+		return Money{}, fmt.Errorf("unknown currency %q", currency)
+	}
+	amount, err := ParseAmount(s, c.MinorUnits)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w for %s", err, currency)
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// String formats the amount as a decimal with the minor units of its currency, like "12.34".
+// The amount of a currency missing from the registry is formatted in minor units
+func (m Money) String() string {
+	s := FormatMoney(m.Amount, m.Currency)
+	if len(s) == 0 {
+		return strconv.FormatInt(m.Amount, 10)
+	}
+	return s
+}
+
+// FormatMoney formats an amount in minor units as a decimal with the minor units of the currency.
+// It returns an empty string for a currency missing from the registry
+func FormatMoney(amount int64, currency string) string {
+	c, ok := LookupCurrency(currency)
+	if !ok {
+		return ""
+	}
+	return FormatAmount(amount, c.MinorUnits)
+}
+
+// ParseAmount parses a decimal amount with at most minorUnits decimals into minor units
+func ParseAmount(s string, minorUnits int32) (int64, error) {
+	digits := strings.TrimPrefix(s, "-")
+	whole, fraction := digits, ""
+	point := strings.IndexByte(digits, '.')
+	if point >= 0 {
+		whole, fraction = digits[:point], digits[point+1:]
+	}
+	if !isDigits(whole) || (point >= 0 && !isDigits(fraction)) {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	if len(fraction) > int(minorUnits) {
+		return 0, fmt.Errorf("amount %q has more than %d decimals", s, minorUnits)
+	}
+
+	fraction += strings.Repeat("0", int(minorUnits)-len(fraction))
+	amount, err := strconv.ParseInt(s[:len(s)-len(digits)]+whole+fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	return amount, nil
+}
+
+// FormatAmount formats an amount in minor units as a decimal with minorUnits decimals
+func FormatAmount(amount int64, minorUnits int32) string {
+	s := strconv.FormatInt(amount, 10)
+	if minorUnits <= 0 {
+		return s
+	}
+
+	sign := ""
+	if amount < 0 {
+		sign, s = "-", s[1:]
+	}
+	if pad := int(minorUnits) + 1 - len(s); pad > 0 {
+		s = strings.Repeat("0", pad) + s
+	}
+	point := len(s) - int(minorUnits)
+	return sign + s[:point] + "." + s[point:]
+}
+
+func isDigits(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAmount(t *testing.T) {
+	testCases := []struct {
+		name       string
+		s          string
+		minorUnits int32
+		amount     int64
+		ok         bool
+	}{
+		{name: "Cents", s: "12.34", minorUnits: 2, amount: 1234, ok: true},
+		{name: "OneDecimal", s: "12.3", minorUnits: 2, amount: 1230, ok: true},
+		{name: "Whole", s: "12", minorUnits: 2, amount: 1200, ok: true},
+		{name: "ZeroUnits", s: "1234", minorUnits: 0, amount: 1234, ok: true},
+		{name: "ThreeUnits", s: "1.005", minorUnits: 3, amount: 1005, ok: true},
+		{name: "Negative", s: "-0.05", minorUnits: 2, amount: -5, ok: true},
+		{name: "ExcessPrecision", s: "12.345", minorUnits: 2},
+		{name: "DecimalsWithoutUnits", s: "12.3", minorUnits: 0},
+		{name: "Empty", s: "", minorUnits: 2},
+		{name: "NoWhole", s: ".5", minorUnits: 2},
+		{name: "NoFraction", s: "5.", minorUnits: 2},
+		{name: "Letters", s: "1e3", minorUnits: 2},
+		{name: "Comma", s: "12,34", minorUnits: 2},
+		{name: "Plus", s: "+12", minorUnits: 2},
+		{name: "Overflow", s: "92233720368547758.08", minorUnits: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			amount, err := ParseAmount(tc.s, tc.minorUnits)
+			if !tc.ok {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.amount, amount)
+		})
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	require.Equal(t, "12.34", FormatAmount(1234, 2))
+	require.Equal(t, "0.05", FormatAmount(5, 2))
+	require.Equal(t, "-0.05", FormatAmount(-5, 2))
+	require.Equal(t, "0.00", FormatAmount(0, 2))
+	require.Equal(t, "1234", FormatAmount(1234, 0))
+	require.Equal(t, "1.005", FormatAmount(1005, 3))
+	require.Equal(t, "-92233720368547758.08", FormatAmount(-9223372036854775808, 2))
+}
+
+func TestMoney(t *testing.T) {
+	money, err := ParseMoney("12.34", USD)
+	require.NoError(t, err)
+	require.Equal(t, Money{Amount: 1234, Currency: USD}, money)
+	require.Equal(t, "12.34", money.String())
+
+	_, err = ParseMoney("12.345", USD)
+	require.Error(t, err)
+
+	_, err = ParseMoney("12", "XXX")
+	require.Error(t, err)
+
+	require.Equal(t, "1234", Money{Amount: 1234, Currency: "XXX"}.String())
+	require.Equal(t, "12.34", FormatMoney(1234, USD))
+	require.Empty(t, FormatMoney(1234, "XXX"))
+}
@@ -0,0 +1,62 @@
+package util
+
+import (
+	"fmt"
+	"time"
+)
+
+// frequencies of a standing order
+const (
+	Daily   = "daily"
+	Weekly  = "weekly"
+	Monthly = "monthly"
+)
+
+func IsFrequencySupport(frequency string) bool {
+	switch frequency {
+	case Daily, Weekly, Monthly:
+		return true
+	}
+	return false
+}
+
+// FirstRun returns the first run at or after start.
+// Monthly runs land on dayOfMonth, or on the last day of a shorter month
+func FirstRun(frequency string, dayOfMonth int, start time.Time) (time.Time, error) {
+	if frequency != Monthly {
+		if !IsFrequencySupport(frequency) {
+			return time.Time{}, fmt.Errorf("unsupported frequency %q", frequency)
+		}
+		return start, nil
+	}
+
+	run := monthlyRun(start, start.Month(), dayOfMonth)
+	if run.Before(start) {
+		run = monthlyRun(start, start.Month()+1, dayOfMonth)
+	}
+	return run, nil
+}
+
+// NextRun returns the run following prev
+func NextRun(frequency string, dayOfMonth int, prev time.Time) (time.Time, error) {
+	switch frequency {
+	case Daily:
+		return prev.AddDate(0, 0, 1), nil
+	case Weekly:
+		return prev.AddDate(0, 0, 7), nil
+	case Monthly:
+		return monthlyRun(prev, prev.Month()+1, dayOfMonth), nil
+	}
+	return time.Time{}, fmt.Errorf("unsupported frequency %q", frequency)
+}
+
+// monthlyRun returns the time of day of t on dayOfMonth of the given month of t's year,
+// clamped to the last day of the month. Months past December roll over to the next year
+func monthlyRun(t time.Time, month time.Month, dayOfMonth int) time.Time {
+	// day 0 of the next month is the last day of this one
+	lastDay := time.Date(t.Year(), month+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	if dayOfMonth > lastDay {
+		dayOfMonth = lastDay
+	}
+	return time.Date(t.Year(), month, dayOfMonth, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
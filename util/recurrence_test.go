@@ -0,0 +1,59 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+}
+
+func TestNextRun(t *testing.T) {
+	testCases := []struct {
+		frequency  string
+		dayOfMonth int
+		prev       time.Time
+		want       time.Time
+	}{
+		{frequency: Daily, prev: date(2022, time.February, 28), want: date(2022, time.March, 1)},
+		{frequency: Weekly, prev: date(2022, time.December, 28), want: date(2023, time.January, 4)},
+		{frequency: Monthly, dayOfMonth: 15, prev: date(2022, time.January, 15), want: date(2022, time.February, 15)},
+		{frequency: Monthly, dayOfMonth: 31, prev: date(2022, time.January, 31), want: date(2022, time.February, 28)},
+		{frequency: Monthly, dayOfMonth: 31, prev: date(2022, time.February, 28), want: date(2022, time.March, 31)},
+		{frequency: Monthly, dayOfMonth: 30, prev: date(2024, time.January, 30), want: date(2024, time.February, 29)},
+		{frequency: Monthly, dayOfMonth: 31, prev: date(2022, time.December, 31), want: date(2023, time.January, 31)},
+	}
+
+	for _, tc := range testCases {
+		got, err := NextRun(tc.frequency, tc.dayOfMonth, tc.prev)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, got, "%s %d after %s", tc.frequency, tc.dayOfMonth, tc.prev)
+	}
+
+	_, err := NextRun("yearly", 1, date(2022, time.January, 1))
+	require.Error(t, err)
+}
+
+func TestFirstRun(t *testing.T) {
+	testCases := []struct {
+		frequency  string
+		dayOfMonth int
+		start      time.Time
+		want       time.Time
+	}{
+		{frequency: Weekly, start: date(2022, time.March, 3), want: date(2022, time.March, 3)},
+		{frequency: Monthly, dayOfMonth: 3, start: date(2022, time.March, 3), want: date(2022, time.March, 3)},
+		{frequency: Monthly, dayOfMonth: 20, start: date(2022, time.March, 3), want: date(2022, time.March, 20)},
+		{frequency: Monthly, dayOfMonth: 1, start: date(2022, time.March, 3), want: date(2022, time.April, 1)},
+		{frequency: Monthly, dayOfMonth: 31, start: date(2022, time.February, 3), want: date(2022, time.February, 28)},
+	}
+
+	for _, tc := range testCases {
+		got, err := FirstRun(tc.frequency, tc.dayOfMonth, tc.start)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, got, "%s %d from %s", tc.frequency, tc.dayOfMonth, tc.start)
+	}
+}
@@ -0,0 +1,15 @@
+package util
+
+// roles of a user
+const (
+	DepositorRole = "depositor"
+	AdminRole     = "admin"
+)
+
+func IsRoleSupport(role string) bool {
+	switch role {
+	case DepositorRole, AdminRole:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package util
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+)
+
+// ErrWebhookAddress is returned for a webhook URL whose host is, or resolves to, an address
+// that isn't public, so that webhooks can't reach the internal network of the bank
+var ErrWebhookAddress = errors.New("webhook address is not public")
+
+// event types a webhook can subscribe to
+const (
+	WebhookTransferReceived = "transfer.received"
+	WebhookTransferSent     = "transfer.sent"
+	WebhookAccountCreated   = "account.created"
+)
+
+// IsWebhookEventSupport returns true if a webhook can subscribe to the event type
+func IsWebhookEventSupport(eventType string) bool {
+	switch eventType {
+	case WebhookTransferReceived, WebhookTransferSent, WebhookAccountCreated:
+		return true
+	}
+	return false
+}
+
+// NewWebhookSecret returns a random key to sign the deliveries of a webhook with
+func NewWebhookSecret() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(key), nil
+}
+
+// SignWebhook returns the hex HMAC-SHA256 of "timestamp.body" keyed with the secret of the webhook.
+// Signing the timestamp lets the receiver reject old deliveries that are sent again by someone else
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhook checks a signature made by SignWebhook in constant time
+func VerifyWebhook(secret string, timestamp int64, body []byte, signature string) bool {
+	expected := SignWebhook(secret, timestamp, body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// IsWebhookURL returns true if rawURL is an absolute http or https URL with a host
+func IsWebhookURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Hostname() != ""
+}
+
+// IsPublicIP returns false for the loopback, private, link-local, unspecified and multicast addresses
+func IsPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
+
+// CheckWebhookHost resolves the host of a webhook URL with lookup and fails with ErrWebhookAddress
+// if any of its addresses isn't public
+func CheckWebhookHost(ctx context.Context, lookup func(context.Context, string) ([]net.IPAddr, error), rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	addrs, err := lookup(ctx, u.Hostname())
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if !IsPublicIP(addr.IP) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrWebhookAddress, u.Hostname(), addr.IP)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignWebhook(t *testing.T) {
+	body := []byte(`{"event_type":"transfer.received"}`)
+	signature := SignWebhook("secret", 1700000000, body)
+	require.Equal(t, "af1c88cc8d732cf4e3108292e810241759644ac0c61b9d3ea8b0c21fd1c461a2", signature)
+	require.True(t, VerifyWebhook("secret", 1700000000, body, signature))
+
+	require.False(t, VerifyWebhook("other", 1700000000, body, signature))
+	require.False(t, VerifyWebhook("secret", 1700000001, body, signature))
+	require.False(t, VerifyWebhook("secret", 1700000000, []byte(`{}`), signature))
+}
+
+func TestNewWebhookSecret(t *testing.T) {
+	secret1, err := NewWebhookSecret()
+	require.NoError(t, err)
+	require.Len(t, secret1, 64)
+
+	secret2, err := NewWebhookSecret()
+	require.NoError(t, err)
+	require.NotEqual(t, secret1, secret2)
+}
+
+func TestIsWebhookEventSupport(t *testing.T) {
+	require.True(t, IsWebhookEventSupport(WebhookTransferReceived))
+	require.True(t, IsWebhookEventSupport(WebhookTransferSent))
+	require.True(t, IsWebhookEventSupport(WebhookAccountCreated))
+	require.False(t, IsWebhookEventSupport("user.registered"))
+}
+
+func TestIsWebhookURL(t *testing.T) {
+	require.True(t, IsWebhookURL("https://partner.example.com/hooks"))
+	require.True(t, IsWebhookURL("http://partner.example.com:8080/hooks"))
+	require.False(t, IsWebhookURL("ftp://partner.example.com/hooks"))
+	require.False(t, IsWebhookURL("file:///etc/passwd"))
+	require.False(t, IsWebhookURL("https:///hooks"))
+	require.False(t, IsWebhookURL("partner.example.com/hooks"))
+}
+
+func TestIsPublicIP(t *testing.T) {
+	public := []string{"93.184.216.34", "2606:2800:220:1:248:1893:25c8:1946"}
+	for _, ip := range public {
+		require.True(t, IsPublicIP(net.ParseIP(ip)), ip)
+	}
+
+	internal := []string{
+		"127.0.0.1", "::1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "fd00::1",
+		"169.254.169.254", "fe80::1", "0.0.0.0", "::", "224.0.0.1", "::ffff:127.0.0.1",
+	}
+	for _, ip := range internal {
+		require.False(t, IsPublicIP(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestCheckWebhookHost(t *testing.T) {
+	lookup := func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		switch host {
+		case "partner.example.com":
+			return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+		case "rebind.example.com":
+			return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}, {IP: net.ParseIP("10.0.0.1")}}, nil
+		case "169.254.169.254":
+			return []net.IPAddr{{IP: net.ParseIP(host)}}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	require.NoError(t, CheckWebhookHost(context.Background(), lookup, "https://partner.example.com/hooks"))
+	require.ErrorIs(t, CheckWebhookHost(context.Background(), lookup, "https://rebind.example.com/hooks"), ErrWebhookAddress)
+	require.ErrorIs(t, CheckWebhookHost(context.Background(), lookup, "http://169.254.169.254/latest/meta-data"), ErrWebhookAddress)
+	require.EqualError(t, CheckWebhookHost(context.Background(), lookup, "https://unknown.example.com/hooks"), "no such host")
+}
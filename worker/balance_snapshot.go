@@ -0,0 +1,42 @@
+package worker
+
+import (
+	"context"
+	db "simplebank/db/sqlc"
+	"time"
+)
+
+// snapshotDelay is how long after midnight the snapshot of the day is taken,
+// so transactions that started before midnight have committed their entries
+const snapshotDelay = time.Hour
+
+// BalanceSnapshotWorker stores the end of day balance of every account that had entries that day
+type BalanceSnapshotWorker struct {
+	store    db.Store
+	interval time.Duration
+}
+
+// NewBalanceSnapshotWorker creates a worker checking for a day to snapshot every interval
+func NewBalanceSnapshotWorker(store db.Store, interval time.Duration) *BalanceSnapshotWorker {
+	return &BalanceSnapshotWorker{
+		store:    store,
+		interval: interval,
+	}
+}
+
+// Run takes balance snapshots until ctx is done
+func (worker *BalanceSnapshotWorker) Run(ctx context.Context) {
+	runPeriodically(ctx, "balance snapshot", worker.interval, worker.runOnce)
+}
+
+// runOnce snapshots the balances at the start of the current UTC day.
+// Accounts that already have the snapshot are skipped, so it is safe to run any number of times
+func (worker *BalanceSnapshotWorker) runOnce(ctx context.Context) error {
+	_, err := worker.store.CreateBalanceSnapshots(ctx, snapshotTime(time.Now()))
+	return err
+}
+
+// snapshotTime returns the latest midnight UTC that is at least snapshotDelay old
+func snapshotTime(now time.Time) time.Time {
+	return now.Add(-snapshotDelay).UTC().Truncate(24 * time.Hour)
+}
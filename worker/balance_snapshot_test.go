@@ -0,0 +1,48 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	mockdb "simplebank/db/mock"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotTime(t *testing.T) {
+	midnight := time.Date(2022, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	require.Equal(t, midnight, snapshotTime(midnight.Add(snapshotDelay)))
+	require.Equal(t, midnight, snapshotTime(midnight.Add(23*time.Hour)))
+	// right after midnight the previous day is still open
+	require.Equal(t, midnight.AddDate(0, 0, -1), snapshotTime(midnight.Add(time.Minute)))
+	// the day boundary is in UTC whatever the zone of now
+	zone := time.FixedZone("UTC+3", 3*60*60)
+	require.Equal(t, midnight, snapshotTime(time.Date(2022, 3, 10, 5, 0, 0, 0, zone)))
+}
+
+func TestBalanceSnapshotWorkerRunOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().CreateBalanceSnapshots(gomock.Any(), gomock.Any()).Times(1).Return(int64(3), nil)
+
+	worker := NewBalanceSnapshotWorker(store, time.Hour)
+	err := worker.runOnce(context.Background())
+	require.NoError(t, err)
+}
+
+func TestBalanceSnapshotWorkerRunOnceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().CreateBalanceSnapshots(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), sql.ErrConnDone)
+
+	worker := NewBalanceSnapshotWorker(store, time.Hour)
+	err := worker.runOnce(context.Background())
+	require.ErrorIs(t, err, sql.ErrConnDone)
+}
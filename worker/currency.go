@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	db "simplebank/db/sqlc"
+	"simplebank/util"
+	"time"
+)
+
+// CurrencyWorker keeps the currency registry of util in sync with the currencies table,
+// so a currency is enabled or disabled without a restart
+type CurrencyWorker struct {
+	store    db.Store
+	interval time.Duration
+}
+
+// NewCurrencyWorker creates a worker reloading the currencies every interval
+func NewCurrencyWorker(store db.Store, interval time.Duration) *CurrencyWorker {
+	return &CurrencyWorker{
+		store:    store,
+		interval: interval,
+	}
+}
+
+// Run reloads the currencies until ctx is done
+func (worker *CurrencyWorker) Run(ctx context.Context) {
+	runPeriodically(ctx, "currency", worker.interval, worker.runOnce)
+}
+
+func (worker *CurrencyWorker) runOnce(ctx context.Context) error {
+	return LoadCurrencies(ctx, worker.store)
+}
+
+// LoadCurrencies replaces the currency registry with the currencies table.
+// The registry is left as it was if they can't be read
+func LoadCurrencies(ctx context.Context, store db.Store) error {
+	rows, err := store.ListCurrencies(ctx)
+	if err != nil {
+		return err
+	}
+
+	currencies := make([]util.Currency, len(rows))
+	for i, row := range rows {
+		currencies[i] = util.Currency{
+			Code:       row.Code,
+			MinorUnits: row.MinorUnits,
+			Enabled:    row.Enabled,
+		}
+	}
+	util.SetCurrencies(currencies)
+	return nil
+}
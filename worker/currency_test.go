@@ -0,0 +1,45 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	mockdb "simplebank/db/mock"
+	db "simplebank/db/sqlc"
+	"simplebank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadCurrencies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	rows := []db.Currency{
+		{Code: util.EUR, MinorUnits: 2, Enabled: true},
+		{Code: "JPY", MinorUnits: 0, Enabled: true},
+		{Code: util.USD, MinorUnits: 2, Enabled: false},
+	}
+	gomock.InOrder(
+		store.EXPECT().ListCurrencies(gomock.Any()).Times(1).Return(rows, nil),
+		store.EXPECT().ListCurrencies(gomock.Any()).Times(1).Return(nil, sql.ErrConnDone),
+	)
+	defer util.SetCurrencies([]util.Currency{
+		{Code: util.USD, MinorUnits: 2, Enabled: true},
+		{Code: util.EUR, MinorUnits: 2, Enabled: true},
+	})
+
+	worker := NewCurrencyWorker(store, time.Minute)
+	err := worker.runOnce(context.Background())
+	require.NoError(t, err)
+	require.True(t, util.IsCurrencySupport("JPY"))
+	require.False(t, util.IsCurrencySupport(util.USD))
+
+	// a failed reload keeps the registry
+	err = worker.runOnce(context.Background())
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	require.True(t, util.IsCurrencySupport("JPY"))
+}
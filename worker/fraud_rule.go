@@ -0,0 +1,32 @@
+package worker
+
+import (
+	"context"
+	"simplebank/fraud"
+	"time"
+)
+
+// FraudRuleWorker reloads the fraud rules of an engine from their file, so a rule is changed without a restart
+type FraudRuleWorker struct {
+	engine   *fraud.Engine
+	path     string
+	interval time.Duration
+}
+
+// NewFraudRuleWorker creates a worker reloading the fraud rules of engine every interval
+func NewFraudRuleWorker(engine *fraud.Engine, path string, interval time.Duration) *FraudRuleWorker {
+	return &FraudRuleWorker{
+		engine:   engine,
+		path:     path,
+		interval: interval,
+	}
+}
+
+// Run reloads the fraud rules until ctx is done
+func (worker *FraudRuleWorker) Run(ctx context.Context) {
+	runPeriodically(ctx, "fraud rules", worker.interval, worker.runOnce)
+}
+
+func (worker *FraudRuleWorker) runOnce(ctx context.Context) error {
+	return worker.engine.LoadRules(worker.path)
+}
@@ -0,0 +1,42 @@
+package worker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"simplebank/fraud"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// amountFacts only knows the amount of the transfer
+type amountFacts int64
+
+func (amount amountFacts) Fact(ctx context.Context, name string, window time.Duration) (int64, error) {
+	return int64(amount), nil
+}
+
+func TestFraudRuleWorker(t *testing.T) {
+	engine := fraud.NewEngine()
+	path := filepath.Join(t.TempDir(), "fraud_rules.json")
+	worker := NewFraudRuleWorker(engine, path, time.Minute)
+
+	err := os.WriteFile(path, []byte(`[{"name": "big", "decision": "deny", "conditions": [{"fact": "transfer.amount", "op": ">", "value": 100}]}]`), 0600)
+	require.NoError(t, err)
+	require.NoError(t, worker.runOnce(context.Background()))
+
+	result, err := engine.Evaluate(context.Background(), amountFacts(500))
+	require.NoError(t, err)
+	require.Equal(t, fraud.Result{Decision: fraud.Deny, Rule: "big"}, result)
+
+	// an edited rule applies on the next run
+	err = os.WriteFile(path, []byte(`[{"name": "big", "decision": "review", "conditions": [{"fact": "transfer.amount", "op": ">", "value": 100}]}]`), 0600)
+	require.NoError(t, err)
+	require.NoError(t, worker.runOnce(context.Background()))
+
+	result, err = engine.Evaluate(context.Background(), amountFacts(500))
+	require.NoError(t, err)
+	require.Equal(t, fraud.Review, result.Decision)
+}
@@ -0,0 +1,42 @@
+package worker
+
+import (
+	"context"
+	db "simplebank/db/sqlc"
+	"time"
+)
+
+// holdBatchSize is how many expired holds are locked by a single run
+const holdBatchSize = 100
+
+// HoldExpiryWorker releases holds that were neither captured nor voided in time
+type HoldExpiryWorker struct {
+	store    db.Store
+	interval time.Duration
+}
+
+// NewHoldExpiryWorker creates a worker polling for expired holds every interval
+func NewHoldExpiryWorker(store db.Store, interval time.Duration) *HoldExpiryWorker {
+	return &HoldExpiryWorker{
+		store:    store,
+		interval: interval,
+	}
+}
+
+// Run releases expired holds until ctx is done
+func (worker *HoldExpiryWorker) Run(ctx context.Context) {
+	runPeriodically(ctx, "hold expiry", worker.interval, worker.runOnce)
+}
+
+// runOnce releases batches of expired holds until none are left
+func (worker *HoldExpiryWorker) runOnce(ctx context.Context) error {
+	for {
+		expired, err := worker.store.ExpireHolds(ctx, holdBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(expired) < holdBatchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	mockdb "simplebank/db/mock"
+	db "simplebank/db/sqlc"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHoldExpiryWorkerRunOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	full := make([]db.Hold, holdBatchSize)
+	gomock.InOrder(
+		store.EXPECT().ExpireHolds(gomock.Any(), gomock.Eq(int32(holdBatchSize))).Times(1).Return(full, nil),
+		store.EXPECT().ExpireHolds(gomock.Any(), gomock.Eq(int32(holdBatchSize))).Times(1).Return([]db.Hold{{ID: 1}}, nil),
+	)
+
+	worker := NewHoldExpiryWorker(store, time.Minute)
+	err := worker.runOnce(context.Background())
+	require.NoError(t, err)
+}
+
+func TestHoldExpiryWorkerRunOnceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().ExpireHolds(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+
+	worker := NewHoldExpiryWorker(store, time.Minute)
+	err := worker.runOnce(context.Background())
+	require.ErrorIs(t, err, sql.ErrConnDone)
+}
@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"context"
+	db "simplebank/db/sqlc"
+	"time"
+)
+
+// interestCatchUpDays is how many past days every run accrues, so the days missed
+// while the worker was down are caught up. Accruing a day twice changes nothing
+const interestCatchUpDays = 7
+
+// interestPostingBatchSize is how many accounts a single transaction posts interest to
+const interestPostingBatchSize = 100
+
+// InterestWorker accrues the daily interest of interest-bearing accounts
+// and posts the interest of the previous month once it is over
+type InterestWorker struct {
+	store    db.Store
+	interval time.Duration
+}
+
+// NewInterestWorker creates a worker checking for days to accrue and months to post every interval
+func NewInterestWorker(store db.Store, interval time.Duration) *InterestWorker {
+	return &InterestWorker{
+		store:    store,
+		interval: interval,
+	}
+}
+
+// Run accrues and posts interest until ctx is done
+func (worker *InterestWorker) Run(ctx context.Context) {
+	runPeriodically(ctx, "interest", worker.interval, worker.runOnce)
+}
+
+func (worker *InterestWorker) runOnce(ctx context.Context) error {
+	return worker.process(ctx, time.Now())
+}
+
+// process accrues the last interestCatchUpDays completed days, then posts the month before the current one.
+// Days are complete snapshotDelay after midnight UTC, like balance snapshots
+func (worker *InterestWorker) process(ctx context.Context, now time.Time) error {
+	end := snapshotTime(now)
+	for day := end.AddDate(0, 0, -interestCatchUpDays); day.Before(end); day = day.AddDate(0, 0, 1) {
+		_, err := worker.store.AccrueInterest(ctx, day)
+		if err != nil {
+			return err
+		}
+	}
+
+	month := time.Date(end.Year(), end.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+	for {
+		postings, err := worker.store.PostInterest(ctx, month, interestPostingBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(postings) == 0 {
+			return nil
+		}
+	}
+}
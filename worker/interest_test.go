@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	mockdb "simplebank/db/mock"
+	db "simplebank/db/sqlc"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterestWorkerProcess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	now := time.Date(2022, 3, 2, 12, 0, 0, 0, time.UTC)
+	today := time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC)
+	february := time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	var calls []*gomock.Call
+	for i := interestCatchUpDays; i > 0; i-- {
+		day := today.AddDate(0, 0, -i)
+		calls = append(calls, store.EXPECT().AccrueInterest(gomock.Any(), gomock.Eq(day)).Times(1).Return(int64(1), nil))
+	}
+	calls = append(calls,
+		store.EXPECT().PostInterest(gomock.Any(), gomock.Eq(february), gomock.Eq(int32(interestPostingBatchSize))).Times(1).Return([]db.InterestPosting{
+			{AccountID: 1, Month: february, Amount: 42},
+		}, nil),
+		store.EXPECT().PostInterest(gomock.Any(), gomock.Eq(february), gomock.Any()).Times(1).Return([]db.InterestPosting{}, nil),
+	)
+	gomock.InOrder(calls...)
+
+	worker := NewInterestWorker(store, time.Hour)
+	err := worker.process(context.Background(), now)
+	require.NoError(t, err)
+}
+
+func TestInterestWorkerProcessAccrualError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().AccrueInterest(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), sql.ErrConnDone)
+	store.EXPECT().PostInterest(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	worker := NewInterestWorker(store, time.Hour)
+	err := worker.process(context.Background(), time.Now())
+	require.ErrorIs(t, err, sql.ErrConnDone)
+}
+
+func TestInterestWorkerProcessPostingError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().AccrueInterest(gomock.Any(), gomock.Any()).Times(interestCatchUpDays).Return(int64(0), nil)
+	store.EXPECT().PostInterest(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+
+	worker := NewInterestWorker(store, time.Hour)
+	err := worker.process(context.Background(), time.Now())
+	require.ErrorIs(t, err, sql.ErrConnDone)
+}
@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"context"
+	"log"
+	db "simplebank/db/sqlc"
+	"time"
+)
+
+// outboxBatchSize is how many pending events are published by a single transaction
+const outboxBatchSize = 100
+
+// OutboxRelay publishes the events written to the outbox by the transactions of the store
+type OutboxRelay struct {
+	store     db.Store
+	publisher Publisher
+	interval  time.Duration
+}
+
+// NewOutboxRelay creates a relay polling for pending events every interval
+func NewOutboxRelay(store db.Store, publisher Publisher, interval time.Duration) *OutboxRelay {
+	return &OutboxRelay{
+		store:     store,
+		publisher: publisher,
+		interval:  interval,
+	}
+}
+
+// Run publishes pending events until ctx is done
+func (relay *OutboxRelay) Run(ctx context.Context) {
+	runPeriodically(ctx, "outbox", relay.interval, relay.runOnce)
+}
+
+// runOnce publishes batches of pending events until a batch isn't full,
+// because no event is left or the remaining ones wait for a failed one
+func (relay *OutboxRelay) runOnce(ctx context.Context) error {
+	for {
+		published, err := relay.store.RelayOutboxEvents(ctx, outboxBatchSize, relay.publish)
+		if err != nil {
+			return err
+		}
+		if published < outboxBatchSize {
+			return nil
+		}
+	}
+}
+
+// publish logs the events that can't be published, they are retried on the next run
+func (relay *OutboxRelay) publish(ctx context.Context, event db.OutboxEvent) error {
+	err := relay.publisher.Publish(ctx, event)
+	if err != nil && ctx.Err() == nil {
+		log.Printf("outbox: cannot publish event %d %s: %v", event.ID, event.EventType, err)
+	}
+	return err
+}
@@ -0,0 +1,91 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	mockdb "simplebank/db/mock"
+	db "simplebank/db/sqlc"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// relayEvents stubs RelayOutboxEvents by handing events to the publish callback
+func relayEvents(events []db.OutboxEvent) func(context.Context, int32, func(context.Context, db.OutboxEvent) error) (int, error) {
+	return func(ctx context.Context, limit int32, publish func(context.Context, db.OutboxEvent) error) (int, error) {
+		published := 0
+		for _, event := range events {
+			if publish(ctx, event) == nil {
+				published++
+			}
+		}
+		return published, nil
+	}
+}
+
+func TestOutboxRelayRunOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	full := make([]db.OutboxEvent, outboxBatchSize)
+	for i := range full {
+		full[i] = db.OutboxEvent{ID: int64(i + 1), EventType: db.EventTransferCompleted}
+	}
+	last := []db.OutboxEvent{{ID: outboxBatchSize + 1, EventType: db.EventAccountCreated}}
+	gomock.InOrder(
+		store.EXPECT().RelayOutboxEvents(gomock.Any(), gomock.Eq(int32(outboxBatchSize)), gomock.Any()).Times(1).DoAndReturn(relayEvents(full)),
+		store.EXPECT().RelayOutboxEvents(gomock.Any(), gomock.Eq(int32(outboxBatchSize)), gomock.Any()).Times(1).DoAndReturn(relayEvents(last)),
+	)
+
+	publisher := &MemoryPublisher{}
+	relay := NewOutboxRelay(store, publisher, time.Minute)
+	err := relay.runOnce(context.Background())
+	require.NoError(t, err)
+
+	events := publisher.Events()
+	require.Len(t, events, outboxBatchSize+1)
+	for i, event := range events {
+		require.Equal(t, int64(i+1), event.ID)
+	}
+}
+
+func TestOutboxRelayRunOncePublishError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	events := []db.OutboxEvent{
+		{ID: 1, AggregateType: db.AggregateAccount, AggregateID: "1"},
+		{ID: 2, AggregateType: db.AggregateAccount, AggregateID: "2"},
+	}
+	store.EXPECT().RelayOutboxEvents(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(relayEvents(events))
+
+	publisher := &MemoryPublisher{
+		Fail: func(event db.OutboxEvent) error {
+			if event.AggregateID == "1" {
+				return errors.New("downstream is down")
+			}
+			return nil
+		},
+	}
+	relay := NewOutboxRelay(store, publisher, time.Minute)
+	err := relay.runOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []db.OutboxEvent{events[1]}, publisher.Events())
+}
+
+func TestOutboxRelayRunOnceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().RelayOutboxEvents(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(0, sql.ErrConnDone)
+
+	relay := NewOutboxRelay(store, &MemoryPublisher{}, time.Minute)
+	err := relay.runOnce(context.Background())
+	require.ErrorIs(t, err, sql.ErrConnDone)
+}
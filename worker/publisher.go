@@ -0,0 +1,68 @@
+package worker
+
+import (
+	"context"
+	"log"
+	db "simplebank/db/sqlc"
+	"sync"
+)
+
+// Publisher delivers the events of the outbox to downstream systems.
+// The same event may be published more than once, see db.Store.RelayOutboxEvents
+type Publisher interface {
+	Publish(ctx context.Context, event db.OutboxEvent) error
+}
+
+// LogPublisher writes every event to the log
+type LogPublisher struct{}
+
+// Publish logs the event
+func (LogPublisher) Publish(ctx context.Context, event db.OutboxEvent) error {
+	log.Printf("event %d %s %s:%s %s", event.ID, event.EventType, event.AggregateType, event.AggregateID, event.Payload)
+	return nil
+}
+
+// MemoryPublisher keeps the published events in memory, for tests
+type MemoryPublisher struct {
+	// Fail, if set, returns the error of an event that can't be published
+	Fail func(event db.OutboxEvent) error
+
+	mu     sync.Mutex
+	events []db.OutboxEvent
+}
+
+// Publish appends the event to the published ones
+func (publisher *MemoryPublisher) Publish(ctx context.Context, event db.OutboxEvent) error {
+	publisher.mu.Lock()
+	defer publisher.mu.Unlock()
+
+	if publisher.Fail != nil {
+		if err := publisher.Fail(event); err != nil {
+			return err
+		}
+	}
+	publisher.events = append(publisher.events, event)
+	return nil
+}
+
+// Events returns the published events in the order they were published
+func (publisher *MemoryPublisher) Events() []db.OutboxEvent {
+	publisher.mu.Lock()
+	defer publisher.mu.Unlock()
+
+	return append([]db.OutboxEvent(nil), publisher.events...)
+}
+
+// Publishers publishes every event with each of its publishers in turn. An event is published again
+// by all of them if one fails, so each of them must cope with events it has already seen
+type Publishers []Publisher
+
+// Publish publishes the event with each publisher and stops at the first error
+func (publishers Publishers) Publish(ctx context.Context, event db.OutboxEvent) error {
+	for _, publisher := range publishers {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
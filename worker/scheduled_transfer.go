@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"context"
+	"log"
+	db "simplebank/db/sqlc"
+	"time"
+)
+
+// scheduledTransferBatchSize is how many due transfers are locked by a single run
+const scheduledTransferBatchSize = 100
+
+// ScheduledTransferWorker executes scheduled transfers once they are due
+type ScheduledTransferWorker struct {
+	store    db.Store
+	interval time.Duration
+}
+
+// NewScheduledTransferWorker creates a worker polling for due transfers every interval
+func NewScheduledTransferWorker(store db.Store, interval time.Duration) *ScheduledTransferWorker {
+	return &ScheduledTransferWorker{
+		store:    store,
+		interval: interval,
+	}
+}
+
+// Run processes due transfers until ctx is done
+func (worker *ScheduledTransferWorker) Run(ctx context.Context) {
+	runPeriodically(ctx, "scheduled transfers", worker.interval, worker.runOnce)
+}
+
+// runOnce executes batches of due transfers until none are left
+func (worker *ScheduledTransferWorker) runOnce(ctx context.Context) error {
+	for {
+		processed, err := worker.store.ExecuteScheduledTransfers(ctx, scheduledTransferBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, scheduled := range processed {
+			if scheduled.Status == db.ScheduledTransferFailed {
+				log.Printf("scheduled transfer [%d] failed: %s", scheduled.ID, scheduled.FailureReason)
+			}
+		}
+		if len(processed) < scheduledTransferBatchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	mockdb "simplebank/db/mock"
+	db "simplebank/db/sqlc"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledTransferWorkerRunOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	full := make([]db.ScheduledTransfer, scheduledTransferBatchSize)
+	gomock.InOrder(
+		store.EXPECT().ExecuteScheduledTransfers(gomock.Any(), gomock.Eq(int32(scheduledTransferBatchSize))).Times(1).Return(full, nil),
+		store.EXPECT().ExecuteScheduledTransfers(gomock.Any(), gomock.Eq(int32(scheduledTransferBatchSize))).Times(1).Return([]db.ScheduledTransfer{
+			{ID: 1, Status: db.ScheduledTransferCompleted},
+			{ID: 2, Status: db.ScheduledTransferFailed, FailureReason: "insufficient funds"},
+		}, nil),
+	)
+
+	worker := NewScheduledTransferWorker(store, time.Minute)
+	err := worker.runOnce(context.Background())
+	require.NoError(t, err)
+}
+
+func TestScheduledTransferWorkerRunOnceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().ExecuteScheduledTransfers(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+
+	worker := NewScheduledTransferWorker(store, time.Minute)
+	err := worker.runOnce(context.Background())
+	require.ErrorIs(t, err, sql.ErrConnDone)
+}
+
+func TestScheduledTransferWorkerRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	store.EXPECT().ExecuteScheduledTransfers(gomock.Any(), gomock.Any()).MinTimes(2).DoAndReturn(
+		func(context.Context, int32) ([]db.ScheduledTransfer, error) {
+			return nil, nil
+		},
+	)
+
+	done := make(chan struct{})
+	go func() {
+		NewScheduledTransferWorker(store, 10*time.Millisecond).Run(ctx)
+		close(done)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker didn't stop after the context was canceled")
+	}
+}
@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"context"
+	"log"
+	db "simplebank/db/sqlc"
+	"time"
+)
+
+// standingOrderBatchSize is how many due standing orders are locked by a single run
+const standingOrderBatchSize = 100
+
+// StandingOrderWorker makes the runs of standing orders once they are due
+type StandingOrderWorker struct {
+	store    db.Store
+	interval time.Duration
+}
+
+// NewStandingOrderWorker creates a worker polling for due standing orders every interval
+func NewStandingOrderWorker(store db.Store, interval time.Duration) *StandingOrderWorker {
+	return &StandingOrderWorker{
+		store:    store,
+		interval: interval,
+	}
+}
+
+// Run processes due standing orders until ctx is done
+func (worker *StandingOrderWorker) Run(ctx context.Context) {
+	runPeriodically(ctx, "standing orders", worker.interval, worker.runOnce)
+}
+
+// runOnce executes batches of due standing orders until none are left.
+// An order that missed several periods gets one run per batch until it catches up
+func (worker *StandingOrderWorker) runOnce(ctx context.Context) error {
+	for {
+		runs, err := worker.store.ExecuteStandingOrders(ctx, standingOrderBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, run := range runs {
+			if run.Status == db.StandingOrderRunFailed {
+				log.Printf("standing order [%d] run for %s failed: %s", run.StandingOrderID, run.RunAt, run.FailureReason)
+			}
+		}
+		if len(runs) == 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	mockdb "simplebank/db/mock"
+	db "simplebank/db/sqlc"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStandingOrderWorkerRunOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	gomock.InOrder(
+		store.EXPECT().ExecuteStandingOrders(gomock.Any(), gomock.Eq(int32(standingOrderBatchSize))).Times(1).Return([]db.StandingOrderRun{
+			{StandingOrderID: 1, Status: db.StandingOrderRunCompleted},
+			{StandingOrderID: 2, Status: db.StandingOrderRunFailed, FailureReason: "insufficient funds"},
+		}, nil),
+		// the first order missed a period and is still due
+		store.EXPECT().ExecuteStandingOrders(gomock.Any(), gomock.Eq(int32(standingOrderBatchSize))).Times(1).Return([]db.StandingOrderRun{
+			{StandingOrderID: 1, Status: db.StandingOrderRunCompleted},
+		}, nil),
+		store.EXPECT().ExecuteStandingOrders(gomock.Any(), gomock.Eq(int32(standingOrderBatchSize))).Times(1).Return([]db.StandingOrderRun{}, nil),
+	)
+
+	worker := NewStandingOrderWorker(store, time.Minute)
+	err := worker.runOnce(context.Background())
+	require.NoError(t, err)
+}
+
+func TestStandingOrderWorkerRunOnceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().ExecuteStandingOrders(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+
+	worker := NewStandingOrderWorker(store, time.Minute)
+	err := worker.runOnce(context.Background())
+	require.ErrorIs(t, err, sql.ErrConnDone)
+}
@@ -0,0 +1,261 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	db "simplebank/db/sqlc"
+	"simplebank/util"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// headers of a webhook delivery. The signature is util.SignWebhook of the timestamp and the body
+const (
+	WebhookIDHeader        = "Webhook-Id"
+	WebhookEventHeader     = "Webhook-Event"
+	WebhookTimestampHeader = "Webhook-Timestamp"
+	WebhookSignatureHeader = "Webhook-Signature"
+)
+
+const (
+	// webhookBatchSize is how many due deliveries are claimed by a single run
+	webhookBatchSize = 10
+	// maxWebhookRetryDelay caps the backoff between two attempts of a delivery
+	maxWebhookRetryDelay = 24 * time.Hour
+	// maxWebhookErrorLength caps the error stored for an attempt
+	maxWebhookErrorLength = 500
+)
+
+// webhookPayload is the body POSTed to a webhook
+type webhookPayload struct {
+	EventID   int64       `json:"event_id"`
+	EventType string      `json:"event_type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// transferWebhookData is the data of transfer.received and transfer.sent,
+// with the account and the entry of the subscriber only
+type transferWebhookData struct {
+	Transfer db.Transfer `json:"transfer"`
+	Account  db.Account  `json:"account"`
+	Entry    db.Entry    `json:"entry"`
+}
+
+// WebhookPublisher turns outbox events into deliveries to the webhooks subscribed to them.
+// A delivery is created once per webhook and event, so publishing an event again is harmless
+type WebhookPublisher struct {
+	store db.Store
+}
+
+// NewWebhookPublisher creates a publisher storing deliveries for the webhook worker
+func NewWebhookPublisher(store db.Store) *WebhookPublisher {
+	return &WebhookPublisher{store: store}
+}
+
+// Publish creates the deliveries of an event. Events no webhook can subscribe to are skipped
+func (publisher *WebhookPublisher) Publish(ctx context.Context, event db.OutboxEvent) error {
+	switch event.EventType {
+	case db.EventTransferCompleted:
+		var result db.TransferTxResult
+		if err := json.Unmarshal(event.Payload, &result); err != nil {
+			return err
+		}
+		received := transferWebhookData{Transfer: result.Transfer, Account: result.ToAccount, Entry: result.ToEntry}
+		err := publisher.addDeliveries(ctx, event, result.ToAccount.Owner, util.WebhookTransferReceived, received)
+		if err != nil {
+			return err
+		}
+		sent := transferWebhookData{Transfer: result.Transfer, Account: result.FromAccount, Entry: result.FromEntry}
+		return publisher.addDeliveries(ctx, event, result.FromAccount.Owner, util.WebhookTransferSent, sent)
+	case db.EventAccountCreated:
+		var account db.Account
+		if err := json.Unmarshal(event.Payload, &account); err != nil {
+			return err
+		}
+		return publisher.addDeliveries(ctx, event, account.Owner, util.WebhookAccountCreated, account)
+	}
+	return nil
+}
+
+// addDeliveries creates a delivery for every webhook of owner subscribed to eventType
+func (publisher *WebhookPublisher) addDeliveries(ctx context.Context, event db.OutboxEvent, owner string, eventType string, data interface{}) error {
+	payload, err := json.Marshal(webhookPayload{
+		EventID:   event.ID,
+		EventType: eventType,
+		CreatedAt: event.CreatedAt,
+		Data:      data,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = publisher.store.CreateWebhookDeliveries(ctx, db.CreateWebhookDeliveriesParams{
+		EventID:   event.ID,
+		EventType: eventType,
+		Payload:   payload,
+		Owner:     owner,
+	})
+	return err
+}
+
+// NewWebhookClient creates the client to deliver webhooks with. It connects only to public addresses,
+// checked once the host is resolved, so a host that resolves to an internal address by the time
+// of a delivery is refused as well as an internal address in the URL. Proxies aren't used for the same reason
+func NewWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || !util.IsPublicIP(ip) {
+				return fmt.Errorf("%w: %s", util.ErrWebhookAddress, host)
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// WebhookWorker POSTs due deliveries to their webhooks and retries the failed ones
+// with exponential backoff until they succeed or run out of attempts
+type WebhookWorker struct {
+	store       db.Store
+	client      *http.Client
+	interval    time.Duration
+	maxAttempts int32
+	retryDelay  time.Duration
+}
+
+// NewWebhookWorker creates a worker polling for due deliveries every interval.
+// A failed delivery is retried after retryDelay, doubled after every attempt, up to maxAttempts in total
+func NewWebhookWorker(store db.Store, client *http.Client, interval time.Duration, maxAttempts int32, retryDelay time.Duration) *WebhookWorker {
+	return &WebhookWorker{
+		store:       store,
+		client:      client,
+		interval:    interval,
+		maxAttempts: maxAttempts,
+		retryDelay:  retryDelay,
+	}
+}
+
+// Run delivers webhooks until ctx is done
+func (worker *WebhookWorker) Run(ctx context.Context) {
+	runPeriodically(ctx, "webhook", worker.interval, worker.runOnce)
+}
+
+// runOnce delivers batches of due deliveries until none are left. The deliveries are claimed
+// for as long as the batch may take, so other workers don't send them at the same time
+func (worker *WebhookWorker) runOnce(ctx context.Context) error {
+	for {
+		lease := time.Duration(webhookBatchSize)*worker.client.Timeout + time.Minute
+		deliveries, err := worker.store.ClaimDueWebhookDeliveries(ctx, db.ClaimDueWebhookDeliveriesParams{
+			LeaseUntil: time.Now().Add(lease),
+			Limit:      webhookBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, delivery := range deliveries {
+			err = worker.deliver(ctx, delivery)
+			if err != nil {
+				return err
+			}
+		}
+		if len(deliveries) < webhookBatchSize {
+			return nil
+		}
+	}
+}
+
+// deliver makes one attempt of a delivery and records it
+func (worker *WebhookWorker) deliver(ctx context.Context, delivery db.WebhookDelivery) error {
+	subscription, err := worker.store.GetWebhookSubscription(ctx, delivery.SubscriptionID)
+	if err != nil {
+		return err
+	}
+
+	statusCode, err := worker.post(ctx, subscription, delivery)
+	if ctx.Err() != nil {
+		// the attempt was cut short by the shutdown, it is made again after the lease
+		return ctx.Err()
+	}
+
+	arg := db.RecordWebhookAttemptTxParams{
+		DeliveryID:    delivery.ID,
+		StatusCode:    statusCode,
+		Status:        db.WebhookDeliveryDelivered,
+		NextAttemptAt: time.Now(),
+	}
+	if err != nil {
+		arg.Error = err.Error()
+		if len(arg.Error) > maxWebhookErrorLength {
+			arg.Error = arg.Error[:maxWebhookErrorLength]
+		}
+
+		attempts := delivery.Attempts + 1
+		if attempts < worker.maxAttempts {
+			arg.Status = db.WebhookDeliveryPending
+			arg.NextAttemptAt = arg.NextAttemptAt.Add(worker.backoff(attempts))
+		} else {
+			arg.Status = db.WebhookDeliveryFailed
+		}
+	}
+
+	_, err = worker.store.RecordWebhookAttemptTx(ctx, arg)
+	return err
+}
+
+// post sends the payload of a delivery signed with the secret of its webhook.
+// Any status but 2xx is an error. The status is 0 if there was no response
+func (worker *WebhookWorker) post(ctx context.Context, subscription db.WebhookSubscription, delivery db.WebhookDelivery) (int32, error) {
+	timestamp := time.Now().Unix()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookIDHeader, strconv.FormatInt(delivery.ID, 10))
+	request.Header.Set(WebhookEventHeader, delivery.EventType)
+	request.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(WebhookSignatureHeader, "sha256="+util.SignWebhook(subscription.Secret, timestamp, delivery.Payload))
+
+	response, err := worker.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	// read a bit of the body, so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return int32(response.StatusCode), fmt.Errorf("unexpected status %s", response.Status)
+	}
+	return int32(response.StatusCode), nil
+}
+
+// backoff returns the delay before the next attempt after the given number of failed ones
+func (worker *WebhookWorker) backoff(attempts int32) time.Duration {
+	delay := worker.retryDelay
+	for i := int32(1); i < attempts && delay < maxWebhookRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxWebhookRetryDelay {
+		delay = maxWebhookRetryDelay
+	}
+	return delay
+}
@@ -0,0 +1,217 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	mockdb "simplebank/db/mock"
+	db "simplebank/db/sqlc"
+	"simplebank/util"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookPublisherTransferCompleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	result := db.TransferTxResult{
+		Transfer:    db.Transfer{ID: 7, FromAccountID: 1, ToAccountID: 2, Amount: 10, ToAmount: 10},
+		FromAccount: db.Account{ID: 1, Owner: "alice", Balance: 90, Currency: util.USD},
+		ToAccount:   db.Account{ID: 2, Owner: "bob", Balance: 110, Currency: util.USD},
+		FromEntry:   db.Entry{ID: 11, AccountID: 1, Amount: -10},
+		ToEntry:     db.Entry{ID: 12, AccountID: 2, Amount: 10},
+	}
+	payload, err := json.Marshal(result)
+	require.NoError(t, err)
+	event := db.OutboxEvent{ID: 3, EventType: db.EventTransferCompleted, Payload: payload}
+
+	var deliveries []db.CreateWebhookDeliveriesParams
+	store.EXPECT().CreateWebhookDeliveries(gomock.Any(), gomock.Any()).Times(2).
+		DoAndReturn(func(ctx context.Context, arg db.CreateWebhookDeliveriesParams) (int64, error) {
+			deliveries = append(deliveries, arg)
+			return 1, nil
+		})
+
+	err = NewWebhookPublisher(store).Publish(context.Background(), event)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+
+	require.Equal(t, "bob", deliveries[0].Owner)
+	require.Equal(t, util.WebhookTransferReceived, deliveries[0].EventType)
+	require.Equal(t, "alice", deliveries[1].Owner)
+	require.Equal(t, util.WebhookTransferSent, deliveries[1].EventType)
+
+	var received struct {
+		EventID   int64               `json:"event_id"`
+		EventType string              `json:"event_type"`
+		Data      transferWebhookData `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(deliveries[0].Payload, &received))
+	require.Equal(t, event.ID, received.EventID)
+	require.Equal(t, util.WebhookTransferReceived, received.EventType)
+	require.Equal(t, result.ToAccount, received.Data.Account)
+	require.Equal(t, result.ToEntry, received.Data.Entry)
+	// the sender's balance is not sent to the recipient
+	require.NotContains(t, string(deliveries[0].Payload), `"balance":90`)
+}
+
+func TestWebhookPublisherSkipsOtherEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().CreateWebhookDeliveries(gomock.Any(), gomock.Any()).Times(0)
+
+	event := db.OutboxEvent{ID: 1, EventType: db.EventUserRegistered, Payload: []byte(`{}`)}
+	err := NewWebhookPublisher(store).Publish(context.Background(), event)
+	require.NoError(t, err)
+}
+
+func TestWebhookWorkerRunOnce(t *testing.T) {
+	subscription := db.WebhookSubscription{ID: 5, Owner: "bob", Secret: "secret"}
+	delivery := db.WebhookDelivery{
+		ID:             9,
+		SubscriptionID: subscription.ID,
+		EventType:      util.WebhookTransferReceived,
+		Payload:        []byte(`{"event_id":3}`),
+		Status:         db.WebhookDeliveryPending,
+	}
+
+	testCases := []struct {
+		name       string
+		statusCode int
+		attempts   int32
+		check      func(t *testing.T, arg db.RecordWebhookAttemptTxParams)
+	}{
+		{
+			name:       "Delivered",
+			statusCode: http.StatusNoContent,
+			check: func(t *testing.T, arg db.RecordWebhookAttemptTxParams) {
+				require.Equal(t, db.WebhookDeliveryDelivered, arg.Status)
+				require.Equal(t, int32(http.StatusNoContent), arg.StatusCode)
+				require.Empty(t, arg.Error)
+			},
+		},
+		{
+			name:       "Retried",
+			statusCode: http.StatusInternalServerError,
+			attempts:   1,
+			check: func(t *testing.T, arg db.RecordWebhookAttemptTxParams) {
+				require.Equal(t, db.WebhookDeliveryPending, arg.Status)
+				require.Equal(t, int32(http.StatusInternalServerError), arg.StatusCode)
+				require.Contains(t, arg.Error, "500")
+				// second failed attempt, so twice the retry delay
+				require.WithinDuration(t, time.Now().Add(2*time.Minute), arg.NextAttemptAt, time.Second)
+			},
+		},
+		{
+			name:       "Failed",
+			statusCode: http.StatusBadGateway,
+			attempts:   2,
+			check: func(t *testing.T, arg db.RecordWebhookAttemptTxParams) {
+				require.Equal(t, db.WebhookDeliveryFailed, arg.Status)
+				require.Equal(t, int32(http.StatusBadGateway), arg.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.Equal(t, string(delivery.Payload), string(body))
+				require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				require.Equal(t, strconv.FormatInt(delivery.ID, 10), r.Header.Get(WebhookIDHeader))
+				require.Equal(t, delivery.EventType, r.Header.Get(WebhookEventHeader))
+
+				timestamp, err := strconv.ParseInt(r.Header.Get(WebhookTimestampHeader), 10, 64)
+				require.NoError(t, err)
+				signature := strings.TrimPrefix(r.Header.Get(WebhookSignatureHeader), "sha256=")
+				require.True(t, util.VerifyWebhook(subscription.Secret, timestamp, body, signature))
+
+				w.WriteHeader(tc.statusCode)
+			}))
+			defer receiver.Close()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			sub := subscription
+			sub.Url = receiver.URL
+			due := delivery
+			due.Attempts = tc.attempts
+
+			store.EXPECT().ClaimDueWebhookDeliveries(gomock.Any(), gomock.Any()).Times(1).Return([]db.WebhookDelivery{due}, nil)
+			store.EXPECT().GetWebhookSubscription(gomock.Any(), gomock.Eq(sub.ID)).Times(1).Return(sub, nil)
+			store.EXPECT().RecordWebhookAttemptTx(gomock.Any(), gomock.Any()).Times(1).
+				DoAndReturn(func(ctx context.Context, arg db.RecordWebhookAttemptTxParams) (db.WebhookDelivery, error) {
+					require.Equal(t, due.ID, arg.DeliveryID)
+					tc.check(t, arg)
+					return db.WebhookDelivery{}, nil
+				})
+
+			worker := NewWebhookWorker(store, receiver.Client(), time.Minute, 3, time.Minute)
+			err := worker.runOnce(context.Background())
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestWebhookWorkerNoResponse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	receiver := httptest.NewServer(http.NotFoundHandler())
+	url := receiver.URL
+	receiver.Close()
+
+	delivery := db.WebhookDelivery{ID: 9, SubscriptionID: 5, Payload: []byte(`{}`)}
+	store.EXPECT().ClaimDueWebhookDeliveries(gomock.Any(), gomock.Any()).Times(1).Return([]db.WebhookDelivery{delivery}, nil)
+	store.EXPECT().GetWebhookSubscription(gomock.Any(), gomock.Any()).Times(1).Return(db.WebhookSubscription{ID: 5, Url: url}, nil)
+	store.EXPECT().RecordWebhookAttemptTx(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(ctx context.Context, arg db.RecordWebhookAttemptTxParams) (db.WebhookDelivery, error) {
+			require.Equal(t, db.WebhookDeliveryPending, arg.Status)
+			require.Zero(t, arg.StatusCode)
+			require.NotEmpty(t, arg.Error)
+			return db.WebhookDelivery{}, nil
+		})
+
+	worker := NewWebhookWorker(store, &http.Client{Timeout: time.Second}, time.Minute, 3, time.Minute)
+	err := worker.runOnce(context.Background())
+	require.NoError(t, err)
+}
+
+func TestWebhookClientRefusesInternalAddresses(t *testing.T) {
+	called := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer receiver.Close()
+
+	// the receiver listens on a loopback address, which a webhook must not reach
+	client := NewWebhookClient(time.Second)
+	_, err := client.Post(receiver.URL, "application/json", strings.NewReader(`{}`))
+	require.ErrorIs(t, err, util.ErrWebhookAddress)
+	require.False(t, called)
+}
+
+func TestWebhookWorkerBackoff(t *testing.T) {
+	worker := NewWebhookWorker(nil, http.DefaultClient, time.Minute, 10, 30*time.Second)
+	require.Equal(t, 30*time.Second, worker.backoff(1))
+	require.Equal(t, time.Minute, worker.backoff(2))
+	require.Equal(t, 4*time.Minute, worker.backoff(4))
+	require.Equal(t, maxWebhookRetryDelay, worker.backoff(100))
+}
@@ -0,0 +1,26 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// runPeriodically calls fn every interval until ctx is done.
+// Errors are logged and the next run happens as scheduled
+func runPeriodically(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := fn(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("%s: %v", name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}